@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/models"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	UserID string `json:"user_id"`
+	Username string `json:"username"`
+	Roles []string `json:"roles"`
+	Scopes []string `json:"scopes"`
+	// jti of the bearer token, or the id of the api key used
+	TokenID string `json:"token_id"`
+	// dealership the caller works for, platform admins have none
+	TenantID uuid.NullUUID `json:"tenant_id"`
+}
+
+type contextKey struct{}
+
+func WithPrincipal(ctx context.Context,principal *Principal) context.Context {
+	return context.WithValue(ctx,contextKey{},principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal,bool) {
+	principal,ok := ctx.Value(contextKey{}).(*Principal)
+	return principal,ok && principal != nil
+}
+
+// UserID returns the caller's user id, or "" for unauthenticated requests.
+func UserID(ctx context.Context) string {
+	if principal,ok := PrincipalFromContext(ctx); ok {
+		return principal.UserID
+	}
+	return ""
+}
+
+// TenantID returns the dealership requests are scoped to, invalid when the
+// caller is a platform admin acting across all dealerships.
+func TenantID(ctx context.Context) uuid.NullUUID {
+	if principal,ok := PrincipalFromContext(ctx); ok {
+		return principal.TenantID
+	}
+	return uuid.NullUUID{}
+}
+
+// Actor names the caller in audit logs.
+func Actor(ctx context.Context) string {
+	principal,ok := PrincipalFromContext(ctx)
+	if !ok {
+		return "anonymous"
+	}
+	if principal.Username != "" && principal.Username != principal.UserID {
+		return principal.Username + " (" + principal.UserID + ")"
+	}
+	return principal.UserID
+}
+
+func (p *Principal) HasRole(role string) bool {
+	return contains(p.Roles,role)
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	return contains(p.Scopes,scope)
+}
+
+func (p *Principal) IsAdmin() bool {
+	return p.HasRole(models.RoleAdmin)
+}
+
+func (p *Principal) IsPlatformAdmin() bool {
+	return p.IsAdmin() && !p.TenantID.Valid
+}
+
+func contains(values []string,value string) bool {
+	for _,v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTenantID reads a dealership id from a claim or header, "" means no tenant.
+func ParseTenantID(s string) (uuid.NullUUID,error) {
+	if s == "" {
+		return uuid.NullUUID{},nil
+	}
+	id,err := uuid.Parse(s)
+	if err != nil {
+		return uuid.NullUUID{},err
+	}
+	return uuid.NullUUID{UUID: id,Valid: true},nil
+}
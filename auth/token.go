@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+var jwtKey = []byte("secret_key")
+
+// Claims are carried by tokens issued from /login, the user id is the subject.
+type Claims struct {
+	UserName string `json:"username"`
+	Roles []string `json:"roles"`
+	TenantID string `json:"tenant_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func GenerateToken(userID,username string,roles []string,tenantID uuid.NullUUID,ttl time.Duration) (string,error) {
+	now := time.Now()
+	claims := Claims{
+		UserName: username,
+		Roles: roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID: uuid.NewString(),
+			Subject: userID,
+			IssuedAt: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	if tenantID.Valid {
+		claims.TenantID = tenantID.UUID.String()
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256,claims)
+	return token.SignedString(jwtKey)
+}
+
+func ParseToken(tokenString string) (*Claims,error) {
+	claims := &Claims{}
+	_,err := jwt.ParseWithClaims(tokenString,claims,func(token *jwt.Token)(interface{},error){
+		return jwtKey,nil
+	},jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil,err
+	}
+	return claims,nil
+}
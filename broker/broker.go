@@ -0,0 +1,48 @@
+// Package broker publishes outbox events to a message broker.
+package broker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Message is one event on its way to a broker. ID is the idempotency key,
+// a message can be published more than once and consumers should drop IDs
+// they have already seen.
+type Message struct {
+	ID string
+	// the event type, e.g. car.updated
+	Subject string
+	// messages with the same key are kept in order where the broker supports it
+	Key string
+	Payload []byte
+}
+
+type Broker interface {
+	Publish(ctx context.Context,msg Message) error
+	Close() error
+}
+
+// FromEnv builds the broker named by BROKER, memory (the default), nats or kafka.
+func FromEnv() (Broker,error) {
+	switch kind := os.Getenv("BROKER"); kind {
+	case "","memory":
+		return NewMemoryBroker(),nil
+	case "nats":
+		return NewNATSBroker(getEnv("NATS_URL","nats://localhost:4222"),getEnv("BROKER_SUBJECT_PREFIX","carzone."))
+	case "kafka":
+		brokers := strings.Split(getEnv("KAFKA_BROKERS","localhost:9092"),",")
+		return NewKafkaBroker(brokers,getEnv("KAFKA_TOPIC","carzone.events")),nil
+	default:
+		return nil,fmt.Errorf("unknown BROKER %q, expected memory, nats or kafka",kind)
+	}
+}
+
+func getEnv(key,fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,40 @@
+package broker
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaBroker writes every event to one topic keyed by Message.Key, so a
+// dealership's events stay in order on their partition. The idempotency key
+// travels in the idempotency-key header for consumers to deduplicate on.
+type KafkaBroker struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaBroker(brokers []string,topic string) *KafkaBroker {
+	return &KafkaBroker{
+		writer: &kafka.Writer{
+			Addr: kafka.TCP(brokers...),
+			Topic: topic,
+			Balancer: &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+func (b *KafkaBroker)Publish(ctx context.Context,msg Message) error {
+	return b.writer.WriteMessages(ctx,kafka.Message{
+		Key: []byte(msg.Key),
+		Value: msg.Payload,
+		Headers: []kafka.Header{
+			{Key: "idempotency-key",Value: []byte(msg.ID)},
+			{Key: "event-type",Value: []byte(msg.Subject)},
+		},
+	})
+}
+
+func (b *KafkaBroker)Close() error {
+	return b.writer.Close()
+}
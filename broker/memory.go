@@ -0,0 +1,66 @@
+package broker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iangechuki/go_carzone/models"
+)
+
+// MemoryBroker hands messages to in process subscribers, it is the default
+// when no external broker is configured and drops duplicate IDs itself.
+type MemoryBroker struct {
+	mu sync.RWMutex
+	subscribers []subscriber
+	seen map[string]bool
+	order []string
+}
+
+type subscriber struct {
+	filter string
+	handler func(Message)
+}
+
+// how many message IDs are remembered for deduplication
+const memorySeenLimit = 10000
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		seen: make(map[string]bool),
+	}
+}
+
+// Subscribe calls handler for every message whose subject matches filter,
+// which takes the same forms as webhook event filters.
+func (b *MemoryBroker)Subscribe(filter string,handler func(Message)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers,subscriber{filter: filter,handler: handler})
+}
+
+func (b *MemoryBroker)Publish(ctx context.Context,msg Message) error {
+	b.mu.Lock()
+	if b.seen[msg.ID] {
+		b.mu.Unlock()
+		return nil
+	}
+	b.seen[msg.ID] = true
+	b.order = append(b.order,msg.ID)
+	if len(b.order) > memorySeenLimit {
+		delete(b.seen,b.order[0])
+		b.order = b.order[1:]
+	}
+	subscribers := append([]subscriber{},b.subscribers...)
+	b.mu.Unlock()
+
+	for _,sub := range subscribers {
+		if models.MatchEvent(sub.filter,msg.Subject) {
+			sub.handler(msg)
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBroker)Close() error {
+	return nil
+}
@@ -0,0 +1,80 @@
+package broker
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func TestMemoryBrokerRoutesBySubject(t *testing.T) {
+	b := NewMemoryBroker()
+	got := map[string][]string{}
+	for _,filter := range []string{"*","car.*","car.updated","engine.*"} {
+		b.Subscribe(filter,func(msg Message) {
+			got[filter] = append(got[filter],msg.Subject)
+		})
+	}
+	for i,subject := range []string{"car.created","car.updated","engine.deleted"} {
+		if err := b.Publish(context.Background(),Message{ID: strconv.Itoa(i),Subject: subject}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		filter string
+		want []string
+	}{
+		{filter: "*",want: []string{"car.created","car.updated","engine.deleted"}},
+		{filter: "car.*",want: []string{"car.created","car.updated"}},
+		{filter: "car.updated",want: []string{"car.updated"}},
+		{filter: "engine.*",want: []string{"engine.deleted"}},
+	}
+	for _,tt := range tests {
+		if len(got[tt.filter]) != len(tt.want) {
+			t.Errorf("%s received %v, want %v",tt.filter,got[tt.filter],tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if got[tt.filter][i] != tt.want[i] {
+				t.Errorf("%s received %v, want %v",tt.filter,got[tt.filter],tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestMemoryBrokerDropsDuplicates(t *testing.T) {
+	b := NewMemoryBroker()
+	delivered := 0
+	b.Subscribe("*",func(Message) {
+		delivered++
+	})
+	msg := Message{ID: "event-1",Subject: "car.updated"}
+	for i := 0; i < 3; i++ {
+		if err := b.Publish(context.Background(),msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if delivered != 1 {
+		t.Errorf("delivered %d times, want once",delivered)
+	}
+}
+
+func TestMemoryBrokerForgetsOldIDs(t *testing.T) {
+	b := NewMemoryBroker()
+	delivered := 0
+	b.Subscribe("*",func(Message) {
+		delivered++
+	})
+	// the first ID is pushed out once the limit is passed and is delivered again
+	for i := 0; i <= memorySeenLimit; i++ {
+		b.Publish(context.Background(),Message{ID: strconv.Itoa(i),Subject: "car.updated"})
+	}
+	b.Publish(context.Background(),Message{ID: "0",Subject: "car.updated"})
+	if delivered != memorySeenLimit + 2 {
+		t.Errorf("delivered %d, want %d",delivered,memorySeenLimit + 2)
+	}
+	if len(b.seen) != memorySeenLimit || len(b.order) != memorySeenLimit {
+		t.Errorf("remembers %d IDs in a %d long order, want %d",len(b.seen),len(b.order),memorySeenLimit)
+	}
+}
@@ -0,0 +1,58 @@
+package broker
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSBroker publishes to JetStream, the message ID is sent as Nats-Msg-Id so
+// the stream drops redeliveries within its duplicate window.
+type NATSBroker struct {
+	conn *nats.Conn
+	js jetstream.JetStream
+	prefix string
+}
+
+func NewNATSBroker(url string,prefix string) (*NATSBroker,error) {
+	conn,err := nats.Connect(url,nats.Name("carzone-outbox"))
+	if err != nil {
+		return nil,err
+	}
+	js,err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil,err
+	}
+	// the stream has to exist before JetStream accepts publishes on its subjects
+	ctx,cancel := context.WithTimeout(context.Background(),10 * time.Second)
+	defer cancel()
+	_,err = js.CreateOrUpdateStream(ctx,jetstream.StreamConfig{
+		Name: "CARZONE_EVENTS",
+		Subjects: []string{prefix + ">"},
+		Duplicates: 10 * time.Minute,
+	})
+	if err != nil {
+		conn.Close()
+		return nil,err
+	}
+	return &NATSBroker{conn: conn,js: js,prefix: prefix},nil
+}
+
+func (b *NATSBroker)Publish(ctx context.Context,msg Message) error {
+	_,err := b.js.PublishMsg(ctx,&nats.Msg{
+		Subject: b.prefix + msg.Subject,
+		Data: msg.Payload,
+		Header: nats.Header{
+			nats.MsgIdHdr: []string{msg.ID},
+			"Carzone-Key": []string{msg.Key},
+		},
+	})
+	return err
+}
+
+func (b *NATSBroker)Close() error {
+	return b.conn.Drain()
+}
@@ -0,0 +1,5633 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.8.0 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for BodyType.
+const (
+	Convertible BodyType = "convertible"
+	Coupe       BodyType = "coupe"
+	Hatchback   BodyType = "hatchback"
+	Minivan     BodyType = "minivan"
+	Pickup      BodyType = "pickup"
+	Sedan       BodyType = "sedan"
+	Suv         BodyType = "suv"
+	Van         BodyType = "van"
+	Wagon       BodyType = "wagon"
+)
+
+// Valid indicates whether the value is a known member of the BodyType enum.
+func (e BodyType) Valid() bool {
+	switch e {
+	case Convertible:
+		return true
+	case Coupe:
+		return true
+	case Hatchback:
+		return true
+	case Minivan:
+		return true
+	case Pickup:
+		return true
+	case Sedan:
+		return true
+	case Suv:
+		return true
+	case Van:
+		return true
+	case Wagon:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for CarRequestStatus.
+const (
+	CarRequestStatusAvailable CarRequestStatus = "available"
+	CarRequestStatusDraft     CarRequestStatus = "draft"
+)
+
+// Valid indicates whether the value is a known member of the CarRequestStatus enum.
+func (e CarRequestStatus) Valid() bool {
+	switch e {
+	case CarRequestStatusAvailable:
+		return true
+	case CarRequestStatusDraft:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for CarStatus.
+const (
+	CarStatusArchived  CarStatus = "archived"
+	CarStatusAvailable CarStatus = "available"
+	CarStatusDraft     CarStatus = "draft"
+	CarStatusReserved  CarStatus = "reserved"
+	CarStatusSold      CarStatus = "sold"
+)
+
+// Valid indicates whether the value is a known member of the CarStatus enum.
+func (e CarStatus) Valid() bool {
+	switch e {
+	case CarStatusArchived:
+		return true
+	case CarStatusAvailable:
+		return true
+	case CarStatusDraft:
+		return true
+	case CarStatusReserved:
+		return true
+	case CarStatusSold:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ChargingStandard.
+const (
+	Ccs1    ChargingStandard = "ccs1"
+	Ccs2    ChargingStandard = "ccs2"
+	Chademo ChargingStandard = "chademo"
+	Gbt     ChargingStandard = "gbt"
+	Nacs    ChargingStandard = "nacs"
+	Type1   ChargingStandard = "type1"
+	Type2   ChargingStandard = "type2"
+)
+
+// Valid indicates whether the value is a known member of the ChargingStandard enum.
+func (e ChargingStandard) Valid() bool {
+	switch e {
+	case Ccs1:
+		return true
+	case Ccs2:
+		return true
+	case Chademo:
+		return true
+	case Gbt:
+		return true
+	case Nacs:
+		return true
+	case Type1:
+		return true
+	case Type2:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for Condition.
+const (
+	CertifiedPreOwned Condition = "certified_pre_owned"
+	New               Condition = "new"
+	Used              Condition = "used"
+)
+
+// Valid indicates whether the value is a known member of the Condition enum.
+func (e Condition) Valid() bool {
+	switch e {
+	case CertifiedPreOwned:
+		return true
+	case New:
+		return true
+	case Used:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for Drivetrain.
+const (
+	Awd  Drivetrain = "awd"
+	Fwd  Drivetrain = "fwd"
+	N4wd Drivetrain = "4wd"
+	Rwd  Drivetrain = "rwd"
+)
+
+// Valid indicates whether the value is a known member of the Drivetrain enum.
+func (e Drivetrain) Valid() bool {
+	switch e {
+	case Awd:
+		return true
+	case Fwd:
+		return true
+	case N4wd:
+		return true
+	case Rwd:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for FuelType.
+const (
+	Diesel   FuelType = "Diesel"
+	Electric FuelType = "Electric"
+	Hybrid   FuelType = "Hybrid"
+	Persol   FuelType = "Persol"
+	Petrol   FuelType = "Petrol"
+)
+
+// Valid indicates whether the value is a known member of the FuelType enum.
+func (e FuelType) Valid() bool {
+	switch e {
+	case Diesel:
+		return true
+	case Electric:
+		return true
+	case Hybrid:
+		return true
+	case Persol:
+		return true
+	case Petrol:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for Transmission.
+const (
+	Automatic Transmission = "automatic"
+	Cvt       Transmission = "cvt"
+	Dct       Transmission = "dct"
+	Manual    Transmission = "manual"
+)
+
+// Valid indicates whether the value is a known member of the Transmission enum.
+func (e Transmission) Valid() bool {
+	switch e {
+	case Automatic:
+		return true
+	case Cvt:
+		return true
+	case Dct:
+		return true
+	case Manual:
+		return true
+	default:
+		return false
+	}
+}
+
+// BodyType defines model for BodyType.
+type BodyType string
+
+// Brand defines model for Brand.
+type Brand struct {
+	Aliases   []string           `json:"aliases"`
+	CreatedAt *time.Time         `json:"created_at,omitempty"`
+	Id        openapi_types.UUID `json:"id"`
+	Models    []CarModel         `json:"models"`
+	Name      string             `json:"name"`
+	UpdatedAt *time.Time         `json:"updated_at,omitempty"`
+}
+
+// BrandRequest defines model for BrandRequest.
+type BrandRequest struct {
+	// Aliases Spellings that only differ from the name or each other in case or punctuation are dropped
+	Aliases *[]string `json:"aliases,omitempty"`
+	Name    string    `json:"name"`
+}
+
+// Car defines model for Car.
+type Car struct {
+	BodyType *BodyType `json:"body_type,omitempty"`
+
+	// Brand Spelled the way the catalogue spells it
+	Brand      string      `json:"brand"`
+	Colour     *string     `json:"colour,omitempty"`
+	Condition  *Condition  `json:"condition,omitempty"`
+	CreatedAt  *time.Time  `json:"created_at,omitempty"`
+	CreatedBy  *string     `json:"created_by,omitempty"`
+	Drivetrain *Drivetrain `json:"drivetrain,omitempty"`
+	Engine     Engine      `json:"engine"`
+	Features   *[]string   `json:"features,omitempty"`
+
+	// FuelType Petrol is answered as Persol, its spelling before /v2. Requests may use either.
+	FuelType      FuelType            `json:"fuelType"`
+	Id            openapi_types.UUID  `json:"id"`
+	Model         *string             `json:"model,omitempty"`
+	Name          string              `json:"name"`
+	OdometerKm    *int                `json:"odometer_km,omitempty"`
+	Price         float64             `json:"price"`
+	ReservedBy    *string             `json:"reserved_by,omitempty"`
+	ReservedUntil *time.Time          `json:"reserved_until,omitempty"`
+	SoldAt        *time.Time          `json:"sold_at,omitempty"`
+	Status        CarStatus           `json:"status"`
+	TenantId      *openapi_types.UUID `json:"tenant_id,omitempty"`
+	Transmission  *Transmission       `json:"transmission,omitempty"`
+	UpdatedAt     *time.Time          `json:"updated_at,omitempty"`
+
+	// Vin Upper case, unique within a dealership
+	Vin  *string `json:"vin,omitempty"`
+	Year string  `json:"year"`
+}
+
+// CarModel defines model for CarModel.
+type CarModel struct {
+	Aliases   []string           `json:"aliases"`
+	BrandId   openapi_types.UUID `json:"brand_id"`
+	CreatedAt *time.Time         `json:"created_at,omitempty"`
+	Id        openapi_types.UUID `json:"id"`
+	Name      string             `json:"name"`
+	UpdatedAt *time.Time         `json:"updated_at,omitempty"`
+}
+
+// CarModelRequest defines model for CarModelRequest.
+type CarModelRequest = BrandRequest
+
+// CarRequest defines model for CarRequest.
+type CarRequest struct {
+	BodyType *BodyType `json:"body_type,omitempty"`
+
+	// Brand Any spelling the catalogue knows, unknown brands are rejected with 422
+	Brand      string      `json:"brand"`
+	Colour     *string     `json:"colour,omitempty"`
+	Condition  *Condition  `json:"condition,omitempty"`
+	Drivetrain *Drivetrain `json:"drivetrain,omitempty"`
+	Engine     Engine      `json:"engine"`
+	Features   *[]string   `json:"features,omitempty"`
+
+	// FuelType Petrol is answered as Persol, its spelling before /v2. Requests may use either.
+	FuelType FuelType `json:"fuelType"`
+
+	// Model Once the brand has models in the catalogue, one of them or an alias
+	Model      *string `json:"model,omitempty"`
+	Name       string  `json:"name"`
+	OdometerKm *int    `json:"odometer_km,omitempty"`
+	Price      float64 `json:"price"`
+
+	// Status Only draft or available, later statuses go through the status endpoints
+	Status       *CarRequestStatus `json:"status,omitempty"`
+	Transmission *Transmission     `json:"transmission,omitempty"`
+
+	// Vin Checked against its check digit, case is ignored
+	Vin  *string `json:"vin,omitempty"`
+	Year string  `json:"year"`
+}
+
+// CarRequestStatus Only draft or available, later statuses go through the status endpoints
+type CarRequestStatus string
+
+// CarStatus defines model for CarStatus.
+type CarStatus string
+
+// ChargingStandard defines model for ChargingStandard.
+type ChargingStandard string
+
+// Condition defines model for Condition.
+type Condition string
+
+// Credentials defines model for Credentials.
+type Credentials struct {
+	Password string `json:"password"`
+	Username string `json:"username"`
+}
+
+// Drivetrain defines model for Drivetrain.
+type Drivetrain string
+
+// Engine defines model for Engine.
+type Engine struct {
+	BatteryCapacityKwh *float64           `json:"battery_capacity_kwh,omitempty"`
+	CarRange           *int64             `json:"car_range,omitempty"`
+	ChargingStandard   *ChargingStandard  `json:"charging_standard,omitempty"`
+	Co2GPerKm          *int64             `json:"co2_g_per_km,omitempty"`
+	Displacement       *int64             `json:"displacement,omitempty"`
+	EngineId           openapi_types.UUID `json:"engine_id"`
+
+	// FuelType Petrol is answered as Persol, its spelling before /v2. Requests may use either.
+	FuelType      *FuelType           `json:"fuel_type,omitempty"`
+	Horsepower    *int64              `json:"horsepower,omitempty"`
+	MotorPowerKw  *int64              `json:"motor_power_kw,omitempty"`
+	NoOfCylinders *int64              `json:"no_of_cylinders,omitempty"`
+	TenantId      *openapi_types.UUID `json:"tenant_id,omitempty"`
+	TorqueNm      *int64              `json:"torque_nm,omitempty"`
+	UpdatedAt     *time.Time          `json:"updated_at,omitempty"`
+}
+
+// EngineRequest Electric engines have no displacement, cylinders or CO2 emissions and
+// need a battery, motor and charging standard. Hybrids need the
+// combustion specs and a battery and motor. Any other fuel type, or
+// none, needs a displacement and cylinders and has no battery, motor or
+// charging standard.
+type EngineRequest struct {
+	BatteryCapacityKwh *float64          `json:"battery_capacity_kwh,omitempty"`
+	CarRange           int64             `json:"car_range"`
+	ChargingStandard   *ChargingStandard `json:"charging_standard,omitempty"`
+	Co2GPerKm          *int64            `json:"co2_g_per_km,omitempty"`
+	Displacement       *int64            `json:"displacement,omitempty"`
+
+	// FuelType Petrol is answered as Persol, its spelling before /v2. Requests may use either.
+	FuelType      *FuelType `json:"fuel_type,omitempty"`
+	Horsepower    *int64    `json:"horsepower,omitempty"`
+	MotorPowerKw  *int64    `json:"motor_power_kw,omitempty"`
+	NoOfCylinders *int64    `json:"no_of_cylinders,omitempty"`
+	TorqueNm      *int64    `json:"torque_nm,omitempty"`
+}
+
+// FuelType Petrol is answered as Persol, its spelling before /v2. Requests may use either.
+type FuelType string
+
+// ReservationRequest defines model for ReservationRequest.
+type ReservationRequest struct {
+	// ExpiresAt Defaults to 48 hours from now, at most 14 days
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// StatusRequest defines model for StatusRequest.
+type StatusRequest struct {
+	Status CarStatus `json:"status"`
+}
+
+// Token defines model for Token.
+type Token struct {
+	Token string `json:"token"`
+}
+
+// Transmission defines model for Transmission.
+type Transmission string
+
+// VINInfo defines model for VINInfo.
+type VINInfo struct {
+	Brand        *string `json:"brand,omitempty"`
+	Country      *string `json:"country,omitempty"`
+	Manufacturer *string `json:"manufacturer,omitempty"`
+
+	// Model Only known to an external provider
+	Model        *string `json:"model,omitempty"`
+	ModelYear    *int    `json:"model_year,omitempty"`
+	Plant        *string `json:"plant,omitempty"`
+	PlantCode    string  `json:"plant_code"`
+	Region       string  `json:"region"`
+	SerialNumber string  `json:"serial_number"`
+
+	// Source wmi for the built in tables, otherwise the provider's name
+	Source string `json:"source"`
+	Vin    string `json:"vin"`
+
+	// Wmi World manufacturer identifier, the first three characters
+	Wmi string `json:"wmi"`
+}
+
+// ID defines model for ID.
+type ID = openapi_types.UUID
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// TenantID defines model for TenantID.
+type TenantID = openapi_types.UUID
+
+// ListCarsParams defines parameters for ListCars.
+type ListCarsParams struct {
+	// Brand Any spelling the catalogue knows, no cars are listed without it
+	Brand *string `form:"brand,omitempty" json:"brand,omitempty"`
+
+	// IsEngine Include the engine specs of each car
+	IsEngine *bool `form:"isEngine,omitempty" json:"isEngine,omitempty"`
+
+	// Status Comma separated car statuses
+	Status       *string       `form:"status,omitempty" json:"status,omitempty"`
+	Vin          *string       `form:"vin,omitempty" json:"vin,omitempty"`
+	Transmission *Transmission `form:"transmission,omitempty" json:"transmission,omitempty"`
+	Drivetrain   *Drivetrain   `form:"drivetrain,omitempty" json:"drivetrain,omitempty"`
+	BodyType     *BodyType     `form:"body_type,omitempty" json:"body_type,omitempty"`
+	Condition    *Condition    `form:"condition,omitempty" json:"condition,omitempty"`
+
+	// Colour Matched ignoring case
+	Colour        *string `form:"colour,omitempty" json:"colour,omitempty"`
+	MaxOdometerKm *int    `form:"max_odometer_km,omitempty" json:"max_odometer_km,omitempty"`
+
+	// Features Comma separated features a car must all have
+	Features *string `form:"features,omitempty" json:"features,omitempty"`
+
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// CreateCarParams defines parameters for CreateCar.
+type CreateCarParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+
+	// IdempotencyKey Replays the first response for retries with the same key and body
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// StreamCarsParams defines parameters for StreamCars.
+type StreamCarsParams struct {
+	// Brand Comma separated brands to stream, all when left out
+	Brand *string `form:"brand,omitempty" json:"brand,omitempty"`
+
+	// LastEventId Last-Event-ID for clients that can't set headers
+	LastEventId *string `form:"last_event_id,omitempty" json:"last_event_id,omitempty"`
+
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+
+	// LastEventID Resume after this event
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// DeleteCarParams defines parameters for DeleteCar.
+type DeleteCarParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// GetCarParams defines parameters for GetCar.
+type GetCarParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// UpdateCarParams defines parameters for UpdateCar.
+type UpdateCarParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// CancelReservationParams defines parameters for CancelReservation.
+type CancelReservationParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// ReserveCarParams defines parameters for ReserveCar.
+type ReserveCarParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// MarkCarSoldParams defines parameters for MarkCarSold.
+type MarkCarSoldParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// ChangeCarStatusParams defines parameters for ChangeCarStatus.
+type ChangeCarStatusParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// ListEnginesParams defines parameters for ListEngines.
+type ListEnginesParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// CreateEngineParams defines parameters for CreateEngine.
+type CreateEngineParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+
+	// IdempotencyKey Replays the first response for retries with the same key and body
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// DeleteEngineParams defines parameters for DeleteEngine.
+type DeleteEngineParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// GetEngineParams defines parameters for GetEngine.
+type GetEngineParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// UpdateEngineParams defines parameters for UpdateEngine.
+type UpdateEngineParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// ListMyCarsParams defines parameters for ListMyCars.
+type ListMyCarsParams struct {
+	// XTenantID Dealership to act in, only honoured for platform admins
+	XTenantID *TenantID `json:"X-Tenant-ID,omitempty"`
+}
+
+// CreateBrandJSONRequestBody defines body for CreateBrand for application/json ContentType.
+type CreateBrandJSONRequestBody = BrandRequest
+
+// UpdateBrandJSONRequestBody defines body for UpdateBrand for application/json ContentType.
+type UpdateBrandJSONRequestBody = BrandRequest
+
+// CreateModelJSONRequestBody defines body for CreateModel for application/json ContentType.
+type CreateModelJSONRequestBody = CarModelRequest
+
+// UpdateModelJSONRequestBody defines body for UpdateModel for application/json ContentType.
+type UpdateModelJSONRequestBody = CarModelRequest
+
+// CreateCarJSONRequestBody defines body for CreateCar for application/json ContentType.
+type CreateCarJSONRequestBody = CarRequest
+
+// UpdateCarJSONRequestBody defines body for UpdateCar for application/json ContentType.
+type UpdateCarJSONRequestBody = CarRequest
+
+// ReserveCarJSONRequestBody defines body for ReserveCar for application/json ContentType.
+type ReserveCarJSONRequestBody = ReservationRequest
+
+// ChangeCarStatusJSONRequestBody defines body for ChangeCarStatus for application/json ContentType.
+type ChangeCarStatusJSONRequestBody = StatusRequest
+
+// CreateEngineJSONRequestBody defines body for CreateEngine for application/json ContentType.
+type CreateEngineJSONRequestBody = EngineRequest
+
+// UpdateEngineJSONRequestBody defines body for UpdateEngine for application/json ContentType.
+type UpdateEngineJSONRequestBody = EngineRequest
+
+// LoginJSONRequestBody defines body for Login for application/json ContentType.
+type LoginJSONRequestBody = Credentials
+
+// RequestEditorFn is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+
+	// ListBrands List the brand catalogue
+	//
+	// Car brands and models are checked against the catalogue and stored
+	// the way it spells them, any alias resolves to its brand or model.
+	// Spellings are matched on their letters and digits ignoring case.
+	//
+	// Corresponds with GET /brands (the `ListBrands` operationId).
+	ListBrands(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateBrandWithBody Add a brand, platform admins only
+	//
+	// Takes any type of body and a specified content type.
+	//
+	// Corresponds with POST /brands (the `CreateBrand` operationId).
+	CreateBrandWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateBrand Add a brand, platform admins only
+	//
+	// Takes a body of the `application/json` content type.
+	//
+	// Corresponds with POST /brands (the `CreateBrand` operationId).
+	CreateBrand(ctx context.Context, body CreateBrandJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteBrand Delete a brand and its models, platform admins only
+	//
+	// Corresponds with DELETE /brands/{id} (the `DeleteBrand` operationId).
+	DeleteBrand(ctx context.Context, id ID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBrand Get a brand with its models
+	//
+	// Corresponds with GET /brands/{id} (the `GetBrand` operationId).
+	GetBrand(ctx context.Context, id ID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateBrandWithBody Rename a brand and replace its aliases, platform admins only
+	//
+	// Cars listed under the old name are renamed along with it.
+	//
+	// Takes any type of body and a specified content type.
+	//
+	// Corresponds with PUT /brands/{id} (the `UpdateBrand` operationId).
+	UpdateBrandWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateBrand Rename a brand and replace its aliases, platform admins only
+	//
+	// Cars listed under the old name are renamed along with it.
+	//
+	// Takes a body of the `application/json` content type.
+	//
+	// Corresponds with PUT /brands/{id} (the `UpdateBrand` operationId).
+	UpdateBrand(ctx context.Context, id ID, body UpdateBrandJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateModelWithBody Add a model to a brand, platform admins only
+	//
+	// Once a brand has models, cars of that brand that give a model have
+	// to give one of them.
+	//
+	// Takes any type of body and a specified content type.
+	//
+	// Corresponds with POST /brands/{id}/models (the `CreateModel` operationId).
+	CreateModelWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateModel Add a model to a brand, platform admins only
+	//
+	// Once a brand has models, cars of that brand that give a model have
+	// to give one of them.
+	//
+	// Takes a body of the `application/json` content type.
+	//
+	// Corresponds with POST /brands/{id}/models (the `CreateModel` operationId).
+	CreateModel(ctx context.Context, id ID, body CreateModelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteModel Delete a model, platform admins only
+	//
+	// Corresponds with DELETE /brands/{id}/models/{modelID} (the `DeleteModel` operationId).
+	DeleteModel(ctx context.Context, id ID, modelID openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateModelWithBody Rename a model and replace its aliases, platform admins only
+	//
+	// Cars listed as the old name are renamed along with it.
+	//
+	// Takes any type of body and a specified content type.
+	//
+	// Corresponds with PUT /brands/{id}/models/{modelID} (the `UpdateModel` operationId).
+	UpdateModelWithBody(ctx context.Context, id ID, modelID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateModel Rename a model and replace its aliases, platform admins only
+	//
+	// Cars listed as the old name are renamed along with it.
+	//
+	// Takes a body of the `application/json` content type.
+	//
+	// Corresponds with PUT /brands/{id}/models/{modelID} (the `UpdateModel` operationId).
+	UpdateModel(ctx context.Context, id ID, modelID openapi_types.UUID, body UpdateModelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListCars List the cars of a brand, optionally by status
+	//
+	// Corresponds with GET /cars (the `ListCars` operationId).
+	ListCars(ctx context.Context, params *ListCarsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateCarWithBody Create a car
+	//
+	// Takes any type of body and a specified content type.
+	//
+	// Corresponds with POST /cars (the `CreateCar` operationId).
+	CreateCarWithBody(ctx context.Context, params *CreateCarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateCar Create a car
+	//
+	// Takes a body of the `application/json` content type.
+	//
+	// Corresponds with POST /cars (the `CreateCar` operationId).
+	CreateCar(ctx context.Context, params *CreateCarParams, body CreateCarJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamCars Live car changes as server sent events, or over a websocket on upgrade
+	//
+	// Each event is named after its type (car.created, car.updated or
+	// car.deleted) and carries the car as data. A reset event means the
+	// stream could not be resumed from Last-Event-ID and the client should
+	// reload its cars. Callers who can't manage inventory only get available
+	// cars and their own reservations, without who reserved them.
+	//
+	// Corresponds with GET /cars/stream (the `StreamCars` operationId).
+	StreamCars(ctx context.Context, params *StreamCarsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteCar Delete a car
+	//
+	// Corresponds with DELETE /cars/{id} (the `DeleteCar` operationId).
+	DeleteCar(ctx context.Context, id ID, params *DeleteCarParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCar Get a car with its engine
+	//
+	// Corresponds with GET /cars/{id} (the `GetCar` operationId).
+	GetCar(ctx context.Context, id ID, params *GetCarParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateCarWithBody Replace a car
+	//
+	// Takes any type of body and a specified content type.
+	//
+	// Corresponds with PUT /cars/{id} (the `UpdateCar` operationId).
+	UpdateCarWithBody(ctx context.Context, id ID, params *UpdateCarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateCar Replace a car
+	//
+	// Takes a body of the `application/json` content type.
+	//
+	// Corresponds with PUT /cars/{id} (the `UpdateCar` operationId).
+	UpdateCar(ctx context.Context, id ID, params *UpdateCarParams, body UpdateCarJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CancelReservation Cancel a reservation
+	//
+	// Corresponds with DELETE /cars/{id}/reservation (the `CancelReservation` operationId).
+	CancelReservation(ctx context.Context, id ID, params *CancelReservationParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReserveCarWithBody Reserve an available car
+	//
+	// Takes any type of body and a specified content type.
+	//
+	// Corresponds with POST /cars/{id}/reservation (the `ReserveCar` operationId).
+	ReserveCarWithBody(ctx context.Context, id ID, params *ReserveCarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReserveCar Reserve an available car
+	//
+	// Takes a body of the `application/json` content type.
+	//
+	// Corresponds with POST /cars/{id}/reservation (the `ReserveCar` operationId).
+	ReserveCar(ctx context.Context, id ID, params *ReserveCarParams, body ReserveCarJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// MarkCarSold Mark a car as sold
+	//
+	// Corresponds with POST /cars/{id}/sell (the `MarkCarSold` operationId).
+	MarkCarSold(ctx context.Context, id ID, params *MarkCarSoldParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ChangeCarStatusWithBody Move a car to another status
+	//
+	// Takes any type of body and a specified content type.
+	//
+	// Corresponds with PUT /cars/{id}/status (the `ChangeCarStatus` operationId).
+	ChangeCarStatusWithBody(ctx context.Context, id ID, params *ChangeCarStatusParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ChangeCarStatus Move a car to another status
+	//
+	// Takes a body of the `application/json` content type.
+	//
+	// Corresponds with PUT /cars/{id}/status (the `ChangeCarStatus` operationId).
+	ChangeCarStatus(ctx context.Context, id ID, params *ChangeCarStatusParams, body ChangeCarStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListEngines List engines
+	//
+	// Corresponds with GET /engines (the `ListEngines` operationId).
+	ListEngines(ctx context.Context, params *ListEnginesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateEngineWithBody Create an engine
+	//
+	// Takes any type of body and a specified content type.
+	//
+	// Corresponds with POST /engines (the `CreateEngine` operationId).
+	CreateEngineWithBody(ctx context.Context, params *CreateEngineParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateEngine Create an engine
+	//
+	// Takes a body of the `application/json` content type.
+	//
+	// Corresponds with POST /engines (the `CreateEngine` operationId).
+	CreateEngine(ctx context.Context, params *CreateEngineParams, body CreateEngineJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteEngine Delete an engine and the cars using it
+	//
+	// Corresponds with DELETE /engines/{id} (the `DeleteEngine` operationId).
+	DeleteEngine(ctx context.Context, id ID, params *DeleteEngineParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEngine Get an engine
+	//
+	// Corresponds with GET /engines/{id} (the `GetEngine` operationId).
+	GetEngine(ctx context.Context, id ID, params *GetEngineParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateEngineWithBody Replace an engine's specs
+	//
+	// Takes any type of body and a specified content type.
+	//
+	// Corresponds with PUT /engines/{id} (the `UpdateEngine` operationId).
+	UpdateEngineWithBody(ctx context.Context, id ID, params *UpdateEngineParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateEngine Replace an engine's specs
+	//
+	// Takes a body of the `application/json` content type.
+	//
+	// Corresponds with PUT /engines/{id} (the `UpdateEngine` operationId).
+	UpdateEngine(ctx context.Context, id ID, params *UpdateEngineParams, body UpdateEngineJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LoginWithBody Exchange local credentials for a bearer token
+	//
+	// Attempts are limited per client address and per username.
+	//
+	// Takes any type of body and a specified content type.
+	//
+	// Corresponds with POST /login (the `Login` operationId).
+	LoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Login Exchange local credentials for a bearer token
+	//
+	// Attempts are limited per client address and per username.
+	//
+	// Takes a body of the `application/json` content type.
+	//
+	// Corresponds with POST /login (the `Login` operationId).
+	Login(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListMyCars Cars created or reserved by the caller
+	//
+	// Corresponds with GET /me/cars (the `ListMyCars` operationId).
+	ListMyCars(ctx context.Context, params *ListMyCarsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DecodeVIN Decode a VIN into its manufacturer, brand, model year and plant
+	//
+	// Decoded from built in manufacturer tables, and from an external
+	// provider when one is configured, which adds the model. Creating a car
+	// with a VIN fills in the brand, model, year and name it leaves out the same way.
+	//
+	// Corresponds with GET /vin/{vin} (the `DecodeVIN` operationId).
+	DecodeVIN(ctx context.Context, vin string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+// ListBrands List the brand catalogue
+//
+// Car brands and models are checked against the catalogue and stored
+// the way it spells them, any alias resolves to its brand or model.
+// Spellings are matched on their letters and digits ignoring case.
+//
+// Corresponds with GET /brands (the `ListBrands` operationId).
+func (c *Client) ListBrands(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListBrandsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// CreateBrandWithBody Add a brand, platform admins only
+//
+// Takes any type of body and a specified content type.
+//
+// Corresponds with POST /brands (the `CreateBrand` operationId).
+func (c *Client) CreateBrandWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateBrandRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// CreateBrand Add a brand, platform admins only
+//
+// Takes a body of the `application/json` content type.
+//
+// Corresponds with POST /brands (the `CreateBrand` operationId).
+func (c *Client) CreateBrand(ctx context.Context, body CreateBrandJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateBrandRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// DeleteBrand Delete a brand and its models, platform admins only
+//
+// Corresponds with DELETE /brands/{id} (the `DeleteBrand` operationId).
+func (c *Client) DeleteBrand(ctx context.Context, id ID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteBrandRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// GetBrand Get a brand with its models
+//
+// Corresponds with GET /brands/{id} (the `GetBrand` operationId).
+func (c *Client) GetBrand(ctx context.Context, id ID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBrandRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// UpdateBrandWithBody Rename a brand and replace its aliases, platform admins only
+//
+// Cars listed under the old name are renamed along with it.
+//
+// Takes any type of body and a specified content type.
+//
+// Corresponds with PUT /brands/{id} (the `UpdateBrand` operationId).
+func (c *Client) UpdateBrandWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateBrandRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// UpdateBrand Rename a brand and replace its aliases, platform admins only
+//
+// Cars listed under the old name are renamed along with it.
+//
+// Takes a body of the `application/json` content type.
+//
+// Corresponds with PUT /brands/{id} (the `UpdateBrand` operationId).
+func (c *Client) UpdateBrand(ctx context.Context, id ID, body UpdateBrandJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateBrandRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// CreateModelWithBody Add a model to a brand, platform admins only
+//
+// Once a brand has models, cars of that brand that give a model have
+// to give one of them.
+//
+// Takes any type of body and a specified content type.
+//
+// Corresponds with POST /brands/{id}/models (the `CreateModel` operationId).
+func (c *Client) CreateModelWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateModelRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// CreateModel Add a model to a brand, platform admins only
+//
+// Once a brand has models, cars of that brand that give a model have
+// to give one of them.
+//
+// Takes a body of the `application/json` content type.
+//
+// Corresponds with POST /brands/{id}/models (the `CreateModel` operationId).
+func (c *Client) CreateModel(ctx context.Context, id ID, body CreateModelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateModelRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// DeleteModel Delete a model, platform admins only
+//
+// Corresponds with DELETE /brands/{id}/models/{modelID} (the `DeleteModel` operationId).
+func (c *Client) DeleteModel(ctx context.Context, id ID, modelID openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteModelRequest(c.Server, id, modelID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// UpdateModelWithBody Rename a model and replace its aliases, platform admins only
+//
+// Cars listed as the old name are renamed along with it.
+//
+// Takes any type of body and a specified content type.
+//
+// Corresponds with PUT /brands/{id}/models/{modelID} (the `UpdateModel` operationId).
+func (c *Client) UpdateModelWithBody(ctx context.Context, id ID, modelID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateModelRequestWithBody(c.Server, id, modelID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// UpdateModel Rename a model and replace its aliases, platform admins only
+//
+// Cars listed as the old name are renamed along with it.
+//
+// Takes a body of the `application/json` content type.
+//
+// Corresponds with PUT /brands/{id}/models/{modelID} (the `UpdateModel` operationId).
+func (c *Client) UpdateModel(ctx context.Context, id ID, modelID openapi_types.UUID, body UpdateModelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateModelRequest(c.Server, id, modelID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// ListCars List the cars of a brand, optionally by status
+//
+// Corresponds with GET /cars (the `ListCars` operationId).
+func (c *Client) ListCars(ctx context.Context, params *ListCarsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListCarsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// CreateCarWithBody Create a car
+//
+// Takes any type of body and a specified content type.
+//
+// Corresponds with POST /cars (the `CreateCar` operationId).
+func (c *Client) CreateCarWithBody(ctx context.Context, params *CreateCarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateCarRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// CreateCar Create a car
+//
+// Takes a body of the `application/json` content type.
+//
+// Corresponds with POST /cars (the `CreateCar` operationId).
+func (c *Client) CreateCar(ctx context.Context, params *CreateCarParams, body CreateCarJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateCarRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// StreamCars Live car changes as server sent events, or over a websocket on upgrade
+//
+// Each event is named after its type (car.created, car.updated or
+// car.deleted) and carries the car as data. A reset event means the
+// stream could not be resumed from Last-Event-ID and the client should
+// reload its cars. Callers who can't manage inventory only get available
+// cars and their own reservations, without who reserved them.
+//
+// Corresponds with GET /cars/stream (the `StreamCars` operationId).
+func (c *Client) StreamCars(ctx context.Context, params *StreamCarsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamCarsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// DeleteCar Delete a car
+//
+// Corresponds with DELETE /cars/{id} (the `DeleteCar` operationId).
+func (c *Client) DeleteCar(ctx context.Context, id ID, params *DeleteCarParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteCarRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// GetCar Get a car with its engine
+//
+// Corresponds with GET /cars/{id} (the `GetCar` operationId).
+func (c *Client) GetCar(ctx context.Context, id ID, params *GetCarParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCarRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// UpdateCarWithBody Replace a car
+//
+// Takes any type of body and a specified content type.
+//
+// Corresponds with PUT /cars/{id} (the `UpdateCar` operationId).
+func (c *Client) UpdateCarWithBody(ctx context.Context, id ID, params *UpdateCarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateCarRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// UpdateCar Replace a car
+//
+// Takes a body of the `application/json` content type.
+//
+// Corresponds with PUT /cars/{id} (the `UpdateCar` operationId).
+func (c *Client) UpdateCar(ctx context.Context, id ID, params *UpdateCarParams, body UpdateCarJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateCarRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// CancelReservation Cancel a reservation
+//
+// Corresponds with DELETE /cars/{id}/reservation (the `CancelReservation` operationId).
+func (c *Client) CancelReservation(ctx context.Context, id ID, params *CancelReservationParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelReservationRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// ReserveCarWithBody Reserve an available car
+//
+// Takes any type of body and a specified content type.
+//
+// Corresponds with POST /cars/{id}/reservation (the `ReserveCar` operationId).
+func (c *Client) ReserveCarWithBody(ctx context.Context, id ID, params *ReserveCarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReserveCarRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// ReserveCar Reserve an available car
+//
+// Takes a body of the `application/json` content type.
+//
+// Corresponds with POST /cars/{id}/reservation (the `ReserveCar` operationId).
+func (c *Client) ReserveCar(ctx context.Context, id ID, params *ReserveCarParams, body ReserveCarJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReserveCarRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// MarkCarSold Mark a car as sold
+//
+// Corresponds with POST /cars/{id}/sell (the `MarkCarSold` operationId).
+func (c *Client) MarkCarSold(ctx context.Context, id ID, params *MarkCarSoldParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMarkCarSoldRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// ChangeCarStatusWithBody Move a car to another status
+//
+// Takes any type of body and a specified content type.
+//
+// Corresponds with PUT /cars/{id}/status (the `ChangeCarStatus` operationId).
+func (c *Client) ChangeCarStatusWithBody(ctx context.Context, id ID, params *ChangeCarStatusParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewChangeCarStatusRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// ChangeCarStatus Move a car to another status
+//
+// Takes a body of the `application/json` content type.
+//
+// Corresponds with PUT /cars/{id}/status (the `ChangeCarStatus` operationId).
+func (c *Client) ChangeCarStatus(ctx context.Context, id ID, params *ChangeCarStatusParams, body ChangeCarStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewChangeCarStatusRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// ListEngines List engines
+//
+// Corresponds with GET /engines (the `ListEngines` operationId).
+func (c *Client) ListEngines(ctx context.Context, params *ListEnginesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListEnginesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// CreateEngineWithBody Create an engine
+//
+// Takes any type of body and a specified content type.
+//
+// Corresponds with POST /engines (the `CreateEngine` operationId).
+func (c *Client) CreateEngineWithBody(ctx context.Context, params *CreateEngineParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateEngineRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// CreateEngine Create an engine
+//
+// Takes a body of the `application/json` content type.
+//
+// Corresponds with POST /engines (the `CreateEngine` operationId).
+func (c *Client) CreateEngine(ctx context.Context, params *CreateEngineParams, body CreateEngineJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateEngineRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// DeleteEngine Delete an engine and the cars using it
+//
+// Corresponds with DELETE /engines/{id} (the `DeleteEngine` operationId).
+func (c *Client) DeleteEngine(ctx context.Context, id ID, params *DeleteEngineParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteEngineRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// GetEngine Get an engine
+//
+// Corresponds with GET /engines/{id} (the `GetEngine` operationId).
+func (c *Client) GetEngine(ctx context.Context, id ID, params *GetEngineParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEngineRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// UpdateEngineWithBody Replace an engine's specs
+//
+// Takes any type of body and a specified content type.
+//
+// Corresponds with PUT /engines/{id} (the `UpdateEngine` operationId).
+func (c *Client) UpdateEngineWithBody(ctx context.Context, id ID, params *UpdateEngineParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateEngineRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// UpdateEngine Replace an engine's specs
+//
+// Takes a body of the `application/json` content type.
+//
+// Corresponds with PUT /engines/{id} (the `UpdateEngine` operationId).
+func (c *Client) UpdateEngine(ctx context.Context, id ID, params *UpdateEngineParams, body UpdateEngineJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateEngineRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// LoginWithBody Exchange local credentials for a bearer token
+//
+// Attempts are limited per client address and per username.
+//
+// Takes any type of body and a specified content type.
+//
+// Corresponds with POST /login (the `Login` operationId).
+func (c *Client) LoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// Login Exchange local credentials for a bearer token
+//
+// Attempts are limited per client address and per username.
+//
+// Takes a body of the `application/json` content type.
+//
+// Corresponds with POST /login (the `Login` operationId).
+func (c *Client) Login(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// ListMyCars Cars created or reserved by the caller
+//
+// Corresponds with GET /me/cars (the `ListMyCars` operationId).
+func (c *Client) ListMyCars(ctx context.Context, params *ListMyCarsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListMyCarsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// DecodeVIN Decode a VIN into its manufacturer, brand, model year and plant
+//
+// Decoded from built in manufacturer tables, and from an external
+// provider when one is configured, which adds the model. Creating a car
+// with a VIN fills in the brand, model, year and name it leaves out the same way.
+//
+// Corresponds with GET /vin/{vin} (the `DecodeVIN` operationId).
+func (c *Client) DecodeVIN(ctx context.Context, vin string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDecodeVINRequest(c.Server, vin)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewListBrandsRequest constructs an http.Request for the ListBrands method
+func NewListBrandsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/brands")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateBrandRequest calls the generic CreateBrand builder with application/json body
+func NewCreateBrandRequest(server string, body CreateBrandJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateBrandRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateBrandRequestWithBody constructs an http.Request for the CreateBrand method, with any body, and a specified content type
+func NewCreateBrandRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/brands")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteBrandRequest constructs an http.Request for the DeleteBrand method
+func NewDeleteBrandRequest(server string, id ID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/brands/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBrandRequest constructs an http.Request for the GetBrand method
+func NewGetBrandRequest(server string, id ID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/brands/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateBrandRequest calls the generic UpdateBrand builder with application/json body
+func NewUpdateBrandRequest(server string, id ID, body UpdateBrandJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateBrandRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateBrandRequestWithBody constructs an http.Request for the UpdateBrand method, with any body, and a specified content type
+func NewUpdateBrandRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/brands/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateModelRequest calls the generic CreateModel builder with application/json body
+func NewCreateModelRequest(server string, id ID, body CreateModelJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateModelRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewCreateModelRequestWithBody constructs an http.Request for the CreateModel method, with any body, and a specified content type
+func NewCreateModelRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/brands/%s/models", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteModelRequest constructs an http.Request for the DeleteModel method
+func NewDeleteModelRequest(server string, id ID, modelID openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "modelID", modelID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/brands/%s/models/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateModelRequest calls the generic UpdateModel builder with application/json body
+func NewUpdateModelRequest(server string, id ID, modelID openapi_types.UUID, body UpdateModelJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateModelRequestWithBody(server, id, modelID, "application/json", bodyReader)
+}
+
+// NewUpdateModelRequestWithBody constructs an http.Request for the UpdateModel method, with any body, and a specified content type
+func NewUpdateModelRequestWithBody(server string, id ID, modelID openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithOptions("simple", false, "modelID", modelID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/brands/%s/models/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListCarsRequest constructs an http.Request for the ListCars method
+func NewListCarsRequest(server string, params *ListCarsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		// queryValues collects non-styled parameters (passthrough, JSON)
+		// that are safe to round-trip through url.Values.Encode().
+		queryValues := queryURL.Query()
+		// rawQueryFragments collects pre-encoded query fragments from
+		// styled parameters, preserving literal commas as delimiters
+		// per the OpenAPI spec (e.g. "color=blue,black,brown").
+		var rawQueryFragments []string
+
+		if params.Brand != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "brand", *params.Brand, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if params.IsEngine != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "isEngine", *params.IsEngine, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "boolean", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "status", *params.Status, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if params.Vin != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "vin", *params.Vin, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if params.Transmission != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "transmission", *params.Transmission, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if params.Drivetrain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "drivetrain", *params.Drivetrain, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if params.BodyType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "body_type", *params.BodyType, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if params.Condition != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "condition", *params.Condition, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if params.Colour != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "colour", *params.Colour, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if params.MaxOdometerKm != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "max_odometer_km", *params.MaxOdometerKm, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "integer", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if params.Features != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "features", *params.Features, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if encoded := queryValues.Encode(); encoded != "" {
+			rawQueryFragments = append(rawQueryFragments, encoded)
+		}
+		queryURL.RawQuery = strings.Join(rawQueryFragments, "&")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewCreateCarRequest calls the generic CreateCar builder with application/json body
+func NewCreateCarRequest(server string, params *CreateCarParams, body CreateCarJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateCarRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateCarRequestWithBody constructs an http.Request for the CreateCar method, with any body, and a specified content type
+func NewCreateCarRequestWithBody(server string, params *CreateCarParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+		if params.IdempotencyKey != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithOptions("simple", false, "Idempotency-Key", *params.IdempotencyKey, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewStreamCarsRequest constructs an http.Request for the StreamCars method
+func NewStreamCarsRequest(server string, params *StreamCarsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/stream")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		// queryValues collects non-styled parameters (passthrough, JSON)
+		// that are safe to round-trip through url.Values.Encode().
+		queryValues := queryURL.Query()
+		// rawQueryFragments collects pre-encoded query fragments from
+		// styled parameters, preserving literal commas as delimiters
+		// per the OpenAPI spec (e.g. "color=blue,black,brown").
+		var rawQueryFragments []string
+
+		if params.Brand != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "brand", *params.Brand, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if params.LastEventId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "last_event_id", *params.LastEventId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if encoded := queryValues.Encode(); encoded != "" {
+			rawQueryFragments = append(rawQueryFragments, encoded)
+		}
+		queryURL.RawQuery = strings.Join(rawQueryFragments, "&")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+		if params.LastEventID != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithOptions("simple", false, "Last-Event-ID", *params.LastEventID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteCarRequest constructs an http.Request for the DeleteCar method
+func NewDeleteCarRequest(server string, id ID, params *DeleteCarParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetCarRequest constructs an http.Request for the GetCar method
+func NewGetCarRequest(server string, id ID, params *GetCarParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewUpdateCarRequest calls the generic UpdateCar builder with application/json body
+func NewUpdateCarRequest(server string, id ID, params *UpdateCarParams, body UpdateCarJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateCarRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewUpdateCarRequestWithBody constructs an http.Request for the UpdateCar method, with any body, and a specified content type
+func NewUpdateCarRequestWithBody(server string, id ID, params *UpdateCarParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewCancelReservationRequest constructs an http.Request for the CancelReservation method
+func NewCancelReservationRequest(server string, id ID, params *CancelReservationParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s/reservation", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewReserveCarRequest calls the generic ReserveCar builder with application/json body
+func NewReserveCarRequest(server string, id ID, params *ReserveCarParams, body ReserveCarJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReserveCarRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewReserveCarRequestWithBody constructs an http.Request for the ReserveCar method, with any body, and a specified content type
+func NewReserveCarRequestWithBody(server string, id ID, params *ReserveCarParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s/reservation", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewMarkCarSoldRequest constructs an http.Request for the MarkCarSold method
+func NewMarkCarSoldRequest(server string, id ID, params *MarkCarSoldParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s/sell", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewChangeCarStatusRequest calls the generic ChangeCarStatus builder with application/json body
+func NewChangeCarStatusRequest(server string, id ID, params *ChangeCarStatusParams, body ChangeCarStatusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewChangeCarStatusRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewChangeCarStatusRequestWithBody constructs an http.Request for the ChangeCarStatus method, with any body, and a specified content type
+func NewChangeCarStatusRequestWithBody(server string, id ID, params *ChangeCarStatusParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cars/%s/status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewListEnginesRequest constructs an http.Request for the ListEngines method
+func NewListEnginesRequest(server string, params *ListEnginesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/engines")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		// queryValues collects non-styled parameters (passthrough, JSON)
+		// that are safe to round-trip through url.Values.Encode().
+		queryValues := queryURL.Query()
+		// rawQueryFragments collects pre-encoded query fragments from
+		// styled parameters, preserving literal commas as delimiters
+		// per the OpenAPI spec (e.g. "color=blue,black,brown").
+		var rawQueryFragments []string
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "limit", *params.Limit, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "integer", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "offset", *params.Offset, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "integer", Format: ""}); err != nil {
+				return nil, err
+			} else {
+				for _, qp := range strings.Split(queryFrag, "&") {
+					rawQueryFragments = append(rawQueryFragments, qp)
+				}
+			}
+
+		}
+
+		if encoded := queryValues.Encode(); encoded != "" {
+			rawQueryFragments = append(rawQueryFragments, encoded)
+		}
+		queryURL.RawQuery = strings.Join(rawQueryFragments, "&")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewCreateEngineRequest calls the generic CreateEngine builder with application/json body
+func NewCreateEngineRequest(server string, params *CreateEngineParams, body CreateEngineJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateEngineRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateEngineRequestWithBody constructs an http.Request for the CreateEngine method, with any body, and a specified content type
+func NewCreateEngineRequestWithBody(server string, params *CreateEngineParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/engines")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+		if params.IdempotencyKey != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithOptions("simple", false, "Idempotency-Key", *params.IdempotencyKey, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: ""})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteEngineRequest constructs an http.Request for the DeleteEngine method
+func NewDeleteEngineRequest(server string, id ID, params *DeleteEngineParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/engines/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetEngineRequest constructs an http.Request for the GetEngine method
+func NewGetEngineRequest(server string, id ID, params *GetEngineParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/engines/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewUpdateEngineRequest calls the generic UpdateEngine builder with application/json body
+func NewUpdateEngineRequest(server string, id ID, params *UpdateEngineParams, body UpdateEngineJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateEngineRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewUpdateEngineRequestWithBody constructs an http.Request for the UpdateEngine method, with any body, and a specified content type
+func NewUpdateEngineRequestWithBody(server string, id ID, params *UpdateEngineParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "id", id, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: "uuid"})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/engines/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewLoginRequest calls the generic Login builder with application/json body
+func NewLoginRequest(server string, body LoginJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewLoginRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewLoginRequestWithBody constructs an http.Request for the Login method, with any body, and a specified content type
+func NewLoginRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/login")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListMyCarsRequest constructs an http.Request for the ListMyCars method
+func NewListMyCarsRequest(server string, params *ListMyCarsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me/cars")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XTenantID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithOptions("simple", false, "X-Tenant-ID", *params.XTenantID, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationHeader, Type: "string", Format: "uuid"})
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Tenant-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDecodeVINRequest constructs an http.Request for the DecodeVIN method
+func NewDecodeVINRequest(server string, vin string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "vin", vin, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/vin/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+
+	// ListBrandsWithResponse List the brand catalogue
+	//
+	// Car brands and models are checked against the catalogue and stored
+	// the way it spells them, any alias resolves to its brand or model.
+	// Spellings are matched on their letters and digits ignoring case.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with GET /brands (the `ListBrands` operationId).
+	ListBrandsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListBrandsResponse, error)
+
+	// CreateBrandWithBodyWithResponse Add a brand, platform admins only
+	//
+	// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /brands (the `CreateBrand` operationId).
+	CreateBrandWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateBrandResponse, error)
+
+	// CreateBrandWithResponse Add a brand, platform admins only
+	//
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /brands (the `CreateBrand` operationId).
+	CreateBrandWithResponse(ctx context.Context, body CreateBrandJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateBrandResponse, error)
+
+	// DeleteBrandWithResponse Delete a brand and its models, platform admins only
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with DELETE /brands/{id} (the `DeleteBrand` operationId).
+	DeleteBrandWithResponse(ctx context.Context, id ID, reqEditors ...RequestEditorFn) (*DeleteBrandResponse, error)
+
+	// GetBrandWithResponse Get a brand with its models
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with GET /brands/{id} (the `GetBrand` operationId).
+	GetBrandWithResponse(ctx context.Context, id ID, reqEditors ...RequestEditorFn) (*GetBrandResponse, error)
+
+	// UpdateBrandWithBodyWithResponse Rename a brand and replace its aliases, platform admins only
+	//
+	// Cars listed under the old name are renamed along with it.
+	//
+	// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with PUT /brands/{id} (the `UpdateBrand` operationId).
+	UpdateBrandWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateBrandResponse, error)
+
+	// UpdateBrandWithResponse Rename a brand and replace its aliases, platform admins only
+	//
+	// Cars listed under the old name are renamed along with it.
+	//
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with PUT /brands/{id} (the `UpdateBrand` operationId).
+	UpdateBrandWithResponse(ctx context.Context, id ID, body UpdateBrandJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateBrandResponse, error)
+
+	// CreateModelWithBodyWithResponse Add a model to a brand, platform admins only
+	//
+	// Once a brand has models, cars of that brand that give a model have
+	// to give one of them.
+	//
+	// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /brands/{id}/models (the `CreateModel` operationId).
+	CreateModelWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateModelResponse, error)
+
+	// CreateModelWithResponse Add a model to a brand, platform admins only
+	//
+	// Once a brand has models, cars of that brand that give a model have
+	// to give one of them.
+	//
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /brands/{id}/models (the `CreateModel` operationId).
+	CreateModelWithResponse(ctx context.Context, id ID, body CreateModelJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateModelResponse, error)
+
+	// DeleteModelWithResponse Delete a model, platform admins only
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with DELETE /brands/{id}/models/{modelID} (the `DeleteModel` operationId).
+	DeleteModelWithResponse(ctx context.Context, id ID, modelID openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteModelResponse, error)
+
+	// UpdateModelWithBodyWithResponse Rename a model and replace its aliases, platform admins only
+	//
+	// Cars listed as the old name are renamed along with it.
+	//
+	// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with PUT /brands/{id}/models/{modelID} (the `UpdateModel` operationId).
+	UpdateModelWithBodyWithResponse(ctx context.Context, id ID, modelID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateModelResponse, error)
+
+	// UpdateModelWithResponse Rename a model and replace its aliases, platform admins only
+	//
+	// Cars listed as the old name are renamed along with it.
+	//
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with PUT /brands/{id}/models/{modelID} (the `UpdateModel` operationId).
+	UpdateModelWithResponse(ctx context.Context, id ID, modelID openapi_types.UUID, body UpdateModelJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateModelResponse, error)
+
+	// ListCarsWithResponse List the cars of a brand, optionally by status
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with GET /cars (the `ListCars` operationId).
+	ListCarsWithResponse(ctx context.Context, params *ListCarsParams, reqEditors ...RequestEditorFn) (*ListCarsResponse, error)
+
+	// CreateCarWithBodyWithResponse Create a car
+	//
+	// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /cars (the `CreateCar` operationId).
+	CreateCarWithBodyWithResponse(ctx context.Context, params *CreateCarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateCarResponse, error)
+
+	// CreateCarWithResponse Create a car
+	//
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /cars (the `CreateCar` operationId).
+	CreateCarWithResponse(ctx context.Context, params *CreateCarParams, body CreateCarJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateCarResponse, error)
+
+	// StreamCarsWithResponse Live car changes as server sent events, or over a websocket on upgrade
+	//
+	// Each event is named after its type (car.created, car.updated or
+	// car.deleted) and carries the car as data. A reset event means the
+	// stream could not be resumed from Last-Event-ID and the client should
+	// reload its cars. Callers who can't manage inventory only get available
+	// cars and their own reservations, without who reserved them.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with GET /cars/stream (the `StreamCars` operationId).
+	StreamCarsWithResponse(ctx context.Context, params *StreamCarsParams, reqEditors ...RequestEditorFn) (*StreamCarsResponse, error)
+
+	// DeleteCarWithResponse Delete a car
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with DELETE /cars/{id} (the `DeleteCar` operationId).
+	DeleteCarWithResponse(ctx context.Context, id ID, params *DeleteCarParams, reqEditors ...RequestEditorFn) (*DeleteCarResponse, error)
+
+	// GetCarWithResponse Get a car with its engine
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with GET /cars/{id} (the `GetCar` operationId).
+	GetCarWithResponse(ctx context.Context, id ID, params *GetCarParams, reqEditors ...RequestEditorFn) (*GetCarResponse, error)
+
+	// UpdateCarWithBodyWithResponse Replace a car
+	//
+	// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with PUT /cars/{id} (the `UpdateCar` operationId).
+	UpdateCarWithBodyWithResponse(ctx context.Context, id ID, params *UpdateCarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateCarResponse, error)
+
+	// UpdateCarWithResponse Replace a car
+	//
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with PUT /cars/{id} (the `UpdateCar` operationId).
+	UpdateCarWithResponse(ctx context.Context, id ID, params *UpdateCarParams, body UpdateCarJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateCarResponse, error)
+
+	// CancelReservationWithResponse Cancel a reservation
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with DELETE /cars/{id}/reservation (the `CancelReservation` operationId).
+	CancelReservationWithResponse(ctx context.Context, id ID, params *CancelReservationParams, reqEditors ...RequestEditorFn) (*CancelReservationResponse, error)
+
+	// ReserveCarWithBodyWithResponse Reserve an available car
+	//
+	// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /cars/{id}/reservation (the `ReserveCar` operationId).
+	ReserveCarWithBodyWithResponse(ctx context.Context, id ID, params *ReserveCarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReserveCarResponse, error)
+
+	// ReserveCarWithResponse Reserve an available car
+	//
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /cars/{id}/reservation (the `ReserveCar` operationId).
+	ReserveCarWithResponse(ctx context.Context, id ID, params *ReserveCarParams, body ReserveCarJSONRequestBody, reqEditors ...RequestEditorFn) (*ReserveCarResponse, error)
+
+	// MarkCarSoldWithResponse Mark a car as sold
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /cars/{id}/sell (the `MarkCarSold` operationId).
+	MarkCarSoldWithResponse(ctx context.Context, id ID, params *MarkCarSoldParams, reqEditors ...RequestEditorFn) (*MarkCarSoldResponse, error)
+
+	// ChangeCarStatusWithBodyWithResponse Move a car to another status
+	//
+	// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with PUT /cars/{id}/status (the `ChangeCarStatus` operationId).
+	ChangeCarStatusWithBodyWithResponse(ctx context.Context, id ID, params *ChangeCarStatusParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ChangeCarStatusResponse, error)
+
+	// ChangeCarStatusWithResponse Move a car to another status
+	//
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with PUT /cars/{id}/status (the `ChangeCarStatus` operationId).
+	ChangeCarStatusWithResponse(ctx context.Context, id ID, params *ChangeCarStatusParams, body ChangeCarStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*ChangeCarStatusResponse, error)
+
+	// ListEnginesWithResponse List engines
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with GET /engines (the `ListEngines` operationId).
+	ListEnginesWithResponse(ctx context.Context, params *ListEnginesParams, reqEditors ...RequestEditorFn) (*ListEnginesResponse, error)
+
+	// CreateEngineWithBodyWithResponse Create an engine
+	//
+	// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /engines (the `CreateEngine` operationId).
+	CreateEngineWithBodyWithResponse(ctx context.Context, params *CreateEngineParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateEngineResponse, error)
+
+	// CreateEngineWithResponse Create an engine
+	//
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /engines (the `CreateEngine` operationId).
+	CreateEngineWithResponse(ctx context.Context, params *CreateEngineParams, body CreateEngineJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateEngineResponse, error)
+
+	// DeleteEngineWithResponse Delete an engine and the cars using it
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with DELETE /engines/{id} (the `DeleteEngine` operationId).
+	DeleteEngineWithResponse(ctx context.Context, id ID, params *DeleteEngineParams, reqEditors ...RequestEditorFn) (*DeleteEngineResponse, error)
+
+	// GetEngineWithResponse Get an engine
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with GET /engines/{id} (the `GetEngine` operationId).
+	GetEngineWithResponse(ctx context.Context, id ID, params *GetEngineParams, reqEditors ...RequestEditorFn) (*GetEngineResponse, error)
+
+	// UpdateEngineWithBodyWithResponse Replace an engine's specs
+	//
+	// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with PUT /engines/{id} (the `UpdateEngine` operationId).
+	UpdateEngineWithBodyWithResponse(ctx context.Context, id ID, params *UpdateEngineParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateEngineResponse, error)
+
+	// UpdateEngineWithResponse Replace an engine's specs
+	//
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with PUT /engines/{id} (the `UpdateEngine` operationId).
+	UpdateEngineWithResponse(ctx context.Context, id ID, params *UpdateEngineParams, body UpdateEngineJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateEngineResponse, error)
+
+	// LoginWithBodyWithResponse Exchange local credentials for a bearer token
+	//
+	// Attempts are limited per client address and per username.
+	//
+	// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /login (the `Login` operationId).
+	LoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginResponse, error)
+
+	// LoginWithResponse Exchange local credentials for a bearer token
+	//
+	// Attempts are limited per client address and per username.
+	//
+	// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with POST /login (the `Login` operationId).
+	LoginWithResponse(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginResponse, error)
+
+	// ListMyCarsWithResponse Cars created or reserved by the caller
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with GET /me/cars (the `ListMyCars` operationId).
+	ListMyCarsWithResponse(ctx context.Context, params *ListMyCarsParams, reqEditors ...RequestEditorFn) (*ListMyCarsResponse, error)
+
+	// DecodeVINWithResponse Decode a VIN into its manufacturer, brand, model year and plant
+	//
+	// Decoded from built in manufacturer tables, and from an external
+	// provider when one is configured, which adds the model. Creating a car
+	// with a VIN fills in the brand, model, year and name it leaves out the same way.
+	//
+	// Returns a wrapper object for the known response body format(s).
+	//
+	// Corresponds with GET /vin/{vin} (the `DecodeVIN` operationId).
+	DecodeVINWithResponse(ctx context.Context, vin string, reqEditors ...RequestEditorFn) (*DecodeVINResponse, error)
+}
+
+type ListBrandsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *[]Brand
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r ListBrandsResponse) GetJSON200() *[]Brand {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r ListBrandsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r ListBrandsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListBrandsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ListBrandsResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type CreateBrandResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON201 the response for an HTTP 201 `application/json` response
+	JSON201 *Brand
+}
+
+// GetJSON201 returns the response for an HTTP 201 `application/json` response
+func (r CreateBrandResponse) GetJSON201() *Brand {
+	return r.JSON201
+}
+
+// GetBody returns the raw response body bytes
+func (r CreateBrandResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateBrandResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateBrandResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r CreateBrandResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type DeleteBrandResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Brand
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r DeleteBrandResponse) GetJSON200() *Brand {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r DeleteBrandResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteBrandResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteBrandResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r DeleteBrandResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type GetBrandResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Brand
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r GetBrandResponse) GetJSON200() *Brand {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r GetBrandResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBrandResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBrandResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r GetBrandResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type UpdateBrandResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Brand
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r UpdateBrandResponse) GetJSON200() *Brand {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r UpdateBrandResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateBrandResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateBrandResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r UpdateBrandResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type CreateModelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON201 the response for an HTTP 201 `application/json` response
+	JSON201 *CarModel
+}
+
+// GetJSON201 returns the response for an HTTP 201 `application/json` response
+func (r CreateModelResponse) GetJSON201() *CarModel {
+	return r.JSON201
+}
+
+// GetBody returns the raw response body bytes
+func (r CreateModelResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateModelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateModelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r CreateModelResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type DeleteModelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *CarModel
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r DeleteModelResponse) GetJSON200() *CarModel {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r DeleteModelResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteModelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteModelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r DeleteModelResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type UpdateModelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *CarModel
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r UpdateModelResponse) GetJSON200() *CarModel {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r UpdateModelResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateModelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateModelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r UpdateModelResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type ListCarsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *[]Car
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r ListCarsResponse) GetJSON200() *[]Car {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r ListCarsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r ListCarsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListCarsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ListCarsResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type CreateCarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON201 the response for an HTTP 201 `application/json` response
+	JSON201 *Car
+}
+
+// GetJSON201 returns the response for an HTTP 201 `application/json` response
+func (r CreateCarResponse) GetJSON201() *Car {
+	return r.JSON201
+}
+
+// GetBody returns the raw response body bytes
+func (r CreateCarResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateCarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateCarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r CreateCarResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type StreamCarsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// GetBody returns the raw response body bytes
+func (r StreamCarsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamCarsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamCarsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r StreamCarsResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type DeleteCarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Car
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r DeleteCarResponse) GetJSON200() *Car {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r DeleteCarResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteCarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteCarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r DeleteCarResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type GetCarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Car
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r GetCarResponse) GetJSON200() *Car {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r GetCarResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r GetCarResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type UpdateCarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Car
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r UpdateCarResponse) GetJSON200() *Car {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r UpdateCarResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateCarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateCarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r UpdateCarResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type CancelReservationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Car
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r CancelReservationResponse) GetJSON200() *Car {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r CancelReservationResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelReservationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelReservationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r CancelReservationResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type ReserveCarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Car
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r ReserveCarResponse) GetJSON200() *Car {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r ReserveCarResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r ReserveCarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReserveCarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ReserveCarResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type MarkCarSoldResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Car
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r MarkCarSoldResponse) GetJSON200() *Car {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r MarkCarSoldResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r MarkCarSoldResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r MarkCarSoldResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r MarkCarSoldResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type ChangeCarStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Car
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r ChangeCarStatusResponse) GetJSON200() *Car {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r ChangeCarStatusResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r ChangeCarStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ChangeCarStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ChangeCarStatusResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type ListEnginesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *[]Engine
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r ListEnginesResponse) GetJSON200() *[]Engine {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r ListEnginesResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r ListEnginesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListEnginesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ListEnginesResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type CreateEngineResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Engine
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r CreateEngineResponse) GetJSON200() *Engine {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r CreateEngineResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateEngineResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateEngineResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r CreateEngineResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type DeleteEngineResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Engine
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r DeleteEngineResponse) GetJSON200() *Engine {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r DeleteEngineResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteEngineResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteEngineResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r DeleteEngineResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type GetEngineResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Engine
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r GetEngineResponse) GetJSON200() *Engine {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r GetEngineResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEngineResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEngineResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r GetEngineResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type UpdateEngineResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Engine
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r UpdateEngineResponse) GetJSON200() *Engine {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r UpdateEngineResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateEngineResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateEngineResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r UpdateEngineResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type LoginResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *Token
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r LoginResponse) GetJSON200() *Token {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r LoginResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r LoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r LoginResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type ListMyCarsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *[]Car
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r ListMyCarsResponse) GetJSON200() *[]Car {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r ListMyCarsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r ListMyCarsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListMyCarsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r ListMyCarsResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+type DecodeVINResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	// JSON200 the response for an HTTP 200 `application/json` response
+	JSON200 *VINInfo
+}
+
+// GetJSON200 returns the response for an HTTP 200 `application/json` response
+func (r DecodeVINResponse) GetJSON200() *VINInfo {
+	return r.JSON200
+}
+
+// GetBody returns the raw response body bytes
+func (r DecodeVINResponse) GetBody() []byte {
+	return r.Body
+}
+
+// Status returns HTTPResponse.Status
+func (r DecodeVINResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DecodeVINResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentType is a convenience method to retrieve the Content-Type value from the HTTP response headers
+func (r DecodeVINResponse) ContentType() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Type")
+	}
+	return ""
+}
+
+// ListBrandsWithResponse List the brand catalogue
+//
+// Car brands and models are checked against the catalogue and stored
+// the way it spells them, any alias resolves to its brand or model.
+// Spellings are matched on their letters and digits ignoring case.
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with GET /brands (the `ListBrands` operationId).
+func (c *ClientWithResponses) ListBrandsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListBrandsResponse, error) {
+	rsp, err := c.ListBrands(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListBrandsResponse(rsp)
+}
+
+// CreateBrandWithBodyWithResponse Add a brand, platform admins only
+//
+// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /brands (the `CreateBrand` operationId).
+func (c *ClientWithResponses) CreateBrandWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateBrandResponse, error) {
+	rsp, err := c.CreateBrandWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBrandResponse(rsp)
+}
+
+// CreateBrandWithResponse Add a brand, platform admins only
+//
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /brands (the `CreateBrand` operationId).
+func (c *ClientWithResponses) CreateBrandWithResponse(ctx context.Context, body CreateBrandJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateBrandResponse, error) {
+	rsp, err := c.CreateBrand(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBrandResponse(rsp)
+}
+
+// DeleteBrandWithResponse Delete a brand and its models, platform admins only
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with DELETE /brands/{id} (the `DeleteBrand` operationId).
+func (c *ClientWithResponses) DeleteBrandWithResponse(ctx context.Context, id ID, reqEditors ...RequestEditorFn) (*DeleteBrandResponse, error) {
+	rsp, err := c.DeleteBrand(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteBrandResponse(rsp)
+}
+
+// GetBrandWithResponse Get a brand with its models
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with GET /brands/{id} (the `GetBrand` operationId).
+func (c *ClientWithResponses) GetBrandWithResponse(ctx context.Context, id ID, reqEditors ...RequestEditorFn) (*GetBrandResponse, error) {
+	rsp, err := c.GetBrand(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBrandResponse(rsp)
+}
+
+// UpdateBrandWithBodyWithResponse Rename a brand and replace its aliases, platform admins only
+//
+// Cars listed under the old name are renamed along with it.
+//
+// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with PUT /brands/{id} (the `UpdateBrand` operationId).
+func (c *ClientWithResponses) UpdateBrandWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateBrandResponse, error) {
+	rsp, err := c.UpdateBrandWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateBrandResponse(rsp)
+}
+
+// UpdateBrandWithResponse Rename a brand and replace its aliases, platform admins only
+//
+// Cars listed under the old name are renamed along with it.
+//
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with PUT /brands/{id} (the `UpdateBrand` operationId).
+func (c *ClientWithResponses) UpdateBrandWithResponse(ctx context.Context, id ID, body UpdateBrandJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateBrandResponse, error) {
+	rsp, err := c.UpdateBrand(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateBrandResponse(rsp)
+}
+
+// CreateModelWithBodyWithResponse Add a model to a brand, platform admins only
+//
+// Once a brand has models, cars of that brand that give a model have
+// to give one of them.
+//
+// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /brands/{id}/models (the `CreateModel` operationId).
+func (c *ClientWithResponses) CreateModelWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateModelResponse, error) {
+	rsp, err := c.CreateModelWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateModelResponse(rsp)
+}
+
+// CreateModelWithResponse Add a model to a brand, platform admins only
+//
+// Once a brand has models, cars of that brand that give a model have
+// to give one of them.
+//
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /brands/{id}/models (the `CreateModel` operationId).
+func (c *ClientWithResponses) CreateModelWithResponse(ctx context.Context, id ID, body CreateModelJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateModelResponse, error) {
+	rsp, err := c.CreateModel(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateModelResponse(rsp)
+}
+
+// DeleteModelWithResponse Delete a model, platform admins only
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with DELETE /brands/{id}/models/{modelID} (the `DeleteModel` operationId).
+func (c *ClientWithResponses) DeleteModelWithResponse(ctx context.Context, id ID, modelID openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteModelResponse, error) {
+	rsp, err := c.DeleteModel(ctx, id, modelID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteModelResponse(rsp)
+}
+
+// UpdateModelWithBodyWithResponse Rename a model and replace its aliases, platform admins only
+//
+// Cars listed as the old name are renamed along with it.
+//
+// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with PUT /brands/{id}/models/{modelID} (the `UpdateModel` operationId).
+func (c *ClientWithResponses) UpdateModelWithBodyWithResponse(ctx context.Context, id ID, modelID openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateModelResponse, error) {
+	rsp, err := c.UpdateModelWithBody(ctx, id, modelID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateModelResponse(rsp)
+}
+
+// UpdateModelWithResponse Rename a model and replace its aliases, platform admins only
+//
+// Cars listed as the old name are renamed along with it.
+//
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with PUT /brands/{id}/models/{modelID} (the `UpdateModel` operationId).
+func (c *ClientWithResponses) UpdateModelWithResponse(ctx context.Context, id ID, modelID openapi_types.UUID, body UpdateModelJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateModelResponse, error) {
+	rsp, err := c.UpdateModel(ctx, id, modelID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateModelResponse(rsp)
+}
+
+// ListCarsWithResponse List the cars of a brand, optionally by status
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with GET /cars (the `ListCars` operationId).
+func (c *ClientWithResponses) ListCarsWithResponse(ctx context.Context, params *ListCarsParams, reqEditors ...RequestEditorFn) (*ListCarsResponse, error) {
+	rsp, err := c.ListCars(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListCarsResponse(rsp)
+}
+
+// CreateCarWithBodyWithResponse Create a car
+//
+// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /cars (the `CreateCar` operationId).
+func (c *ClientWithResponses) CreateCarWithBodyWithResponse(ctx context.Context, params *CreateCarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateCarResponse, error) {
+	rsp, err := c.CreateCarWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateCarResponse(rsp)
+}
+
+// CreateCarWithResponse Create a car
+//
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /cars (the `CreateCar` operationId).
+func (c *ClientWithResponses) CreateCarWithResponse(ctx context.Context, params *CreateCarParams, body CreateCarJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateCarResponse, error) {
+	rsp, err := c.CreateCar(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateCarResponse(rsp)
+}
+
+// StreamCarsWithResponse Live car changes as server sent events, or over a websocket on upgrade
+//
+// Each event is named after its type (car.created, car.updated or
+// car.deleted) and carries the car as data. A reset event means the
+// stream could not be resumed from Last-Event-ID and the client should
+// reload its cars. Callers who can't manage inventory only get available
+// cars and their own reservations, without who reserved them.
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with GET /cars/stream (the `StreamCars` operationId).
+func (c *ClientWithResponses) StreamCarsWithResponse(ctx context.Context, params *StreamCarsParams, reqEditors ...RequestEditorFn) (*StreamCarsResponse, error) {
+	rsp, err := c.StreamCars(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamCarsResponse(rsp)
+}
+
+// DeleteCarWithResponse Delete a car
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with DELETE /cars/{id} (the `DeleteCar` operationId).
+func (c *ClientWithResponses) DeleteCarWithResponse(ctx context.Context, id ID, params *DeleteCarParams, reqEditors ...RequestEditorFn) (*DeleteCarResponse, error) {
+	rsp, err := c.DeleteCar(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteCarResponse(rsp)
+}
+
+// GetCarWithResponse Get a car with its engine
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with GET /cars/{id} (the `GetCar` operationId).
+func (c *ClientWithResponses) GetCarWithResponse(ctx context.Context, id ID, params *GetCarParams, reqEditors ...RequestEditorFn) (*GetCarResponse, error) {
+	rsp, err := c.GetCar(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCarResponse(rsp)
+}
+
+// UpdateCarWithBodyWithResponse Replace a car
+//
+// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with PUT /cars/{id} (the `UpdateCar` operationId).
+func (c *ClientWithResponses) UpdateCarWithBodyWithResponse(ctx context.Context, id ID, params *UpdateCarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateCarResponse, error) {
+	rsp, err := c.UpdateCarWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateCarResponse(rsp)
+}
+
+// UpdateCarWithResponse Replace a car
+//
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with PUT /cars/{id} (the `UpdateCar` operationId).
+func (c *ClientWithResponses) UpdateCarWithResponse(ctx context.Context, id ID, params *UpdateCarParams, body UpdateCarJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateCarResponse, error) {
+	rsp, err := c.UpdateCar(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateCarResponse(rsp)
+}
+
+// CancelReservationWithResponse Cancel a reservation
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with DELETE /cars/{id}/reservation (the `CancelReservation` operationId).
+func (c *ClientWithResponses) CancelReservationWithResponse(ctx context.Context, id ID, params *CancelReservationParams, reqEditors ...RequestEditorFn) (*CancelReservationResponse, error) {
+	rsp, err := c.CancelReservation(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelReservationResponse(rsp)
+}
+
+// ReserveCarWithBodyWithResponse Reserve an available car
+//
+// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /cars/{id}/reservation (the `ReserveCar` operationId).
+func (c *ClientWithResponses) ReserveCarWithBodyWithResponse(ctx context.Context, id ID, params *ReserveCarParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReserveCarResponse, error) {
+	rsp, err := c.ReserveCarWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReserveCarResponse(rsp)
+}
+
+// ReserveCarWithResponse Reserve an available car
+//
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /cars/{id}/reservation (the `ReserveCar` operationId).
+func (c *ClientWithResponses) ReserveCarWithResponse(ctx context.Context, id ID, params *ReserveCarParams, body ReserveCarJSONRequestBody, reqEditors ...RequestEditorFn) (*ReserveCarResponse, error) {
+	rsp, err := c.ReserveCar(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReserveCarResponse(rsp)
+}
+
+// MarkCarSoldWithResponse Mark a car as sold
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /cars/{id}/sell (the `MarkCarSold` operationId).
+func (c *ClientWithResponses) MarkCarSoldWithResponse(ctx context.Context, id ID, params *MarkCarSoldParams, reqEditors ...RequestEditorFn) (*MarkCarSoldResponse, error) {
+	rsp, err := c.MarkCarSold(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMarkCarSoldResponse(rsp)
+}
+
+// ChangeCarStatusWithBodyWithResponse Move a car to another status
+//
+// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with PUT /cars/{id}/status (the `ChangeCarStatus` operationId).
+func (c *ClientWithResponses) ChangeCarStatusWithBodyWithResponse(ctx context.Context, id ID, params *ChangeCarStatusParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ChangeCarStatusResponse, error) {
+	rsp, err := c.ChangeCarStatusWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseChangeCarStatusResponse(rsp)
+}
+
+// ChangeCarStatusWithResponse Move a car to another status
+//
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with PUT /cars/{id}/status (the `ChangeCarStatus` operationId).
+func (c *ClientWithResponses) ChangeCarStatusWithResponse(ctx context.Context, id ID, params *ChangeCarStatusParams, body ChangeCarStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*ChangeCarStatusResponse, error) {
+	rsp, err := c.ChangeCarStatus(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseChangeCarStatusResponse(rsp)
+}
+
+// ListEnginesWithResponse List engines
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with GET /engines (the `ListEngines` operationId).
+func (c *ClientWithResponses) ListEnginesWithResponse(ctx context.Context, params *ListEnginesParams, reqEditors ...RequestEditorFn) (*ListEnginesResponse, error) {
+	rsp, err := c.ListEngines(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListEnginesResponse(rsp)
+}
+
+// CreateEngineWithBodyWithResponse Create an engine
+//
+// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /engines (the `CreateEngine` operationId).
+func (c *ClientWithResponses) CreateEngineWithBodyWithResponse(ctx context.Context, params *CreateEngineParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateEngineResponse, error) {
+	rsp, err := c.CreateEngineWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateEngineResponse(rsp)
+}
+
+// CreateEngineWithResponse Create an engine
+//
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /engines (the `CreateEngine` operationId).
+func (c *ClientWithResponses) CreateEngineWithResponse(ctx context.Context, params *CreateEngineParams, body CreateEngineJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateEngineResponse, error) {
+	rsp, err := c.CreateEngine(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateEngineResponse(rsp)
+}
+
+// DeleteEngineWithResponse Delete an engine and the cars using it
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with DELETE /engines/{id} (the `DeleteEngine` operationId).
+func (c *ClientWithResponses) DeleteEngineWithResponse(ctx context.Context, id ID, params *DeleteEngineParams, reqEditors ...RequestEditorFn) (*DeleteEngineResponse, error) {
+	rsp, err := c.DeleteEngine(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteEngineResponse(rsp)
+}
+
+// GetEngineWithResponse Get an engine
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with GET /engines/{id} (the `GetEngine` operationId).
+func (c *ClientWithResponses) GetEngineWithResponse(ctx context.Context, id ID, params *GetEngineParams, reqEditors ...RequestEditorFn) (*GetEngineResponse, error) {
+	rsp, err := c.GetEngine(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEngineResponse(rsp)
+}
+
+// UpdateEngineWithBodyWithResponse Replace an engine's specs
+//
+// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with PUT /engines/{id} (the `UpdateEngine` operationId).
+func (c *ClientWithResponses) UpdateEngineWithBodyWithResponse(ctx context.Context, id ID, params *UpdateEngineParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateEngineResponse, error) {
+	rsp, err := c.UpdateEngineWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateEngineResponse(rsp)
+}
+
+// UpdateEngineWithResponse Replace an engine's specs
+//
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with PUT /engines/{id} (the `UpdateEngine` operationId).
+func (c *ClientWithResponses) UpdateEngineWithResponse(ctx context.Context, id ID, params *UpdateEngineParams, body UpdateEngineJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateEngineResponse, error) {
+	rsp, err := c.UpdateEngine(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateEngineResponse(rsp)
+}
+
+// LoginWithBodyWithResponse Exchange local credentials for a bearer token
+//
+// Attempts are limited per client address and per username.
+//
+// Takes any type of body and a specified content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /login (the `Login` operationId).
+func (c *ClientWithResponses) LoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginResponse, error) {
+	rsp, err := c.LoginWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginResponse(rsp)
+}
+
+// LoginWithResponse Exchange local credentials for a bearer token
+//
+// Attempts are limited per client address and per username.
+//
+// Takes a body of the `application/json` content type, and returns a wrapper object for the known response body format(s).
+//
+// Corresponds with POST /login (the `Login` operationId).
+func (c *ClientWithResponses) LoginWithResponse(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginResponse, error) {
+	rsp, err := c.Login(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginResponse(rsp)
+}
+
+// ListMyCarsWithResponse Cars created or reserved by the caller
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with GET /me/cars (the `ListMyCars` operationId).
+func (c *ClientWithResponses) ListMyCarsWithResponse(ctx context.Context, params *ListMyCarsParams, reqEditors ...RequestEditorFn) (*ListMyCarsResponse, error) {
+	rsp, err := c.ListMyCars(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListMyCarsResponse(rsp)
+}
+
+// DecodeVINWithResponse Decode a VIN into its manufacturer, brand, model year and plant
+//
+// Decoded from built in manufacturer tables, and from an external
+// provider when one is configured, which adds the model. Creating a car
+// with a VIN fills in the brand, model, year and name it leaves out the same way.
+//
+// Returns a wrapper object for the known response body format(s).
+//
+// Corresponds with GET /vin/{vin} (the `DecodeVIN` operationId).
+func (c *ClientWithResponses) DecodeVINWithResponse(ctx context.Context, vin string, reqEditors ...RequestEditorFn) (*DecodeVINResponse, error) {
+	rsp, err := c.DecodeVIN(ctx, vin, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDecodeVINResponse(rsp)
+}
+
+// ParseListBrandsResponse parses an HTTP response from a ListBrandsWithResponse call
+func ParseListBrandsResponse(rsp *http.Response) (*ListBrandsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListBrandsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Brand
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateBrandResponse parses an HTTP response from a CreateBrandWithResponse call
+func ParseCreateBrandResponse(rsp *http.Response) (*CreateBrandResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateBrandResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Brand
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteBrandResponse parses an HTTP response from a DeleteBrandWithResponse call
+func ParseDeleteBrandResponse(rsp *http.Response) (*DeleteBrandResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteBrandResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Brand
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBrandResponse parses an HTTP response from a GetBrandWithResponse call
+func ParseGetBrandResponse(rsp *http.Response) (*GetBrandResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBrandResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Brand
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateBrandResponse parses an HTTP response from a UpdateBrandWithResponse call
+func ParseUpdateBrandResponse(rsp *http.Response) (*UpdateBrandResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateBrandResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Brand
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateModelResponse parses an HTTP response from a CreateModelWithResponse call
+func ParseCreateModelResponse(rsp *http.Response) (*CreateModelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateModelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CarModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteModelResponse parses an HTTP response from a DeleteModelWithResponse call
+func ParseDeleteModelResponse(rsp *http.Response) (*DeleteModelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteModelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CarModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateModelResponse parses an HTTP response from a UpdateModelWithResponse call
+func ParseUpdateModelResponse(rsp *http.Response) (*UpdateModelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateModelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CarModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListCarsResponse parses an HTTP response from a ListCarsWithResponse call
+func ParseListCarsResponse(rsp *http.Response) (*ListCarsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListCarsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Car
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateCarResponse parses an HTTP response from a CreateCarWithResponse call
+func ParseCreateCarResponse(rsp *http.Response) (*CreateCarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateCarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Car
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamCarsResponse parses an HTTP response from a StreamCarsWithResponse call
+func ParseStreamCarsResponse(rsp *http.Response) (*StreamCarsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamCarsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseDeleteCarResponse parses an HTTP response from a DeleteCarWithResponse call
+func ParseDeleteCarResponse(rsp *http.Response) (*DeleteCarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteCarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Car
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCarResponse parses an HTTP response from a GetCarWithResponse call
+func ParseGetCarResponse(rsp *http.Response) (*GetCarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Car
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case rsp.StatusCode == 304:
+		break // No content-type
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateCarResponse parses an HTTP response from a UpdateCarWithResponse call
+func ParseUpdateCarResponse(rsp *http.Response) (*UpdateCarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateCarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Car
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCancelReservationResponse parses an HTTP response from a CancelReservationWithResponse call
+func ParseCancelReservationResponse(rsp *http.Response) (*CancelReservationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CancelReservationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Car
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReserveCarResponse parses an HTTP response from a ReserveCarWithResponse call
+func ParseReserveCarResponse(rsp *http.Response) (*ReserveCarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReserveCarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Car
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseMarkCarSoldResponse parses an HTTP response from a MarkCarSoldWithResponse call
+func ParseMarkCarSoldResponse(rsp *http.Response) (*MarkCarSoldResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MarkCarSoldResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Car
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseChangeCarStatusResponse parses an HTTP response from a ChangeCarStatusWithResponse call
+func ParseChangeCarStatusResponse(rsp *http.Response) (*ChangeCarStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ChangeCarStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Car
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListEnginesResponse parses an HTTP response from a ListEnginesWithResponse call
+func ParseListEnginesResponse(rsp *http.Response) (*ListEnginesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListEnginesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Engine
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateEngineResponse parses an HTTP response from a CreateEngineWithResponse call
+func ParseCreateEngineResponse(rsp *http.Response) (*CreateEngineResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateEngineResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Engine
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteEngineResponse parses an HTTP response from a DeleteEngineWithResponse call
+func ParseDeleteEngineResponse(rsp *http.Response) (*DeleteEngineResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteEngineResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Engine
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEngineResponse parses an HTTP response from a GetEngineWithResponse call
+func ParseGetEngineResponse(rsp *http.Response) (*GetEngineResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEngineResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Engine
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case rsp.StatusCode == 304:
+		break // No content-type
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateEngineResponse parses an HTTP response from a UpdateEngineWithResponse call
+func ParseUpdateEngineResponse(rsp *http.Response) (*UpdateEngineResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateEngineResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Engine
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLoginResponse parses an HTTP response from a LoginWithResponse call
+func ParseLoginResponse(rsp *http.Response) (*LoginResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Token
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListMyCarsResponse parses an HTTP response from a ListMyCarsWithResponse call
+func ParseListMyCarsResponse(rsp *http.Response) (*ListMyCarsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListMyCarsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Car
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDecodeVINResponse parses an HTTP response from a DecodeVINWithResponse call
+func ParseDecodeVINResponse(rsp *http.Response) (*DecodeVINResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DecodeVINResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VINInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
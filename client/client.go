@@ -0,0 +1,5 @@
+// Package client is a typed Go client for the CarZone REST API, generated from
+// openapi/openapi.yaml. Regenerate it with go generate after changing the document.
+package client
+
+//go:generate oapi-codegen -config oapi-codegen.yaml ../openapi/openapi.yaml
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/client"
+)
+
+func (a *app)cars(ctx context.Context,args []string) error {
+	action,args,err := subcommand(a.stderr,"cars",args)
+	if err != nil {
+		return err
+	}
+	switch action {
+	case "list":
+		return a.listCars(ctx,args)
+	case "get":
+		return a.getCar(ctx,args)
+	case "create":
+		return a.createCar(ctx,args)
+	case "update":
+		return a.updateCar(ctx,args)
+	case "delete":
+		return a.deleteCar(ctx,args)
+	case "import":
+		return a.importCars(ctx,args)
+	default:
+		return fmt.Errorf("unknown cars action %q",action)
+	}
+}
+
+func (a *app)listCars(ctx context.Context,args []string) error {
+	fs := flag.NewFlagSet("cars list",flag.ContinueOnError)
+	brand := fs.String("brand","","the brand to list, required")
+	status := fs.String("status","","comma separated statuses")
+	engines := fs.Bool("engines",false,"include engine specs")
+	vin := fs.String("vin","","only the car with this VIN")
+	transmission := fs.String("transmission","","manual, automatic, cvt or dct")
+	drivetrain := fs.String("drivetrain","","fwd, rwd, awd or 4wd")
+	bodyType := fs.String("body","","body type, such as sedan, suv or pickup")
+	condition := fs.String("condition","","new, used or certified_pre_owned")
+	colour := fs.String("colour","","colour, ignoring case")
+	maxOdometer := fs.Int("max-odometer",0,"at most this many km, zero for any")
+	features := fs.String("features","","comma separated features the cars must all have")
+	if err := parseFlags(fs,args); err != nil {
+		return err
+	}
+	if *brand == "" {
+		return errors.New("cars list needs -brand")
+	}
+	params := &client.ListCarsParams{XTenantID: a.tenant,Brand: brand,IsEngine: engines}
+	if *status != "" {
+		params.Status = status
+	}
+	if *vin != "" {
+		params.Vin = vin
+	}
+	if *transmission != "" {
+		params.Transmission = (*client.Transmission)(transmission)
+	}
+	if *drivetrain != "" {
+		params.Drivetrain = (*client.Drivetrain)(drivetrain)
+	}
+	if *bodyType != "" {
+		params.BodyType = (*client.BodyType)(bodyType)
+	}
+	if *condition != "" {
+		params.Condition = (*client.Condition)(condition)
+	}
+	if *colour != "" {
+		params.Colour = colour
+	}
+	if *maxOdometer != 0 {
+		params.MaxOdometerKm = maxOdometer
+	}
+	if *features != "" {
+		params.Features = features
+	}
+	resp,err := a.api.ListCarsWithResponse(ctx,params)
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.HTTPResponse,resp.Body)
+	}
+	return a.print(*resp.JSON200,carColumns,carRows(*resp.JSON200...))
+}
+
+func (a *app)getCar(ctx context.Context,args []string) error {
+	fs := flag.NewFlagSet("cars get",flag.ContinueOnError)
+	if err := parseFlags(fs,args); err != nil {
+		return err
+	}
+	id,err := idArg(fs)
+	if err != nil {
+		return err
+	}
+	car,err := a.fetchCar(ctx,id)
+	if err != nil {
+		return err
+	}
+	return a.print(car,carColumns,carRows(*car))
+}
+
+func (a *app)fetchCar(ctx context.Context,id uuid.UUID) (*client.Car,error) {
+	resp,err := a.api.GetCarWithResponse(ctx,id,&client.GetCarParams{XTenantID: a.tenant})
+	if err != nil {
+		return nil,err
+	}
+	if resp.JSON200 == nil {
+		return nil,apiError(resp.HTTPResponse,resp.Body)
+	}
+	return resp.JSON200,nil
+}
+
+// carFlags are the fields of a car settable from the command line, on update
+// only the ones given replace the car's current values.
+type carFlags struct {
+	fs *flag.FlagSet
+	file *string
+	name *string
+	year *string
+	brand *string
+	model *string
+	fuelType *string
+	engine *string
+	price *float64
+	status *string
+	vin *string
+	odometer *int
+	transmission *string
+	drivetrain *string
+	colour *string
+	bodyType *string
+	condition *string
+	features *string
+}
+
+func newCarFlags(name string) *carFlags {
+	fs := flag.NewFlagSet(name,flag.ContinueOnError)
+	return &carFlags{
+		fs: fs,
+		file: fs.String("f","","JSON or YAML file with the car, - for stdin"),
+		name: fs.String("name","","name"),
+		year: fs.String("year","","model year"),
+		brand: fs.String("brand","","brand, any spelling the catalogue knows"),
+		model: fs.String("model","","model"),
+		fuelType: fs.String("fuel","","fuel type: Petrol, Diesel, Electric or Hybrid"),
+		engine: fs.String("engine","","engine ID"),
+		price: fs.Float64("price",0,"price"),
+		status: fs.String("status","","draft or available"),
+		vin: fs.String("vin","","vehicle identification number"),
+		odometer: fs.Int("odometer",0,"odometer reading in km"),
+		transmission: fs.String("transmission","","manual, automatic, cvt or dct"),
+		drivetrain: fs.String("drivetrain","","fwd, rwd, awd or 4wd"),
+		colour: fs.String("colour","","colour"),
+		bodyType: fs.String("body","","body type, such as sedan, suv or pickup"),
+		condition: fs.String("condition","","new, used or certified_pre_owned"),
+		features: fs.String("features","","comma separated features, replacing the current ones"),
+	}
+}
+
+// apply reads the file, if any, then the flags that were set over req.
+func (f *carFlags)apply(a *app,req *client.CarRequest) error {
+	if *f.file != "" {
+		records,err := readRecords(a,*f.file)
+		if err != nil {
+			return err
+		}
+		if len(records) != 1 {
+			return fmt.Errorf("%s holds %d cars, use cars import for more than one",*f.file,len(records))
+		}
+		if *req,err = carRequest(records[0]); err != nil {
+			return err
+		}
+	}
+	var err error
+	f.fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "name":
+			req.Name = *f.name
+		case "year":
+			req.Year = *f.year
+		case "brand":
+			req.Brand = *f.brand
+		case "model":
+			req.Model = f.model
+		case "fuel":
+			req.FuelType = client.FuelType(*f.fuelType)
+		case "engine":
+			var id uuid.UUID
+			if id,err = uuid.Parse(*f.engine); err != nil {
+				err = fmt.Errorf("invalid engine: %w",err)
+			}
+			req.Engine = client.Engine{EngineId: id}
+		case "price":
+			req.Price = *f.price
+		case "status":
+			status := client.CarRequestStatus(*f.status)
+			req.Status = &status
+		case "vin":
+			req.Vin = f.vin
+		case "odometer":
+			req.OdometerKm = f.odometer
+		case "transmission":
+			req.Transmission = (*client.Transmission)(f.transmission)
+		case "drivetrain":
+			req.Drivetrain = (*client.Drivetrain)(f.drivetrain)
+		case "colour":
+			req.Colour = f.colour
+		case "body":
+			req.BodyType = (*client.BodyType)(f.bodyType)
+		case "condition":
+			req.Condition = (*client.Condition)(f.condition)
+		case "features":
+			features := splitList(*f.features)
+			req.Features = &features
+		}
+	})
+	return err
+}
+
+func (a *app)createCar(ctx context.Context,args []string) error {
+	f := newCarFlags("cars create")
+	if err := parseFlags(f.fs,args); err != nil {
+		return err
+	}
+	var req client.CarRequest
+	if err := f.apply(a,&req); err != nil {
+		return err
+	}
+	car,err := a.postCar(ctx,req,nil)
+	if err != nil {
+		return err
+	}
+	return a.print(car,carColumns,carRows(*car))
+}
+
+func (a *app)postCar(ctx context.Context,req client.CarRequest,idempotencyKey *string) (*client.Car,error) {
+	resp,err := a.api.CreateCarWithResponse(ctx,&client.CreateCarParams{XTenantID: a.tenant,IdempotencyKey: idempotencyKey},req)
+	if err != nil {
+		return nil,err
+	}
+	if resp.JSON201 == nil {
+		return nil,apiError(resp.HTTPResponse,resp.Body)
+	}
+	return resp.JSON201,nil
+}
+
+func (a *app)updateCar(ctx context.Context,args []string) error {
+	f := newCarFlags("cars update")
+	if err := parseFlags(f.fs,args); err != nil {
+		return err
+	}
+	id,err := idArg(f.fs)
+	if err != nil {
+		return err
+	}
+	current,err := a.fetchCar(ctx,id)
+	if err != nil {
+		return err
+	}
+	// status moves through its own endpoints, it is only sent when asked for
+	req := client.CarRequest{
+		Name: current.Name,
+		Year: current.Year,
+		Brand: current.Brand,
+		Model: current.Model,
+		FuelType: current.FuelType,
+		Engine: client.Engine{EngineId: current.Engine.EngineId},
+		Price: current.Price,
+		Vin: current.Vin,
+		OdometerKm: current.OdometerKm,
+		Transmission: current.Transmission,
+		Drivetrain: current.Drivetrain,
+		Colour: current.Colour,
+		BodyType: current.BodyType,
+		Condition: current.Condition,
+		Features: current.Features,
+	}
+	if err := f.apply(a,&req); err != nil {
+		return err
+	}
+	resp,err := a.api.UpdateCarWithResponse(ctx,id,&client.UpdateCarParams{XTenantID: a.tenant},req)
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.HTTPResponse,resp.Body)
+	}
+	return a.print(resp.JSON200,carColumns,carRows(*resp.JSON200))
+}
+
+func (a *app)deleteCar(ctx context.Context,args []string) error {
+	fs := flag.NewFlagSet("cars delete",flag.ContinueOnError)
+	if err := parseFlags(fs,args); err != nil {
+		return err
+	}
+	id,err := idArg(fs)
+	if err != nil {
+		return err
+	}
+	resp,err := a.api.DeleteCarWithResponse(ctx,id,&client.DeleteCarParams{XTenantID: a.tenant})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.HTTPResponse,resp.Body)
+	}
+	return a.print(resp.JSON200,carColumns,carRows(*resp.JSON200))
+}
+
+func (a *app)importCars(ctx context.Context,args []string) error {
+	fs := flag.NewFlagSet("cars import",flag.ContinueOnError)
+	if err := parseFlags(fs,args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("import takes exactly one file")
+	}
+	created := []client.Car{}
+	err := a.importFile(fs.Arg(0),"cars",func(record map[string]interface{},idempotencyKey string) error {
+		req,err := carRequest(record)
+		if err != nil {
+			return err
+		}
+		car,err := a.postCar(ctx,req,&idempotencyKey)
+		if err != nil {
+			return err
+		}
+		created = append(created,*car)
+		return nil
+	})
+	if len(created) == 0 {
+		return err
+	}
+	if printErr := a.print(created,carColumns,carRows(created...)); printErr != nil {
+		return printErr
+	}
+	return err
+}
+
+// carRequest accepts the car JSON of the API, and for CSV rows and hand
+// written YAML a flat engine_id and numeric years.
+func carRequest(record map[string]interface{}) (client.CarRequest,error) {
+	req := client.CarRequest{
+		Name: stringField(record,"name"),
+		Year: stringField(record,"year"),
+		Brand: stringField(record,"brand"),
+		FuelType: client.FuelType(stringField(record,"fuelType")),
+	}
+	price,err := numberField(record,"price")
+	if err != nil {
+		return req,err
+	}
+	req.Price = price
+	engineID := stringField(record,"engine_id")
+	if engine,ok := record["engine"].(map[string]interface{}); ok {
+		engineID = stringField(engine,"engine_id")
+	}
+	if engineID != "" {
+		if req.Engine.EngineId,err = uuid.Parse(engineID); err != nil {
+			return req,fmt.Errorf("invalid engine_id: %w",err)
+		}
+	}
+	if status := stringField(record,"status"); status != "" {
+		s := client.CarRequestStatus(status)
+		req.Status = &s
+	}
+	if model := stringField(record,"model"); model != "" {
+		req.Model = &model
+	}
+	if vin := stringField(record,"vin"); vin != "" {
+		req.Vin = &vin
+	}
+	if _,ok := record["odometer_km"]; ok {
+		odometer,err := intField(record,"odometer_km")
+		if err != nil {
+			return req,err
+		}
+		km := int(odometer)
+		req.OdometerKm = &km
+	}
+	if transmission := stringField(record,"transmission"); transmission != "" {
+		req.Transmission = (*client.Transmission)(&transmission)
+	}
+	if drivetrain := stringField(record,"drivetrain"); drivetrain != "" {
+		req.Drivetrain = (*client.Drivetrain)(&drivetrain)
+	}
+	if colour := stringField(record,"colour"); colour != "" {
+		req.Colour = &colour
+	}
+	if bodyType := stringField(record,"body_type"); bodyType != "" {
+		req.BodyType = (*client.BodyType)(&bodyType)
+	}
+	if condition := stringField(record,"condition"); condition != "" {
+		req.Condition = (*client.Condition)(&condition)
+	}
+	if features := listField(record,"features"); len(features) > 0 {
+		req.Features = &features
+	}
+	return req,nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/iangechuki/go_carzone/client"
+	"golang.org/x/term"
+)
+
+// config is cached in the user's config directory, readable only by them
+// since it holds a bearer token.
+type config struct {
+	Server string `json:"server"`
+	Token string `json:"token"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+func configPath() (string,error) {
+	if path := os.Getenv("CARZONE_CONFIG"); path != "" {
+		return path,nil
+	}
+	dir,err := os.UserConfigDir()
+	if err != nil {
+		return "",err
+	}
+	return filepath.Join(dir,"carzone","config.json"),nil
+}
+
+// loadConfig returns an empty config before the first login.
+func loadConfig() (*config,error) {
+	path,err := configPath()
+	if err != nil {
+		return nil,err
+	}
+	data,err := os.ReadFile(path)
+	if errors.Is(err,os.ErrNotExist) {
+		return &config{},nil
+	}
+	if err != nil {
+		return nil,err
+	}
+	var cfg config
+	if err := json.Unmarshal(data,&cfg); err != nil {
+		return nil,fmt.Errorf("reading %s: %w",path,err)
+	}
+	return &cfg,nil
+}
+
+func (c *config)save() error {
+	path,err := configPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path),0o700); err != nil {
+		return err
+	}
+	data,err := json.MarshalIndent(c,"","  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path,data,0o600)
+}
+
+// token returns the cached token for server, failing early rather than
+// letting the server answer 401 when there is none or it has expired.
+func (c *config)token(server string) (string,error) {
+	switch {
+	case c.Token == "" || c.Server != server:
+		return "",fmt.Errorf("not logged in to %s, run carzonectl login",server)
+	case !c.ExpiresAt.IsZero() && time.Now().After(c.ExpiresAt):
+		return "",errors.New("session expired, run carzonectl login")
+	}
+	return c.Token,nil
+}
+
+// tokenExpiry reads the exp claim without verifying the token, only the
+// server can do that. A token it can't read is treated as never expiring.
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token,".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload,err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if json.Unmarshal(payload,&claims) != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp,0)
+}
+
+func (a *app)login(ctx context.Context,args []string) error {
+	fs := flag.NewFlagSet("login",flag.ContinueOnError)
+	username := fs.String("u","","username, prompted for when left out")
+	password := fs.String("p","","password, prompted for when left out; prefer the prompt, flags end up in shell history")
+	if err := parseFlags(fs,args); err != nil {
+		return err
+	}
+	in := bufio.NewReader(a.stdin)
+	if *username == "" {
+		fmt.Fprint(a.stderr,"Username: ")
+		line,err := in.ReadString('\n')
+		if err != nil && line == "" {
+			return err
+		}
+		*username = strings.TrimSpace(line)
+	}
+	if *password == "" {
+		secret,err := readPassword(a,in)
+		if err != nil {
+			return err
+		}
+		*password = secret
+	}
+
+	// /login is not versioned, so it doesn't go through a.api
+	auth,err := client.NewClientWithResponses(a.server)
+	if err != nil {
+		return err
+	}
+	resp,err := auth.LoginWithResponse(ctx,client.Credentials{Username: *username,Password: *password})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.HTTPResponse,resp.Body)
+	}
+	a.config.Server = a.server
+	a.config.Token = resp.JSON200.Token
+	a.config.ExpiresAt = tokenExpiry(resp.JSON200.Token)
+	if err := a.config.save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr,"Logged in to %s as %s\n",a.server,*username)
+	return nil
+}
+
+// readPassword doesn't echo when reading from a terminal, piped input is read as a line.
+func readPassword(a *app,in *bufio.Reader) (string,error) {
+	fmt.Fprint(a.stderr,"Password: ")
+	if f,ok := a.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		secret,err := term.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(a.stderr)
+		return string(secret),err
+	}
+	line,err := in.ReadString('\n')
+	if err != nil && line == "" {
+		return "",err
+	}
+	return strings.TrimRight(line,"\r\n"),nil
+}
+
+func (a *app)logout() error {
+	a.config.Token = ""
+	a.config.ExpiresAt = time.Time{}
+	if err := a.config.save(); err != nil {
+		return err
+	}
+	fmt.Fprintln(a.stderr,"Logged out")
+	return nil
+}
+
+// parseFlags turns -h and bad flags into errUsage, the flag package has
+// already printed the problem along with the defaults.
+func parseFlags(fs *flag.FlagSet,args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/client"
+)
+
+func (a *app)engines(ctx context.Context,args []string) error {
+	action,args,err := subcommand(a.stderr,"engines",args)
+	if err != nil {
+		return err
+	}
+	switch action {
+	case "list":
+		return a.listEngines(ctx,args)
+	case "get":
+		return a.getEngine(ctx,args)
+	case "create":
+		return a.createEngine(ctx,args)
+	case "update":
+		return a.updateEngine(ctx,args)
+	case "delete":
+		return a.deleteEngine(ctx,args)
+	case "import":
+		return a.importEngines(ctx,args)
+	default:
+		return fmt.Errorf("unknown engines action %q",action)
+	}
+}
+
+func (a *app)listEngines(ctx context.Context,args []string) error {
+	fs := flag.NewFlagSet("engines list",flag.ContinueOnError)
+	limit := fs.Int("limit",0,"page size, at most 100, zero for all")
+	offset := fs.Int("offset",0,"engines to skip")
+	if err := parseFlags(fs,args); err != nil {
+		return err
+	}
+	resp,err := a.api.ListEnginesWithResponse(ctx,&client.ListEnginesParams{XTenantID: a.tenant,Limit: limit,Offset: offset})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.HTTPResponse,resp.Body)
+	}
+	return a.print(*resp.JSON200,engineColumns,engineRows(*resp.JSON200...))
+}
+
+func (a *app)getEngine(ctx context.Context,args []string) error {
+	fs := flag.NewFlagSet("engines get",flag.ContinueOnError)
+	if err := parseFlags(fs,args); err != nil {
+		return err
+	}
+	id,err := idArg(fs)
+	if err != nil {
+		return err
+	}
+	engine,err := a.fetchEngine(ctx,id)
+	if err != nil {
+		return err
+	}
+	return a.print(engine,engineColumns,engineRows(*engine))
+}
+
+func (a *app)fetchEngine(ctx context.Context,id uuid.UUID) (*client.Engine,error) {
+	resp,err := a.api.GetEngineWithResponse(ctx,id,&client.GetEngineParams{XTenantID: a.tenant})
+	if err != nil {
+		return nil,err
+	}
+	if resp.JSON200 == nil {
+		return nil,apiError(resp.HTTPResponse,resp.Body)
+	}
+	return resp.JSON200,nil
+}
+
+// engineFlags are the specs of an engine settable from the command line, on
+// update only the ones given replace the engine's current values.
+type engineFlags struct {
+	fs *flag.FlagSet
+	file *string
+	fuelType *string
+	displacement *int64
+	cylinders *int64
+	carRange *int64
+	battery *float64
+	motor *int64
+	charging *string
+	horsepower *int64
+	torque *int64
+	co2 *int64
+}
+
+func newEngineFlags(name string) *engineFlags {
+	fs := flag.NewFlagSet(name,flag.ContinueOnError)
+	return &engineFlags{
+		fs: fs,
+		file: fs.String("f","","JSON or YAML file with the engine, - for stdin"),
+		fuelType: fs.String("fuel","","powertrain: Petrol, Diesel, Electric or Hybrid"),
+		displacement: fs.Int64("displacement",0,"displacement in cc"),
+		cylinders: fs.Int64("cylinders",0,"number of cylinders"),
+		carRange: fs.Int64("range",0,"range in km"),
+		battery: fs.Float64("battery",0,"battery capacity in kWh"),
+		motor: fs.Int64("motor",0,"motor power in kW"),
+		charging: fs.String("charging","","charging standard, such as type2, ccs2 or nacs"),
+		horsepower: fs.Int64("hp",0,"horsepower"),
+		torque: fs.Int64("torque",0,"torque in Nm"),
+		co2: fs.Int64("co2",0,"CO2 emissions in g/km"),
+	}
+}
+
+// apply reads the file, if any, then the flags that were set over req.
+func (f *engineFlags)apply(a *app,req *client.EngineRequest) error {
+	if *f.file != "" {
+		records,err := readRecords(a,*f.file)
+		if err != nil {
+			return err
+		}
+		if len(records) != 1 {
+			return fmt.Errorf("%s holds %d engines, use engines import for more than one",*f.file,len(records))
+		}
+		if *req,err = engineRequest(records[0]); err != nil {
+			return err
+		}
+	}
+	f.fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "fuel":
+			req.FuelType = (*client.FuelType)(f.fuelType)
+		case "displacement":
+			req.Displacement = f.displacement
+		case "cylinders":
+			req.NoOfCylinders = f.cylinders
+		case "range":
+			req.CarRange = *f.carRange
+		case "battery":
+			req.BatteryCapacityKwh = f.battery
+		case "motor":
+			req.MotorPowerKw = f.motor
+		case "charging":
+			req.ChargingStandard = (*client.ChargingStandard)(f.charging)
+		case "hp":
+			req.Horsepower = f.horsepower
+		case "torque":
+			req.TorqueNm = f.torque
+		case "co2":
+			req.Co2GPerKm = f.co2
+		}
+	})
+	return nil
+}
+
+func (a *app)createEngine(ctx context.Context,args []string) error {
+	f := newEngineFlags("engines create")
+	if err := parseFlags(f.fs,args); err != nil {
+		return err
+	}
+	var req client.EngineRequest
+	if err := f.apply(a,&req); err != nil {
+		return err
+	}
+	engine,err := a.postEngine(ctx,req,nil)
+	if err != nil {
+		return err
+	}
+	return a.print(engine,engineColumns,engineRows(*engine))
+}
+
+func (a *app)postEngine(ctx context.Context,req client.EngineRequest,idempotencyKey *string) (*client.Engine,error) {
+	resp,err := a.api.CreateEngineWithResponse(ctx,&client.CreateEngineParams{XTenantID: a.tenant,IdempotencyKey: idempotencyKey},req)
+	if err != nil {
+		return nil,err
+	}
+	if resp.JSON200 == nil {
+		return nil,apiError(resp.HTTPResponse,resp.Body)
+	}
+	return resp.JSON200,nil
+}
+
+func (a *app)updateEngine(ctx context.Context,args []string) error {
+	f := newEngineFlags("engines update")
+	if err := parseFlags(f.fs,args); err != nil {
+		return err
+	}
+	id,err := idArg(f.fs)
+	if err != nil {
+		return err
+	}
+	current,err := a.fetchEngine(ctx,id)
+	if err != nil {
+		return err
+	}
+	req := client.EngineRequest{
+		FuelType: current.FuelType,
+		Displacement: current.Displacement,
+		NoOfCylinders: current.NoOfCylinders,
+		BatteryCapacityKwh: current.BatteryCapacityKwh,
+		MotorPowerKw: current.MotorPowerKw,
+		ChargingStandard: current.ChargingStandard,
+		Horsepower: current.Horsepower,
+		TorqueNm: current.TorqueNm,
+		Co2GPerKm: current.Co2GPerKm,
+	}
+	if current.CarRange != nil {
+		req.CarRange = *current.CarRange
+	}
+	if err := f.apply(a,&req); err != nil {
+		return err
+	}
+	resp,err := a.api.UpdateEngineWithResponse(ctx,id,&client.UpdateEngineParams{XTenantID: a.tenant},req)
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.HTTPResponse,resp.Body)
+	}
+	return a.print(resp.JSON200,engineColumns,engineRows(*resp.JSON200))
+}
+
+func (a *app)deleteEngine(ctx context.Context,args []string) error {
+	fs := flag.NewFlagSet("engines delete",flag.ContinueOnError)
+	if err := parseFlags(fs,args); err != nil {
+		return err
+	}
+	id,err := idArg(fs)
+	if err != nil {
+		return err
+	}
+	resp,err := a.api.DeleteEngineWithResponse(ctx,id,&client.DeleteEngineParams{XTenantID: a.tenant})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.HTTPResponse,resp.Body)
+	}
+	return a.print(resp.JSON200,engineColumns,engineRows(*resp.JSON200))
+}
+
+func (a *app)importEngines(ctx context.Context,args []string) error {
+	fs := flag.NewFlagSet("engines import",flag.ContinueOnError)
+	if err := parseFlags(fs,args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("import takes exactly one file")
+	}
+	created := []client.Engine{}
+	err := a.importFile(fs.Arg(0),"engines",func(record map[string]interface{},idempotencyKey string) error {
+		req,err := engineRequest(record)
+		if err != nil {
+			return err
+		}
+		engine,err := a.postEngine(ctx,req,&idempotencyKey)
+		if err != nil {
+			return err
+		}
+		created = append(created,*engine)
+		return nil
+	})
+	if len(created) == 0 {
+		return err
+	}
+	if printErr := a.print(created,engineColumns,engineRows(created...)); printErr != nil {
+		return printErr
+	}
+	return err
+}
+
+// engineRequest leaves out the specs a record doesn't give, as electric
+// engines have no displacement and combustion ones no battery.
+func engineRequest(record map[string]interface{}) (client.EngineRequest,error) {
+	var req client.EngineRequest
+	var err error
+	if fuelType := stringField(record,"fuel_type"); fuelType != "" {
+		req.FuelType = (*client.FuelType)(&fuelType)
+	}
+	if charging := stringField(record,"charging_standard"); charging != "" {
+		req.ChargingStandard = (*client.ChargingStandard)(&charging)
+	}
+	if req.CarRange,err = intField(record,"car_range"); err != nil {
+		return req,err
+	}
+	for key,dest := range map[string]**int64{
+		"displacement": &req.Displacement,
+		"no_of_cylinders": &req.NoOfCylinders,
+		"motor_power_kw": &req.MotorPowerKw,
+		"horsepower": &req.Horsepower,
+		"torque_nm": &req.TorqueNm,
+		"co2_g_per_km": &req.Co2GPerKm,
+	} {
+		if _,ok := record[key]; !ok {
+			continue
+		}
+		n,err := intField(record,key)
+		if err != nil {
+			return req,err
+		}
+		*dest = &n
+	}
+	if _,ok := record["battery_capacity_kwh"]; ok {
+		battery,err := numberField(record,"battery_capacity_kwh")
+		if err != nil {
+			return req,err
+		}
+		req.BatteryCapacityKwh = &battery
+	}
+	return req,nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readRecords reads a JSON or YAML document holding one object or a list of
+// them, or a CSV file with a header row naming the fields. path - is stdin.
+func readRecords(a *app,path string) ([]map[string]interface{},error) {
+	data,err := readFile(a,path)
+	if err != nil {
+		return nil,err
+	}
+	return parseRecords(path,data)
+}
+
+func readFile(a *app,path string) ([]byte,error) {
+	if path == "-" {
+		return io.ReadAll(a.stdin)
+	}
+	return os.ReadFile(path)
+}
+
+func parseRecords(path string,data []byte) ([]map[string]interface{},error) {
+	if strings.EqualFold(filepath.Ext(path),".csv") {
+		return parseCSV(data)
+	}
+	// JSON is YAML, one decoder reads both
+	var doc interface{}
+	if err := yaml.Unmarshal(data,&doc); err != nil {
+		return nil,fmt.Errorf("reading %s: %w",path,err)
+	}
+	switch doc := doc.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{doc},nil
+	case []interface{}:
+		records := make([]map[string]interface{},len(doc))
+		for i,item := range doc {
+			record,ok := item.(map[string]interface{})
+			if !ok {
+				return nil,fmt.Errorf("reading %s: item %d is not an object",path,i+1)
+			}
+			records[i] = record
+		}
+		return records,nil
+	default:
+		return nil,fmt.Errorf("reading %s: expected an object or a list of objects",path)
+	}
+}
+
+func parseCSV(data []byte) ([]map[string]interface{},error) {
+	rows,err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		return nil,err
+	}
+	if len(rows) == 0 {
+		return nil,nil
+	}
+	header := rows[0]
+	records := make([]map[string]interface{},0,len(rows)-1)
+	for _,row := range rows[1:] {
+		record := make(map[string]interface{},len(header))
+		for i,field := range header {
+			if value := strings.TrimSpace(row[i]); value != "" {
+				record[strings.TrimSpace(field)] = value
+			}
+		}
+		records = append(records,record)
+	}
+	return records,nil
+}
+
+// importFile calls create for each record of path in order, carrying on past
+// failures. Every record gets an Idempotency-Key derived from the file's
+// contents and its position, so running the same file again after a partial
+// failure doesn't create duplicates while the server still remembers the keys.
+func (a *app)importFile(path,resource string,create func(record map[string]interface{},idempotencyKey string) error) error {
+	data,err := readFile(a,path)
+	if err != nil {
+		return err
+	}
+	records,err := parseRecords(path,data)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	prefix := "import-" + hex.EncodeToString(sum[:12])
+	failed := 0
+	for i,record := range records {
+		if err := create(record,fmt.Sprintf("%s-%d",prefix,i)); err != nil {
+			failed++
+			fmt.Fprintf(a.stderr,"record %d: %v\n",i+1,err)
+		}
+	}
+	fmt.Fprintf(a.stderr,"Imported %d of %d %s\n",len(records)-failed,len(records),resource)
+	if failed > 0 {
+		return fmt.Errorf("%d %s failed to import",failed,resource)
+	}
+	return nil
+}
+
+func stringField(record map[string]interface{},key string) string {
+	switch v := record[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func numberField(record map[string]interface{},key string) (float64,error) {
+	switch v := record[key].(type) {
+	case nil:
+		return 0,nil
+	case int:
+		return float64(v),nil
+	case float64:
+		return v,nil
+	case string:
+		n,err := strconv.ParseFloat(v,64)
+		if err != nil {
+			return 0,fmt.Errorf("invalid %s %q",key,v)
+		}
+		return n,nil
+	default:
+		return 0,fmt.Errorf("invalid %s %v",key,v)
+	}
+}
+
+func intField(record map[string]interface{},key string) (int64,error) {
+	n,err := numberField(record,key)
+	if err != nil {
+		return 0,err
+	}
+	if n != float64(int64(n)) {
+		return 0,fmt.Errorf("invalid %s %v, expected a whole number",key,n)
+	}
+	return int64(n),nil
+}
+
+// listField reads a list, or a comma separated string as CSV cells hold lists.
+func listField(record map[string]interface{},key string) []string {
+	items,ok := record[key].([]interface{})
+	if !ok {
+		return splitList(stringField(record,key))
+	}
+	list := make([]string,0,len(items))
+	for _,item := range items {
+		list = append(list,fmt.Sprint(item))
+	}
+	return list
+}
+
+func splitList(value string) []string {
+	list := []string{}
+	for _,item := range strings.Split(value,",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list,item)
+		}
+	}
+	return list
+}
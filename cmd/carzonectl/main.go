@@ -0,0 +1,188 @@
+// Command carzonectl manages the cars and engines of a CarZone server from the
+// command line. Global flags go before the command:
+//
+//	carzonectl login -u admin
+//	carzonectl -o yaml cars list -brand Toyota
+//	carzonectl engines import engines.csv
+//
+// It talks to the /v1 REST API through the generated client package.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/client"
+)
+
+const defaultServer = "http://localhost:8080"
+
+const usage = `Usage: carzonectl [flags] <command> [args]
+
+Commands:
+  login                      log in and cache the token
+  logout                     forget the cached token
+  cars list|get|create|update|delete|import
+  engines list|get|create|update|delete|import
+
+Run "carzonectl cars" or "carzonectl engines" for their subcommands.
+
+Flags:
+`
+
+// errUsage is returned after a usage message has been printed, it only sets the exit code.
+var errUsage = errors.New("usage")
+
+type app struct {
+	server string
+	output string
+	tenant *client.TenantID
+	apiKey string
+	config *config
+	api *client.ClientWithResponses
+	stdin io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	flags := flag.NewFlagSet("carzonectl",flag.ExitOnError)
+	server := flags.String("server","","CarZone URL, defaults to $CARZONE_SERVER, then the server last logged in to")
+	output := flags.String("o","table","output format: table, json or yaml")
+	tenant := flags.String("tenant","","dealership to act in, only honoured for platform admins")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(),usage)
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if flags.Arg(0) == "help" {
+		flags.SetOutput(os.Stdout)
+		flags.Usage()
+		return
+	}
+
+	a,err := newApp(*server,*output,*tenant)
+	if err == nil {
+		err = a.run(context.Background(),flags.Args())
+	}
+	if errors.Is(err,errUsage) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr,"Error:",err)
+		os.Exit(1)
+	}
+}
+
+func newApp(server,output,tenant string) (*app,error) {
+	switch output {
+	case "table","json","yaml":
+	default:
+		return nil,fmt.Errorf("unknown output format %q",output)
+	}
+	cfg,err := loadConfig()
+	if err != nil {
+		return nil,err
+	}
+	if server == "" {
+		server = os.Getenv("CARZONE_SERVER")
+	}
+	if server == "" {
+		server = cfg.Server
+	}
+	if server == "" {
+		server = defaultServer
+	}
+	a := &app{
+		server: strings.TrimSuffix(server,"/"),
+		output: output,
+		apiKey: os.Getenv("CARZONE_API_KEY"),
+		config: cfg,
+		stdin: os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+	if tenant != "" {
+		id,err := uuid.Parse(tenant)
+		if err != nil {
+			return nil,fmt.Errorf("invalid tenant: %w",err)
+		}
+		a.tenant = &id
+	}
+	a.api,err = client.NewClientWithResponses(a.server+"/v1",client.WithRequestEditorFn(a.authorize))
+	if err != nil {
+		return nil,err
+	}
+	return a,nil
+}
+
+func (a *app)run(ctx context.Context,args []string) error {
+	switch args[0] {
+	case "login":
+		return a.login(ctx,args[1:])
+	case "logout":
+		return a.logout()
+	case "cars":
+		return a.cars(ctx,args[1:])
+	case "engines":
+		return a.engines(ctx,args[1:])
+	default:
+		return fmt.Errorf("unknown command %q, see carzonectl help",args[0])
+	}
+}
+
+// authorize prefers $CARZONE_API_KEY over the cached token, for scripts and CI.
+func (a *app)authorize(ctx context.Context,req *http.Request) error {
+	if a.apiKey != "" {
+		req.Header.Set("X-API-Key",a.apiKey)
+		return nil
+	}
+	token,err := a.config.token(a.server)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization","Bearer "+token)
+	return nil
+}
+
+// apiError turns a response the command didn't expect into an error carrying
+// the server's message.
+func apiError(resp *http.Response,body []byte) error {
+	msg := strings.TrimSpace(string(body))
+	if msg == "" {
+		msg = http.StatusText(resp.StatusCode)
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("%s: %s, run carzonectl login",resp.Status,msg)
+	}
+	return fmt.Errorf("%s: %s",resp.Status,msg)
+}
+
+// subcommand prints usage for a resource command when it is missing its action.
+func subcommand(stderr io.Writer,resource string,args []string) (string,[]string,error) {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" {
+		fmt.Fprintf(stderr,"Usage: carzonectl %s list|get ID|create|update ID|delete ID|import FILE\n",resource)
+		fmt.Fprintf(stderr,"Run carzonectl %s <action> -h for its flags.\n",resource)
+		return "",nil,errUsage
+	}
+	return args[0],args[1:],nil
+}
+
+// idArg parses the single ID argument of get, update and delete.
+func idArg(fs *flag.FlagSet) (uuid.UUID,error) {
+	if fs.NArg() != 1 {
+		return uuid.UUID{},fmt.Errorf("%s takes exactly one ID",fs.Name())
+	}
+	return uuid.Parse(fs.Arg(0))
+}
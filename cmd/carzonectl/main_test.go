@@ -0,0 +1,343 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// stub answers like the /v1 API and keeps the requests it got.
+type stub struct {
+	*httptest.Server
+	requests []*http.Request
+	bodies []string
+	handle func(w http.ResponseWriter,r *http.Request,body string)
+}
+
+func newStub(t *testing.T,handle func(w http.ResponseWriter,r *http.Request,body string)) *stub {
+	t.Helper()
+	s := &stub{handle: handle}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		body,_ := io.ReadAll(r.Body)
+		s.requests = append(s.requests,r)
+		s.bodies = append(s.bodies,string(body))
+		w.Header().Set("Content-Type","application/json")
+		s.handle(w,r,string(body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// testApp points a at server with its output captured, the config lives in a
+// temporary directory so tests never touch the user's login.
+func testApp(t *testing.T,server string,output string,tenant string) (*app,*bytes.Buffer,*bytes.Buffer) {
+	t.Helper()
+	t.Setenv("CARZONE_CONFIG",filepath.Join(t.TempDir(),"config.json"))
+	t.Setenv("CARZONE_SERVER","")
+	t.Setenv("CARZONE_API_KEY","cz_test_key")
+	a,err := newApp(server,output,tenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout,stderr := &bytes.Buffer{},&bytes.Buffer{}
+	a.stdout,a.stderr = stdout,stderr
+	return a,stdout,stderr
+}
+
+var (
+	carID = uuid.MustParse("7d3c2a8e-1f4b-4c6d-9e0a-2b5f8c1d3e4f")
+	engineID = uuid.MustParse("e1a2b3c4-d5e6-4f70-8a9b-0c1d2e3f4a5b")
+)
+
+func carJSON(name string,price float64) string {
+	return fmt.Sprintf(`{"id":%q,"name":%q,"year":"2019","brand":"Toyota","fuelType":"Petrol","engine":{"engine_id":%q},"price":%v,"odometer_km":42000,"status":"available"}`,
+		carID,name,engineID,price)
+}
+
+func TestNewApp(t *testing.T) {
+	t.Setenv("CARZONE_CONFIG",filepath.Join(t.TempDir(),"config.json"))
+	t.Setenv("CARZONE_SERVER","http://carzone.example/")
+	tests := []struct {
+		name string
+		server string
+		output string
+		tenant string
+		wantServer string
+		wantErr bool
+	}{
+		{name: "defaults",output: "table",wantServer: "http://carzone.example"},
+		{name: "server flag",server: "http://localhost:9000/",output: "json",wantServer: "http://localhost:9000"},
+		{name: "tenant",output: "yaml",tenant: uuid.NewString(),wantServer: "http://carzone.example"},
+		{name: "unknown output",output: "xml",wantErr: true},
+		{name: "malformed tenant",output: "table",tenant: "dealership-1",wantErr: true},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			a,err := newApp(tt.server,tt.output,tt.tenant)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("newApp succeeded")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a.server != tt.wantServer {
+				t.Errorf("server = %q, want %q",a.server,tt.wantServer)
+			}
+			if (tt.tenant != "") != (a.tenant != nil) {
+				t.Errorf("tenant = %v, want %q",a.tenant,tt.tenant)
+			}
+		})
+	}
+}
+
+func TestListCars(t *testing.T) {
+	s := newStub(t,func(w http.ResponseWriter,r *http.Request,body string) {
+		fmt.Fprint(w,"["+carJSON("Corolla",1500000)+"]")
+	})
+	tenant := uuid.NewString()
+	a,stdout,_ := testApp(t,s.URL,"table",tenant)
+
+	err := a.run(context.Background(),[]string{"cars","list","-brand","Toyota","-status","available,reserved","-engines",
+		"-transmission","automatic","-max-odometer","50000","-features","sunroof,tow_bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := s.requests[0]
+	if r.Method != http.MethodGet || r.URL.Path != "/v1/cars" {
+		t.Errorf("request = %s %s",r.Method,r.URL.Path)
+	}
+	want := map[string]string{"brand": "Toyota","status": "available,reserved","isEngine": "true","transmission": "automatic",
+		"max_odometer_km": "50000","features": "sunroof,tow_bar"}
+	for key,value := range want {
+		if got := r.URL.Query().Get(key); got != value {
+			t.Errorf("%s = %q, want %q",key,got,value)
+		}
+	}
+	for _,key := range []string{"vin","colour","condition","drivetrain","body_type"} {
+		if r.URL.Query().Has(key) {
+			t.Errorf("%s was sent without its flag",key)
+		}
+	}
+	if r.Header.Get("X-Tenant-ID") != tenant || r.Header.Get("X-API-Key") != "cz_test_key" {
+		t.Errorf("headers = %v",r.Header)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()),"\n")
+	if len(lines) != 2 || strings.Join(strings.Fields(lines[0])," ") != strings.Join(carColumns," ") {
+		t.Fatalf("table = %q",stdout.String())
+	}
+	row := strings.Fields(lines[1])
+	if wantRow := []string{carID.String(),"Corolla","2019","Toyota","Petrol",engineID.String(),"1500000.00","42000","available"}; strings.Join(row," ") != strings.Join(wantRow," ") {
+		t.Errorf("row = %q, want %q",row,wantRow)
+	}
+}
+
+func TestListCarsNeedsBrand(t *testing.T) {
+	s := newStub(t,func(w http.ResponseWriter,r *http.Request,body string) {})
+	a,_,_ := testApp(t,s.URL,"table","")
+	if err := a.run(context.Background(),[]string{"cars","list"}); err == nil || !strings.Contains(err.Error(),"-brand") {
+		t.Errorf("run = %v, want it to ask for -brand",err)
+	}
+	if err := a.run(context.Background(),[]string{"cars","list","-speed","fast"}); !errors.Is(err,errUsage) {
+		t.Errorf("unknown flag: %v, want %v",err,errUsage)
+	}
+	if err := a.run(context.Background(),[]string{"cars"}); !errors.Is(err,errUsage) {
+		t.Errorf("missing action: %v, want %v",err,errUsage)
+	}
+	if len(s.requests) != 0 {
+		t.Errorf("%d requests reached the server",len(s.requests))
+	}
+}
+
+func TestGetCarOutput(t *testing.T) {
+	s := newStub(t,func(w http.ResponseWriter,r *http.Request,body string) {
+		fmt.Fprint(w,carJSON("Corolla",1500000))
+	})
+	tests := []struct {
+		output string
+		check func(t *testing.T,out string)
+	}{
+		{output: "json",check: func(t *testing.T,out string) {
+			var car map[string]interface{}
+			if err := json.Unmarshal([]byte(out),&car); err != nil {
+				t.Fatal(err)
+			}
+			if car["name"] != "Corolla" || car["odometer_km"] != 42000.0 {
+				t.Errorf("car = %v",car)
+			}
+		}},
+		{output: "yaml",check: func(t *testing.T,out string) {
+			// keys in the client's field order, block style and only quoted where YAML needs it
+			if !strings.HasPrefix(out,"brand: Toyota\nengine:\n    engine_id: "+engineID.String()+"\n") || !strings.Contains(out,"\nyear: \"2019\"\n") {
+				t.Errorf("yaml = %s",out)
+			}
+		}},
+	}
+	for _,tt := range tests {
+		t.Run(tt.output,func(t *testing.T) {
+			a,stdout,_ := testApp(t,s.URL,tt.output,"")
+			if err := a.run(context.Background(),[]string{"cars","get",carID.String()}); err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t,stdout.String())
+		})
+	}
+
+	a,_,_ := testApp(t,s.URL,"table","")
+	for _,args := range [][]string{{"cars","get"},{"cars","get","not-an-id"},{"cars","get",carID.String(),carID.String()}} {
+		if err := a.run(context.Background(),args); err == nil {
+			t.Errorf("%q succeeded",args)
+		}
+	}
+}
+
+func TestUpdateCarKeepsUnsetFields(t *testing.T) {
+	s := newStub(t,func(w http.ResponseWriter,r *http.Request,body string) {
+		if r.Method == http.MethodPut {
+			fmt.Fprint(w,carJSON("Corolla",1450000))
+			return
+		}
+		fmt.Fprint(w,carJSON("Corolla",1500000))
+	})
+	a,_,_ := testApp(t,s.URL,"json","")
+	if err := a.run(context.Background(),[]string{"cars","update","-price","1450000","-colour","white",carID.String()}); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.requests) != 2 || s.requests[1].Method != http.MethodPut || s.requests[1].URL.Path != "/v1/cars/"+carID.String() {
+		t.Fatalf("requests = %v",s.requests)
+	}
+	var sent map[string]interface{}
+	if err := json.Unmarshal([]byte(s.bodies[1]),&sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent["price"] != 1450000.0 || sent["colour"] != "white" || sent["name"] != "Corolla" || sent["odometer_km"] != 42000.0 {
+		t.Errorf("sent %v",sent)
+	}
+	if _,ok := sent["status"]; ok {
+		t.Error("status was sent without -status")
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	s := newStub(t,func(w http.ResponseWriter,r *http.Request,body string) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w,"invalid API key")
+	})
+	a,_,_ := testApp(t,s.URL,"table","")
+	err := a.run(context.Background(),[]string{"cars","delete",carID.String()})
+	if err == nil || !strings.Contains(err.Error(),"invalid API key") || !strings.Contains(err.Error(),"carzonectl login") {
+		t.Errorf("run = %v, want the server's message and a hint to log in",err)
+	}
+}
+
+func TestImportCars(t *testing.T) {
+	s := newStub(t,func(w http.ResponseWriter,r *http.Request,body string) {
+		if strings.Contains(body,"Broken") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w,"price must be greater than zero")
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w,carJSON("Corolla",1500000))
+	})
+	path := filepath.Join(t.TempDir(),"cars.csv")
+	csv := "name,year,brand,fuelType,engine_id,price,odometer_km,features\n" +
+		"Corolla,2019,Toyota,Petrol," + engineID.String() + ",1500000,42000,sunroof;tow_bar\n" +
+		"Broken,2019,Toyota,Petrol," + engineID.String() + ",0,,\n"
+	if err := os.WriteFile(path,[]byte(csv),0o600); err != nil {
+		t.Fatal(err)
+	}
+	a,stdout,stderr := testApp(t,s.URL,"table","")
+	err := a.run(context.Background(),[]string{"cars","import",path})
+	if err == nil || !strings.Contains(err.Error(),"1 cars failed") {
+		t.Errorf("run = %v, want one failure",err)
+	}
+	if !strings.Contains(stderr.String(),"record 2: 400 Bad Request: price must be greater than zero") || !strings.Contains(stderr.String(),"Imported 1 of 2 cars") {
+		t.Errorf("stderr = %q",stderr.String())
+	}
+	if !strings.Contains(stdout.String(),"Corolla") {
+		t.Errorf("the imported car wasn't printed: %q",stdout.String())
+	}
+	var first map[string]interface{}
+	if err := json.Unmarshal([]byte(s.bodies[0]),&first); err != nil {
+		t.Fatal(err)
+	}
+	if first["odometer_km"] != 42000.0 || first["engine"].(map[string]interface{})["engine_id"] != engineID.String() {
+		t.Errorf("first car sent as %v",first)
+	}
+
+	// running the file again sends the same keys, a new file different ones
+	keys := []string{s.requests[0].Header.Get("Idempotency-Key"),s.requests[1].Header.Get("Idempotency-Key")}
+	if keys[0] == "" || keys[0] == keys[1] {
+		t.Fatalf("idempotency keys = %q",keys)
+	}
+	a.run(context.Background(),[]string{"cars","import",path})
+	if again := s.requests[2].Header.Get("Idempotency-Key"); again != keys[0] {
+		t.Errorf("second run sent key %q, want %q",again,keys[0])
+	}
+}
+
+func TestLogin(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+	token := "header." + base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`,exp))) + ".signature"
+	s := newStub(t,func(w http.ResponseWriter,r *http.Request,body string) {
+		if r.URL.Path == "/login" {
+			if !strings.Contains(body,`"username":"admin"`) || !strings.Contains(body,`"password":"secret"`) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprintf(w,`{"token":%q}`,token)
+			return
+		}
+		fmt.Fprint(w,"[]")
+	})
+	a,_,stderr := testApp(t,s.URL,"table","")
+	a.apiKey = ""
+	a.stdin = strings.NewReader("secret\n")
+	if err := a.run(context.Background(),[]string{"login","-u","admin"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stderr.String(),"Logged in to "+s.URL+" as admin") {
+		t.Errorf("stderr = %q",stderr.String())
+	}
+	cfg,err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server != s.URL || cfg.Token != token || cfg.ExpiresAt.Unix() != exp {
+		t.Errorf("saved config = %+v",cfg)
+	}
+
+	if err := a.run(context.Background(),[]string{"engines","list"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.requests[1].Header.Get("Authorization"); got != "Bearer "+token {
+		t.Errorf("Authorization = %q",got)
+	}
+
+	if err := a.run(context.Background(),[]string{"logout"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.run(context.Background(),[]string{"engines","list"}); err == nil || !strings.Contains(err.Error(),"not logged in") {
+		t.Errorf("after logout: %v",err)
+	}
+	if len(s.requests) != 2 {
+		t.Errorf("%d requests, the logged out one shouldn't reach the server",len(s.requests))
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/iangechuki/go_carzone/client"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	carColumns = []string{"ID","NAME","YEAR","BRAND","FUEL","ENGINE","PRICE","KM","STATUS"}
+	engineColumns = []string{"ID","FUEL","DISPLACEMENT","CYLINDERS","BATTERY","RANGE","UPDATED"}
+)
+
+// print writes v as JSON or YAML with the API's field names, or rows under
+// columns as a table.
+func (a *app)print(v interface{},columns []string,rows [][]string) error {
+	switch a.output {
+	case "json":
+		data,err := json.MarshalIndent(v,"","  ")
+		if err != nil {
+			return err
+		}
+		_,err = fmt.Fprintln(a.stdout,string(data))
+		return err
+	case "yaml":
+		data,err := toYAML(v)
+		if err != nil {
+			return err
+		}
+		_,err = a.stdout.Write(data)
+		return err
+	}
+	tw := tabwriter.NewWriter(a.stdout,0,0,2,' ',0)
+	fmt.Fprintln(tw,strings.Join(columns,"\t"))
+	for _,row := range rows {
+		fmt.Fprintln(tw,strings.Join(row,"\t"))
+	}
+	return tw.Flush()
+}
+
+// toYAML goes through JSON so the keys match the json tags, decoding into a
+// node rather than a map keeps them in the API's order.
+func toYAML(v interface{}) ([]byte,error) {
+	data,err := json.Marshal(v)
+	if err != nil {
+		return nil,err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data,&node); err != nil {
+		return nil,err
+	}
+	blockStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+// blockStyle undoes the flow style and quoting the nodes keep from the JSON.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _,child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+func carRows(cars ...client.Car) [][]string {
+	rows := make([][]string,len(cars))
+	for i,car := range cars {
+		odometer := ""
+		if car.OdometerKm != nil {
+			odometer = strconv.Itoa(*car.OdometerKm)
+		}
+		rows[i] = []string{
+			car.Id.String(),
+			car.Name,
+			car.Year,
+			car.Brand,
+			string(car.FuelType),
+			car.Engine.EngineId.String(),
+			strconv.FormatFloat(car.Price,'f',2,64),
+			odometer,
+			string(car.Status),
+		}
+	}
+	return rows
+}
+
+func engineRows(engines ...client.Engine) [][]string {
+	rows := make([][]string,len(engines))
+	for i,engine := range engines {
+		updated := ""
+		if engine.UpdatedAt != nil {
+			updated = engine.UpdatedAt.Local().Format("2006-01-02 15:04")
+		}
+		fuelType,battery := "",""
+		if engine.FuelType != nil {
+			fuelType = string(*engine.FuelType)
+		}
+		if engine.BatteryCapacityKwh != nil {
+			battery = strconv.FormatFloat(*engine.BatteryCapacityKwh,'f',-1,64)
+		}
+		rows[i] = []string{
+			engine.EngineId.String(),
+			fuelType,
+			optional(engine.Displacement),
+			optional(engine.NoOfCylinders),
+			battery,
+			optional(engine.CarRange),
+			updated,
+		}
+	}
+	return rows
+}
+
+func optional(n *int64) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.FormatInt(*n,10)
+}
@@ -0,0 +1,35 @@
+// Command mockoidc runs a local OpenID Connect provider for trying the CarZone
+// OIDC login without a corporate identity provider.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/iangechuki/go_carzone/mockoidc"
+)
+
+func main() {
+	addr := flag.String("addr",":9999","listen address")
+	issuer := flag.String("issuer","http://localhost:9999","issuer URL, must be reachable by CarZone")
+	clientID := flag.String("client-id","carzone","OAuth2 client id")
+	clientSecret := flag.String("client-secret","carzone-secret","OAuth2 client secret")
+	username := flag.String("username","jane","preferred_username of the signed in user")
+	groups := flag.String("groups","carzone-admins","comma separated groups of the signed in user")
+	flag.Parse()
+
+	server,err := mockoidc.New(*clientID,*clientSecret,mockoidc.User{
+		Subject: *username,
+		Username: *username,
+		Email: *username + "@example.com",
+		Groups: strings.Split(*groups,","),
+	})
+	if err != nil {
+		log.Fatal("Error creating mock oidc server: ",err)
+	}
+	server.Issuer = *issuer
+	log.Printf("Mock OIDC provider %s listening on %s",*issuer,*addr)
+	log.Fatal(http.ListenAndServe(*addr,server.Handler()))
+}
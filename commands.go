@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/driver"
+	"github.com/iangechuki/go_carzone/models"
+	userService "github.com/iangechuki/go_carzone/service/user"
+	"github.com/iangechuki/go_carzone/store/schema"
+	userStore "github.com/iangechuki/go_carzone/store/user"
+	"golang.org/x/term"
+)
+
+func migrate(args []string){
+	flags := flag.NewFlagSet("migrate",flag.ExitOnError)
+	status := flags.Bool("status",false,"list pending migrations without applying them")
+	flags.Parse(args)
+
+	driver.InitDB()
+	defer driver.CloseDB()
+	db := driver.GetDB()
+	if *status {
+		pending,err := schema.Pending(context.Background(),db)
+		if err != nil {
+			log.Fatal("Error checking migrations: ",err)
+		}
+		if len(pending) == 0 {
+			fmt.Println("Database is up to date")
+		}
+		for _,name := range pending {
+			fmt.Println("pending",name)
+		}
+		return
+	}
+	migrateDB(db)
+}
+
+// migrateDB applies pending migrations, then the row level security
+// policies when DB_ROW_LEVEL_SECURITY=true.
+func migrateDB(db *sql.DB){
+	applied,err := schema.Migrate(context.Background(),db)
+	for _,name := range applied {
+		log.Printf("Applied migration %s",name)
+	}
+	if err != nil {
+		log.Fatal("Error migrating database: ",err)
+	}
+	if len(applied) == 0 {
+		log.Println("Database is up to date")
+	}
+	if os.Getenv("DB_ROW_LEVEL_SECURITY") == "true" {
+		if err := schema.ApplyRowLevelSecurity(context.Background(),db); err != nil {
+			log.Fatal("Error applying row level security: ",err)
+		}
+	}
+}
+
+func seed(args []string){
+	flags := flag.NewFlagSet("seed",flag.ExitOnError)
+	fixture := flags.String("fixture","demo","embedded fixture set to load, one of "+strings.Join(schema.Fixtures(),", "))
+	file := flags.String("file","","SQL file to load instead of an embedded fixture set")
+	flags.Parse(args)
+
+	script,name := "",*file
+	if *file != "" {
+		data,err := os.ReadFile(*file)
+		if err != nil {
+			log.Fatal("Error reading fixture file: ",err)
+		}
+		script = string(data)
+	} else {
+		var err error
+		if script,err = schema.Fixture(*fixture); err != nil {
+			log.Fatal("Error: ",err)
+		}
+		name = *fixture
+	}
+
+	driver.InitDB()
+	defer driver.CloseDB()
+	db := driver.GetDB()
+	// fixtures are written against the latest schema
+	pending,err := schema.Pending(context.Background(),db)
+	if err != nil {
+		log.Fatal("Error checking migrations: ",err)
+	}
+	if len(pending) > 0 {
+		log.Fatalf("Database is missing migrations %s, run main migrate first",strings.Join(pending,", "))
+	}
+	if err := schema.Seed(context.Background(),db,script); err != nil {
+		log.Fatal("Error loading fixtures: ",err)
+	}
+	log.Printf("Loaded fixtures %s",name)
+}
+
+func createUser(args []string){
+	flags := flag.NewFlagSet("create-user",flag.ExitOnError)
+	username := flags.String("username","","username to log in with")
+	password := flags.String("password","","password, read from stdin when left out so it stays out of shell history")
+	roles := flags.String("roles",models.RoleStaff,"comma separated roles: admin, staff or viewer")
+	tenant := flags.String("tenant","","dealership id, only admins may be left platform-wide")
+	flags.Parse(args)
+
+	userReq := &models.UserRequest{
+		Username: *username,
+		Password: *password,
+		Roles: strings.Split(*roles,","),
+	}
+	if *tenant != "" {
+		tenantID,err := uuid.Parse(*tenant)
+		if err != nil {
+			log.Fatal("Error parsing tenant: ",err)
+		}
+		userReq.TenantID = uuid.NullUUID{UUID: tenantID,Valid: true}
+	}
+	// a user without a dealership sees none unless they are a platform admin
+	if !userReq.TenantID.Valid && !hasRole(userReq.Roles,models.RoleAdmin) {
+		log.Fatal("Error: create-user needs -tenant unless the roles include admin")
+	}
+	if userReq.Password == "" {
+		userReq.Password = readPassword()
+	}
+	// fail on a bad request before waiting on the database
+	if err := models.ValidateUserRequest(userReq); err != nil {
+		log.Fatal("Error: ",err)
+	}
+
+	driver.InitDB()
+	defer driver.CloseDB()
+	users := userService.NewUserService(userStore.New(driver.GetDB()))
+	user,err := users.CreateUser(context.Background(),userReq)
+	if err != nil {
+		log.Fatal("Error creating user: ",err)
+	}
+	log.Printf("audit: created user %s (%s) with roles %s",user.Username,user.ID,strings.Join(user.Roles,","))
+}
+
+func hasRole(roles []string,role string) bool {
+	for _,r := range roles {
+		if strings.TrimSpace(r) == role {
+			return true
+		}
+	}
+	return false
+}
+
+// readPassword prompts without echo on a terminal, piped input is read as a line.
+func readPassword() string {
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprint(os.Stderr,"Password: ")
+		password,err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			log.Fatal("Error reading password: ",err)
+		}
+		return string(password)
+	}
+	line,err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		log.Fatal("Error reading password: ",err)
+	}
+	return strings.TrimRight(line,"\r\n")
+}
+
+// healthcheck is for container probes in images without curl or wget, it
+// doesn't touch the database.
+func healthcheck(args []string){
+	flags := flag.NewFlagSet("healthcheck",flag.ExitOnError)
+	url := flags.String("url","http://localhost:"+getEnv("PORT","8080")+"/health","health endpoint to probe")
+	timeout := flags.Duration("timeout",3*time.Second,"give up after")
+	flags.Parse(args)
+
+	client := &http.Client{Timeout: *timeout}
+	resp,err := client.Get(*url)
+	if err != nil {
+		log.Fatal("Error: ",err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Fatalf("Error: %s answered %s",*url,resp.Status)
+	}
+}
@@ -10,14 +10,18 @@ import (
 	_ "github.com/lib/pq"
 )
 var db *sql.DB
-func InitDB(){
-	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+// ConnString is the lib/pq connection string built from the DB_* variables.
+func ConnString() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		os.Getenv("DB_HOST"),
 		os.Getenv("DB_PORT"),
 		os.Getenv("DB_USER"),
 		os.Getenv("DB_PASSWORD"),
 		os.Getenv("DB_NAME"),
 	)
+}
+func InitDB(){
+	connStr := ConnString()
 	fmt.Println(connStr)
 	fmt.Println("Trying to connect to db")
 	var err error
@@ -0,0 +1,122 @@
+package apikey
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/iangechuki/go_carzone/middleware"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/otel"
+)
+
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyServiceInterface
+}
+
+func NewAPIKeyHandler(apiKeyService service.APIKeyServiceInterface) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (h *APIKeyHandler)CreateAPIKey(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("APIKeyHandler")
+	ctx,span := tracer.Start(r.Context(), "CreateAPIKey-Handler")
+	defer span.End()
+
+	var keyReq models.APIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&keyReq); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	// a key can never be granted more than the credentials that created it
+	for _,scope := range keyReq.Scopes {
+		if !middleware.HasScope(r,scope) {
+			http.Error(w,"Cannot grant scope "+scope,http.StatusForbidden)
+			return
+		}
+	}
+	issuedKey,err := h.apiKeyService.CreateAPIKey(ctx,&keyReq)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error creating api key: ",err)
+		return
+	}
+	responseBody,err := json.Marshal(issuedKey)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error while marshallin",err)
+		return
+	}
+	w.Header().Set("Content-Type","application/json")
+	w.WriteHeader(http.StatusCreated)
+	_,err = w.Write(responseBody)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error writing messages ",err)
+		return
+	}
+}
+
+func (h *APIKeyHandler)ListAPIKeys(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("APIKeyHandler")
+	ctx,span := tracer.Start(r.Context(), "ListAPIKeys-Handler")
+	defer span.End()
+
+	keys,err := h.apiKeyService.ListAPIKeys(ctx)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	body,err := json.Marshal(keys)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	w.Header().Set("Content-Type","application/json")
+	w.WriteHeader(http.StatusOK)
+	_,err = w.Write(body)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error writing messages ",err)
+		return
+	}
+}
+
+func (h *APIKeyHandler)RevokeAPIKey(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("APIKeyHandler")
+	ctx,span := tracer.Start(r.Context(), "RevokeAPIKey-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	revokedKey,err := h.apiKeyService.RevokeAPIKey(ctx,id)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error revoking api key: ",err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(revokedKey); err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err,models.ErrInvalidAPIKeyRequest),errors.Is(err,models.ErrTenantRequired):
+		return http.StatusBadRequest
+	case errors.Is(err,models.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
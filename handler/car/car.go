@@ -2,10 +2,14 @@ package car
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/iangechuki/go_carzone/handler/dto"
 	"github.com/iangechuki/go_carzone/models"
 	"github.com/iangechuki/go_carzone/service"
 	"go.opentelemetry.io/otel"
@@ -15,11 +19,14 @@ import (
 
 type CarHandler struct {
 	carService service.CarServiceInterface
+	version dto.Version
 }
 
-func NewCarHandler(service service.CarServiceInterface) *CarHandler {
+// NewCarHandler serves cars in the JSON of the given API version.
+func NewCarHandler(service service.CarServiceInterface,version dto.Version) *CarHandler {
 	return &CarHandler{
 		carService: service,
+		version: version,
 	}
 }
 
@@ -33,17 +40,18 @@ func (h *CarHandler)GetCarByID(w http.ResponseWriter,r *http.Request){
 
 	car,err := h.carService.GetCarByID(ctx,id)
 	if err != nil {
-		http.Error(w,err.Error(),http.StatusInternalServerError)
+		http.Error(w,err.Error(),statusFor(err))
 		log.Println("Error: ",err)
 		return
 	}
-	body,err := json.Marshal(car)
+	body,err := json.Marshal(h.version.Car(car))
 	if err != nil {
 		http.Error(w,err.Error(),http.StatusInternalServerError)
 		log.Println("Error: ",err)
 		return
 	}
 	w.Header().Set("Content-Type","application/json")
+	w.Header().Set("Last-Modified",car.LastModified().UTC().Format(http.TimeFormat))
 	w.WriteHeader(http.StatusOK)
 	_,err = w.Write(body)
 	if err != nil {
@@ -59,14 +67,67 @@ func (h *CarHandler)GetCarByBrand(w http.ResponseWriter,r *http.Request){
 
 	brand := r.URL.Query().Get("brand")
 	isEngine := r.URL.Query().Get("isEngine") == "true"
+	var filter models.CarFilter
+	if status := r.URL.Query().Get("status"); status != "" {
+		filter.Statuses = strings.Split(status,",")
+	}
+	filter.VIN = r.URL.Query().Get("vin")
+	filter.Transmission = r.URL.Query().Get("transmission")
+	filter.Drivetrain = r.URL.Query().Get("drivetrain")
+	filter.BodyType = r.URL.Query().Get("body_type")
+	filter.Condition = r.URL.Query().Get("condition")
+	filter.Colour = r.URL.Query().Get("colour")
+	if features := r.URL.Query().Get("features"); features != "" {
+		filter.Features = strings.Split(features,",")
+	}
+	if value := r.URL.Query().Get("max_odometer_km"); value != "" {
+		n,err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w,"invalid max_odometer_km",http.StatusBadRequest)
+			return
+		}
+		filter.MaxOdometerKm = n
+	}
 
-	cars,err := h.carService.GetCarsByBrand(ctx,brand,isEngine)
+	// REST lists one brand's cars, unpaged, and an empty brand matches no
+	// car. Paging through every brand is left to GraphQL.
+	cars := []models.Car{}
+	if brand != "" {
+		var err error
+		cars,err = h.carService.GetCarsByBrand(ctx,brand,isEngine,filter)
+		if err != nil {
+			http.Error(w,err.Error(),statusFor(err))
+			log.Println("Error: ",err)
+			return
+		}
+	}
+	body,err := json.Marshal(h.version.Cars(cars))
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	w.Header().Set("Content-Type","application/json")
+	w.WriteHeader(http.StatusOK)
+	_,err = w.Write(body)
 	if err != nil {
 		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error writing messages ",err)
+		return
+	}
+}
+func (h *CarHandler)GetMyCars(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CarHandler")
+	ctx,span := tracer.Start(r.Context(), "GetMyCars-Handler")
+	defer span.End()
+
+	cars,err := h.carService.GetMyCars(ctx)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
 		log.Println("Error: ",err)
 		return
 	}
-	body,err := json.Marshal(cars)
+	body,err := json.Marshal(h.version.Cars(cars))
 	if err != nil {
 		http.Error(w,err.Error(),http.StatusInternalServerError)
 		log.Println("Error: ",err)
@@ -92,21 +153,20 @@ func (h *CarHandler)CreateCar(w http.ResponseWriter,r *http.Request){
 		log.Println("Error: ",err)
 		return
 	}
-	var carReq models.CarRequest
-	err = json.Unmarshal(body,&carReq)
+	carReq,err := h.version.CarRequest(body)
 	if err != nil {
 		http.Error(w,err.Error(),http.StatusInternalServerError)
 		log.Println("Error: ",err)
 		return
 	}
-	createdCar,err := h.carService.CreateCar(ctx,&carReq)
+	createdCar,err := h.carService.CreateCar(ctx,carReq)
 	if err != nil {
-		http.Error(w,err.Error(),http.StatusInternalServerError)
+		http.Error(w,err.Error(),statusFor(err))
 		log.Println("Error: ",err)
 		return
 	}
 	
-	responseBody ,err := json.Marshal(createdCar)
+	responseBody ,err := json.Marshal(h.version.Car(createdCar))
 	if err != nil {
 		http.Error(w,err.Error(),http.StatusInternalServerError)
 		log.Println("Error while marshallin",err)
@@ -129,19 +189,25 @@ func (h *CarHandler)UpdateCar(w http.ResponseWriter,r *http.Request){
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	var carReq models.CarRequest
-	if err:= json.NewDecoder(r.Body).Decode(&carReq); err != nil {
+	body,err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	carReq,err := h.version.CarRequest(body)
+	if err != nil {
 		http.Error(w,err.Error(),http.StatusBadRequest)
 		log.Println("Error decoding req: ",err)
 		return
 	}
-	updatedCar,err := h.carService.UpdateCar(ctx,id,&carReq)
+	updatedCar,err := h.carService.UpdateCar(ctx,id,carReq)
 	if err != nil {
-		http.Error(w,err.Error(),http.StatusInternalServerError)
+		http.Error(w,err.Error(),statusFor(err))
 		log.Println("Error updating car: ",err)
 		return
 	}
-	if err := json.NewEncoder(w).Encode(updatedCar); err != nil {
+	if err := json.NewEncoder(w).Encode(h.version.Car(updatedCar)); err != nil {
 		http.Error(w,err.Error(),http.StatusInternalServerError)
 		log.Println("Error: ",err)
 		return
@@ -157,13 +223,119 @@ func (h *CarHandler)DeleteCar(w http.ResponseWriter,r *http.Request){
 	id := vars["id"]
 	deletedCar,err := h.carService.DeleteCar(ctx,id)
 	if err != nil {
-		http.Error(w,err.Error(),http.StatusInternalServerError)
+		http.Error(w,err.Error(),statusFor(err))
 		log.Println("Error deleting car: ",err)
 		return
 	}
-	if err := json.NewEncoder(w).Encode(deletedCar); err != nil {
+	if err := json.NewEncoder(w).Encode(h.version.Car(deletedCar)); err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+}
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err,models.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err,models.ErrInvalidTransition),errors.Is(err,models.ErrStatusConflict),errors.Is(err,models.ErrVINTaken):
+		return http.StatusConflict
+	case errors.Is(err,models.ErrInvalidReservationExpiry),errors.Is(err,models.ErrInvalidCarFilter),
+		errors.Is(err,models.ErrInvalidVIN),errors.Is(err,models.ErrInvalidCarDetails),errors.Is(err,models.ErrEngineNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err,models.ErrUnknownBrand),errors.Is(err,models.ErrUnknownModel),errors.Is(err,models.ErrEngineFuelMismatch):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err,models.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *CarHandler)ReserveCar(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CarHandler")
+	ctx,span := tracer.Start(r.Context(), "ReserveCar-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	var reservationReq models.ReservationRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&reservationReq); err != nil {
+			http.Error(w,err.Error(),http.StatusBadRequest)
+			log.Println("Error decoding req: ",err)
+			return
+		}
+	}
+	car,err := h.carService.ReserveCar(ctx,id,&reservationReq)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error reserving car: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,h.version.Car(car))
+}
+func (h *CarHandler)CancelReservation(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CarHandler")
+	ctx,span := tracer.Start(r.Context(), "CancelReservation-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	car,err := h.carService.CancelReservation(ctx,id)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error cancelling reservation: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,h.version.Car(car))
+}
+func (h *CarHandler)MarkCarSold(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CarHandler")
+	ctx,span := tracer.Start(r.Context(), "MarkCarSold-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	car,err := h.carService.MarkCarSold(ctx,id)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error marking car sold: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,h.version.Car(car))
+}
+func (h *CarHandler)ChangeCarStatus(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CarHandler")
+	ctx,span := tracer.Start(r.Context(), "ChangeCarStatus-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	var statusReq models.StatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&statusReq); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	car,err := h.carService.ChangeCarStatus(ctx,id,statusReq.Status)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error changing car status: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,h.version.Car(car))
+}
+
+func writeJSON(w http.ResponseWriter,status int,v interface{}) {
+	body,err := json.Marshal(v)
+	if err != nil {
 		http.Error(w,err.Error(),http.StatusInternalServerError)
 		log.Println("Error: ",err)
 		return
 	}
-}
\ No newline at end of file
+	w.Header().Set("Content-Type","application/json")
+	w.WriteHeader(status)
+	if _,err := w.Write(body); err != nil {
+		log.Println("Error writing messages ",err)
+	}
+}
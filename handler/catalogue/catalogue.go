@@ -0,0 +1,194 @@
+package catalogue
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/otel"
+)
+
+type CatalogueHandler struct {
+	catalogueService service.CatalogueServiceInterface
+}
+
+func NewCatalogueHandler(catalogueService service.CatalogueServiceInterface) *CatalogueHandler {
+	return &CatalogueHandler{
+		catalogueService: catalogueService,
+	}
+}
+
+func (h *CatalogueHandler)ListBrands(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CatalogueHandler")
+	ctx,span := tracer.Start(r.Context(), "ListBrands-Handler")
+	defer span.End()
+
+	brands,err := h.catalogueService.ListBrands(ctx)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,brands)
+}
+
+func (h *CatalogueHandler)GetBrandByID(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CatalogueHandler")
+	ctx,span := tracer.Start(r.Context(), "GetBrandByID-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	brand,err := h.catalogueService.GetBrandByID(ctx,vars["id"])
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,brand)
+}
+
+func (h *CatalogueHandler)CreateBrand(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CatalogueHandler")
+	ctx,span := tracer.Start(r.Context(), "CreateBrand-Handler")
+	defer span.End()
+
+	var brandReq models.BrandRequest
+	if err := json.NewDecoder(r.Body).Decode(&brandReq); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	brand,err := h.catalogueService.CreateBrand(ctx,&brandReq)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error creating brand: ",err)
+		return
+	}
+	writeJSON(w,http.StatusCreated,brand)
+}
+
+func (h *CatalogueHandler)UpdateBrand(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CatalogueHandler")
+	ctx,span := tracer.Start(r.Context(), "UpdateBrand-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	var brandReq models.BrandRequest
+	if err := json.NewDecoder(r.Body).Decode(&brandReq); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	brand,err := h.catalogueService.UpdateBrand(ctx,vars["id"],&brandReq)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error updating brand: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,brand)
+}
+
+func (h *CatalogueHandler)DeleteBrand(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CatalogueHandler")
+	ctx,span := tracer.Start(r.Context(), "DeleteBrand-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	brand,err := h.catalogueService.DeleteBrand(ctx,vars["id"])
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error deleting brand: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,brand)
+}
+
+func (h *CatalogueHandler)CreateModel(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CatalogueHandler")
+	ctx,span := tracer.Start(r.Context(), "CreateModel-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	var modelReq models.CarModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&modelReq); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	model,err := h.catalogueService.CreateModel(ctx,vars["id"],&modelReq)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error creating model: ",err)
+		return
+	}
+	writeJSON(w,http.StatusCreated,model)
+}
+
+func (h *CatalogueHandler)UpdateModel(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CatalogueHandler")
+	ctx,span := tracer.Start(r.Context(), "UpdateModel-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	var modelReq models.CarModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&modelReq); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	model,err := h.catalogueService.UpdateModel(ctx,vars["id"],vars["modelID"],&modelReq)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error updating model: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,model)
+}
+
+func (h *CatalogueHandler)DeleteModel(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("CatalogueHandler")
+	ctx,span := tracer.Start(r.Context(), "DeleteModel-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	model,err := h.catalogueService.DeleteModel(ctx,vars["id"],vars["modelID"])
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error deleting model: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,model)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err,models.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err,models.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err,models.ErrInvalidCatalogueEntry):
+		return http.StatusBadRequest
+	case errors.Is(err,models.ErrCatalogueConflict),errors.Is(err,models.ErrCatalogueEntryInUse):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter,status int,v interface{}) {
+	body,err := json.Marshal(v)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	w.Header().Set("Content-Type","application/json")
+	w.WriteHeader(status)
+	if _,err := w.Write(body); err != nil {
+		log.Println("Error writing messages ",err)
+	}
+}
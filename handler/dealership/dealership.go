@@ -0,0 +1,136 @@
+package dealership
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/otel"
+)
+
+type DealershipHandler struct {
+	dealershipService service.DealershipServiceInterface
+}
+
+func NewDealershipHandler(dealershipService service.DealershipServiceInterface) *DealershipHandler {
+	return &DealershipHandler{
+		dealershipService: dealershipService,
+	}
+}
+
+func (h *DealershipHandler)GetDealershipByID(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("DealershipHandler")
+	ctx,span := tracer.Start(r.Context(), "GetDealershipByID-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	dealership,err := h.dealershipService.GetDealershipByID(ctx,id)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,dealership)
+}
+
+func (h *DealershipHandler)ListDealerships(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("DealershipHandler")
+	ctx,span := tracer.Start(r.Context(), "ListDealerships-Handler")
+	defer span.End()
+
+	dealerships,err := h.dealershipService.ListDealerships(ctx)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,dealerships)
+}
+
+func (h *DealershipHandler)CreateDealership(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("DealershipHandler")
+	ctx,span := tracer.Start(r.Context(), "CreateDealership-Handler")
+	defer span.End()
+
+	var dealershipReq models.DealershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&dealershipReq); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	dealership,err := h.dealershipService.CreateDealership(ctx,&dealershipReq)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error creating dealership: ",err)
+		return
+	}
+	writeJSON(w,http.StatusCreated,dealership)
+}
+
+func (h *DealershipHandler)UpdateDealership(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("DealershipHandler")
+	ctx,span := tracer.Start(r.Context(), "UpdateDealership-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	var dealershipReq models.DealershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&dealershipReq); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	dealership,err := h.dealershipService.UpdateDealership(ctx,id,&dealershipReq)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error updating dealership: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,dealership)
+}
+
+func (h *DealershipHandler)DeleteDealership(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("DealershipHandler")
+	ctx,span := tracer.Start(r.Context(), "DeleteDealership-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	dealership,err := h.dealershipService.DeleteDealership(ctx,id)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error deleting dealership: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,dealership)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err,models.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err,models.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter,status int,v interface{}) {
+	body,err := json.Marshal(v)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	w.Header().Set("Content-Type","application/json")
+	w.WriteHeader(status)
+	if _,err := w.Write(body); err != nil {
+		log.Println("Error writing messages ",err)
+	}
+}
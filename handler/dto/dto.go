@@ -0,0 +1,15 @@
+// Package dto decouples the JSON of each REST API version from the internal
+// models, so models can change without breaking clients of an older version.
+package dto
+
+import "github.com/iangechuki/go_carzone/models"
+
+// Version converts between the internal models and one API version's JSON.
+type Version interface {
+	Car(car *models.Car) interface{}
+	Cars(cars []models.Car) interface{}
+	Engine(engine *models.Engine) interface{}
+	Engines(engines []models.Engine) interface{}
+	CarRequest(body []byte) (*models.CarRequest,error)
+	EngineRequest(body []byte) (*models.EngineRequest,error)
+}
@@ -0,0 +1,228 @@
+// Package v1 is the JSON served under /v1 and on the unversioned routes,
+// frozen at the shape models.Car had when the API was versioned. Fields are
+// only ever added, and only optional ones.
+package v1
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/models"
+)
+
+type Engine struct {
+	EngineID uuid.UUID `json:"engine_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	Displacement int64 `json:"displacement"`
+	NoOfCylinders int64 `json:"no_of_cylinders"`
+	CarRange int64 `json:"car_range"`
+	FuelType string `json:"fuel_type,omitempty"`
+	BatteryCapacityKWh float64 `json:"battery_capacity_kwh,omitempty"`
+	MotorPowerKW int64 `json:"motor_power_kw,omitempty"`
+	ChargingStandard string `json:"charging_standard,omitempty"`
+	Horsepower int64 `json:"horsepower,omitempty"`
+	TorqueNm int64 `json:"torque_nm,omitempty"`
+	CO2GPerKm int64 `json:"co2_g_per_km,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+}
+
+type Car struct {
+	ID uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	CreatedBy string `json:"created_by"`
+	Name string `json:"name"`
+	Year string `json:"year"`
+	Brand string `json:"brand"`
+	Model string `json:"model,omitempty"`
+	FuelType string `json:"fuelType"`
+	Engine Engine `json:"engine"`
+	Price float64 `json:"price"`
+	VIN string `json:"vin,omitempty"`
+	OdometerKm int `json:"odometer_km"`
+	Transmission string `json:"transmission,omitempty"`
+	Drivetrain string `json:"drivetrain,omitempty"`
+	Colour string `json:"colour,omitempty"`
+	BodyType string `json:"body_type,omitempty"`
+	Condition string `json:"condition,omitempty"`
+	Features []string `json:"features"`
+	Status string `json:"status"`
+	ReservedBy string `json:"reserved_by,omitempty"`
+	ReservedUntil *time.Time `json:"reserved_until,omitempty"`
+	SoldAt *time.Time `json:"sold_at,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CarRequest struct {
+	Name string `json:"name"`
+	Year string `json:"year"`
+	Brand string `json:"brand"`
+	Model string `json:"model,omitempty"`
+	FuelType string `json:"fuelType"`
+	Engine Engine `json:"engine"`
+	Price float64 `json:"price"`
+	VIN string `json:"vin,omitempty"`
+	OdometerKm int `json:"odometer_km"`
+	Transmission string `json:"transmission,omitempty"`
+	Drivetrain string `json:"drivetrain,omitempty"`
+	Colour string `json:"colour,omitempty"`
+	BodyType string `json:"body_type,omitempty"`
+	Condition string `json:"condition,omitempty"`
+	Features []string `json:"features,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+type EngineRequest struct {
+	Displacement int64 `json:"displacement"`
+	NoOfCylinders int64 `json:"no_of_cylinders"`
+	CarRange int64 `json:"car_range"`
+	FuelType string `json:"fuel_type,omitempty"`
+	BatteryCapacityKWh float64 `json:"battery_capacity_kwh,omitempty"`
+	MotorPowerKW int64 `json:"motor_power_kw,omitempty"`
+	ChargingStandard string `json:"charging_standard,omitempty"`
+	Horsepower int64 `json:"horsepower,omitempty"`
+	TorqueNm int64 `json:"torque_nm,omitempty"`
+	CO2GPerKm int64 `json:"co2_g_per_km,omitempty"`
+}
+
+// Version implements dto.Version.
+type Version struct{}
+
+// fuelTypePersol is how v1 has always spelled Petrol. It is still what v1
+// answers with, and requests may use either spelling.
+const fuelTypePersol = "Persol"
+
+func fromFuelType(fuelType string) string {
+	if fuelType == models.FuelTypePetrol {
+		return fuelTypePersol
+	}
+	return fuelType
+}
+
+func toFuelType(fuelType string) string {
+	if fuelType == fuelTypePersol {
+		return models.FuelTypePetrol
+	}
+	return fuelType
+}
+
+func toEngine(engine *models.Engine) Engine {
+	return Engine{
+		EngineID: engine.EngineID,
+		TenantID: engine.TenantID,
+		Displacement: engine.Displacement,
+		NoOfCylinders: engine.NoOfCylinders,
+		CarRange: engine.CarRange,
+		FuelType: fromFuelType(engine.FuelType),
+		BatteryCapacityKWh: engine.BatteryCapacityKWh,
+		MotorPowerKW: engine.MotorPowerKW,
+		ChargingStandard: engine.ChargingStandard,
+		Horsepower: engine.Horsepower,
+		TorqueNm: engine.TorqueNm,
+		CO2GPerKm: engine.CO2GPerKm,
+		UpdatedAt: engine.UpdatedAt,
+	}
+}
+
+func toCar(car *models.Car) Car {
+	return Car{
+		ID: car.ID,
+		TenantID: car.TenantID,
+		CreatedBy: car.CreatedBy,
+		Name: car.Name,
+		Year: car.Year,
+		Brand: car.Brand,
+		Model: car.Model,
+		FuelType: fromFuelType(car.FuelType),
+		Engine: toEngine(&car.Engine),
+		Price: car.Price,
+		VIN: car.VIN,
+		OdometerKm: car.OdometerKm,
+		Transmission: car.Transmission,
+		Drivetrain: car.Drivetrain,
+		Colour: car.Colour,
+		BodyType: car.BodyType,
+		Condition: car.Condition,
+		Features: car.Features,
+		Status: car.Status,
+		ReservedBy: car.ReservedBy,
+		ReservedUntil: car.ReservedUntil,
+		SoldAt: car.SoldAt,
+		CreatedAt: car.CreatedAt,
+		UpdatedAt: car.UpdatedAt,
+	}
+}
+
+func (Version)Car(car *models.Car) interface{} {
+	return toCar(car)
+}
+
+func (Version)Cars(cars []models.Car) interface{} {
+	out := make([]Car,len(cars))
+	for i := range cars {
+		out[i] = toCar(&cars[i])
+	}
+	return out
+}
+
+func (Version)Engine(engine *models.Engine) interface{} {
+	return toEngine(engine)
+}
+
+func (Version)Engines(engines []models.Engine) interface{} {
+	out := make([]Engine,len(engines))
+	for i := range engines {
+		out[i] = toEngine(&engines[i])
+	}
+	return out
+}
+
+func (Version)CarRequest(body []byte) (*models.CarRequest,error) {
+	var req CarRequest
+	if err := json.Unmarshal(body,&req); err != nil {
+		return nil,err
+	}
+	return &models.CarRequest{
+		Name: req.Name,
+		Year: req.Year,
+		Brand: req.Brand,
+		Model: req.Model,
+		FuelType: toFuelType(req.FuelType),
+		Engine: models.Engine{
+			EngineID: req.Engine.EngineID,
+			Displacement: req.Engine.Displacement,
+			NoOfCylinders: req.Engine.NoOfCylinders,
+			CarRange: req.Engine.CarRange,
+		},
+		Price: req.Price,
+		VIN: req.VIN,
+		OdometerKm: req.OdometerKm,
+		Transmission: req.Transmission,
+		Drivetrain: req.Drivetrain,
+		Colour: req.Colour,
+		BodyType: req.BodyType,
+		Condition: req.Condition,
+		Features: req.Features,
+		Status: req.Status,
+	},nil
+}
+
+func (Version)EngineRequest(body []byte) (*models.EngineRequest,error) {
+	var req EngineRequest
+	if err := json.Unmarshal(body,&req); err != nil {
+		return nil,err
+	}
+	return &models.EngineRequest{
+		Displacement: req.Displacement,
+		NoOfCylinders: req.NoOfCylinders,
+		CarRange: req.CarRange,
+		FuelType: toFuelType(req.FuelType),
+		BatteryCapacityKWh: req.BatteryCapacityKWh,
+		MotorPowerKW: req.MotorPowerKW,
+		ChargingStandard: req.ChargingStandard,
+		Horsepower: req.Horsepower,
+		TorqueNm: req.TorqueNm,
+		CO2GPerKm: req.CO2GPerKm,
+	},nil
+}
@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/iangechuki/go_carzone/models"
+)
+
+func TestFuelTypeSpelling(t *testing.T) {
+	v := Version{}
+	car := v.Car(&models.Car{FuelType: models.FuelTypePetrol,Engine: models.Engine{FuelType: models.FuelTypePetrol}}).(Car)
+	if car.FuelType != "Persol" || car.Engine.FuelType != "Persol" {
+		t.Errorf("answered %q and %q, want Persol",car.FuelType,car.Engine.FuelType)
+	}
+	if car := v.Car(&models.Car{FuelType: models.FuelTypeDiesel}).(Car); car.FuelType != models.FuelTypeDiesel {
+		t.Errorf("answered %q, want %q",car.FuelType,models.FuelTypeDiesel)
+	}
+
+	for _,fuelType := range []string{"Persol","Petrol"} {
+		carReq,err := v.CarRequest([]byte(`{"fuelType":"` + fuelType + `"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		engineReq,err := v.EngineRequest([]byte(`{"fuel_type":"` + fuelType + `"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if carReq.FuelType != models.FuelTypePetrol || engineReq.FuelType != models.FuelTypePetrol {
+			t.Errorf("%s read as %q and %q, want %q",fuelType,carReq.FuelType,engineReq.FuelType,models.FuelTypePetrol)
+		}
+	}
+}
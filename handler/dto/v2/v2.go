@@ -0,0 +1,229 @@
+// Package v2 is the JSON served under /v2. Fields are snake_case throughout,
+// the year is a number, a car names its engine by id and only embeds the
+// engine's specs when they were loaded, and a reservation is its own object.
+package v2
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/models"
+)
+
+type Engine struct {
+	ID uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	DisplacementCC int64 `json:"displacement_cc"`
+	Cylinders int64 `json:"cylinders"`
+	RangeKM int64 `json:"range_km"`
+	FuelType string `json:"fuel_type,omitempty"`
+	BatteryCapacityKWh float64 `json:"battery_capacity_kwh,omitempty"`
+	MotorPowerKW int64 `json:"motor_power_kw,omitempty"`
+	ChargingStandard string `json:"charging_standard,omitempty"`
+	Horsepower int64 `json:"horsepower,omitempty"`
+	TorqueNm int64 `json:"torque_nm,omitempty"`
+	CO2GPerKm int64 `json:"co2_g_per_km,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type Reservation struct {
+	ReservedBy string `json:"reserved_by"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type Car struct {
+	ID uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	CreatedBy string `json:"created_by"`
+	Name string `json:"name"`
+	Year int `json:"year"`
+	Brand string `json:"brand"`
+	Model string `json:"model,omitempty"`
+	FuelType string `json:"fuel_type"`
+	EngineID uuid.UUID `json:"engine_id"`
+	Engine *Engine `json:"engine,omitempty"`
+	Price float64 `json:"price"`
+	VIN string `json:"vin,omitempty"`
+	OdometerKm int `json:"odometer_km"`
+	Transmission string `json:"transmission,omitempty"`
+	Drivetrain string `json:"drivetrain,omitempty"`
+	Colour string `json:"colour,omitempty"`
+	BodyType string `json:"body_type,omitempty"`
+	Condition string `json:"condition,omitempty"`
+	Features []string `json:"features"`
+	Status string `json:"status"`
+	Reservation *Reservation `json:"reservation,omitempty"`
+	SoldAt *time.Time `json:"sold_at,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CarRequest struct {
+	Name string `json:"name"`
+	Year int `json:"year"`
+	Brand string `json:"brand"`
+	Model string `json:"model,omitempty"`
+	FuelType string `json:"fuel_type"`
+	EngineID uuid.UUID `json:"engine_id"`
+	Price float64 `json:"price"`
+	VIN string `json:"vin,omitempty"`
+	OdometerKm int `json:"odometer_km"`
+	Transmission string `json:"transmission,omitempty"`
+	Drivetrain string `json:"drivetrain,omitempty"`
+	Colour string `json:"colour,omitempty"`
+	BodyType string `json:"body_type,omitempty"`
+	Condition string `json:"condition,omitempty"`
+	Features []string `json:"features,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+type EngineRequest struct {
+	DisplacementCC int64 `json:"displacement_cc"`
+	Cylinders int64 `json:"cylinders"`
+	RangeKM int64 `json:"range_km"`
+	FuelType string `json:"fuel_type,omitempty"`
+	BatteryCapacityKWh float64 `json:"battery_capacity_kwh,omitempty"`
+	MotorPowerKW int64 `json:"motor_power_kw,omitempty"`
+	ChargingStandard string `json:"charging_standard,omitempty"`
+	Horsepower int64 `json:"horsepower,omitempty"`
+	TorqueNm int64 `json:"torque_nm,omitempty"`
+	CO2GPerKm int64 `json:"co2_g_per_km,omitempty"`
+}
+
+// Version implements dto.Version.
+type Version struct{}
+
+func toEngine(engine *models.Engine) *Engine {
+	out := &Engine{
+		ID: engine.EngineID,
+		TenantID: engine.TenantID,
+		DisplacementCC: engine.Displacement,
+		Cylinders: engine.NoOfCylinders,
+		RangeKM: engine.CarRange,
+		FuelType: engine.FuelType,
+		BatteryCapacityKWh: engine.BatteryCapacityKWh,
+		MotorPowerKW: engine.MotorPowerKW,
+		ChargingStandard: engine.ChargingStandard,
+		Horsepower: engine.Horsepower,
+		TorqueNm: engine.TorqueNm,
+		CO2GPerKm: engine.CO2GPerKm,
+	}
+	if !engine.UpdatedAt.IsZero() {
+		out.UpdatedAt = &engine.UpdatedAt
+	}
+	return out
+}
+
+// hasSpecs is false for cars listed without their engine, which only carry its id.
+func hasSpecs(engine *models.Engine) bool {
+	return engine.Displacement != 0 || engine.NoOfCylinders != 0 || engine.CarRange != 0 || engine.FuelType != ""
+}
+
+func toCar(car *models.Car) Car {
+	year,_ := strconv.Atoi(car.Year)
+	out := Car{
+		ID: car.ID,
+		TenantID: car.TenantID,
+		CreatedBy: car.CreatedBy,
+		Name: car.Name,
+		Year: year,
+		Brand: car.Brand,
+		Model: car.Model,
+		FuelType: car.FuelType,
+		EngineID: car.Engine.EngineID,
+		Price: car.Price,
+		VIN: car.VIN,
+		OdometerKm: car.OdometerKm,
+		Transmission: car.Transmission,
+		Drivetrain: car.Drivetrain,
+		Colour: car.Colour,
+		BodyType: car.BodyType,
+		Condition: car.Condition,
+		Features: car.Features,
+		Status: car.Status,
+		SoldAt: car.SoldAt,
+		CreatedAt: car.CreatedAt,
+		UpdatedAt: car.UpdatedAt,
+	}
+	if hasSpecs(&car.Engine) {
+		out.Engine = toEngine(&car.Engine)
+	}
+	if car.ReservedBy != "" {
+		out.Reservation = &Reservation{ReservedBy: car.ReservedBy,ExpiresAt: car.ReservedUntil}
+	}
+	return out
+}
+
+func (Version)Car(car *models.Car) interface{} {
+	return toCar(car)
+}
+
+func (Version)Cars(cars []models.Car) interface{} {
+	out := make([]Car,len(cars))
+	for i := range cars {
+		out[i] = toCar(&cars[i])
+	}
+	return out
+}
+
+func (Version)Engine(engine *models.Engine) interface{} {
+	return toEngine(engine)
+}
+
+func (Version)Engines(engines []models.Engine) interface{} {
+	out := make([]*Engine,len(engines))
+	for i := range engines {
+		out[i] = toEngine(&engines[i])
+	}
+	return out
+}
+
+func (Version)CarRequest(body []byte) (*models.CarRequest,error) {
+	var req CarRequest
+	if err := json.Unmarshal(body,&req); err != nil {
+		return nil,err
+	}
+	carReq := &models.CarRequest{
+		Name: req.Name,
+		Brand: req.Brand,
+		Model: req.Model,
+		FuelType: req.FuelType,
+		Engine: models.Engine{EngineID: req.EngineID},
+		Price: req.Price,
+		VIN: req.VIN,
+		OdometerKm: req.OdometerKm,
+		Transmission: req.Transmission,
+		Drivetrain: req.Drivetrain,
+		Colour: req.Colour,
+		BodyType: req.BodyType,
+		Condition: req.Condition,
+		Features: req.Features,
+		Status: req.Status,
+	}
+	// left empty so a missing year reads as missing rather than out of range
+	if req.Year != 0 {
+		carReq.Year = strconv.Itoa(req.Year)
+	}
+	return carReq,nil
+}
+
+func (Version)EngineRequest(body []byte) (*models.EngineRequest,error) {
+	var req EngineRequest
+	if err := json.Unmarshal(body,&req); err != nil {
+		return nil,err
+	}
+	return &models.EngineRequest{
+		Displacement: req.DisplacementCC,
+		NoOfCylinders: req.Cylinders,
+		CarRange: req.RangeKM,
+		FuelType: req.FuelType,
+		BatteryCapacityKWh: req.BatteryCapacityKWh,
+		MotorPowerKW: req.MotorPowerKW,
+		ChargingStandard: req.ChargingStandard,
+		Horsepower: req.Horsepower,
+		TorqueNm: req.TorqueNm,
+		CO2GPerKm: req.CO2GPerKm,
+	},nil
+}
@@ -2,11 +2,14 @@ package engine
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/iangechuki/go_carzone/handler/dto"
 	"github.com/iangechuki/go_carzone/models"
 	"github.com/iangechuki/go_carzone/service"
 	"go.opentelemetry.io/otel"
@@ -14,11 +17,14 @@ import (
 
 type EngineHandler struct {
 	engineService service.EngineServiceInterface
+	version dto.Version
 }
 
-func NewEngineHandler(engineService service.EngineServiceInterface) *EngineHandler {
+// NewEngineHandler serves engines in the JSON of the given API version.
+func NewEngineHandler(engineService service.EngineServiceInterface,version dto.Version) *EngineHandler {
 	return &EngineHandler{
 		engineService: engineService,
+		version: version,
 	}
 }
 
@@ -36,7 +42,49 @@ func (h *EngineHandler)GetEngineByID(w http.ResponseWriter,r *http.Request){
 		log.Println("Error: ",err)
 		return
 	}
-	body,err := json.Marshal(engine)
+	body,err := json.Marshal(h.version.Engine(engine))
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	w.Header().Set("Content-Type","application/json")
+	if !engine.UpdatedAt.IsZero() {
+		w.Header().Set("Last-Modified",engine.UpdatedAt.UTC().Format(http.TimeFormat))
+	}
+	w.WriteHeader(http.StatusOK)
+	_,err = w.Write(body)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error writing messages ",err)
+		return
+	}
+}
+
+// ListEngines pages through engines with ?limit= and ?offset=.
+func (h *EngineHandler)ListEngines(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("EngineHandler")
+	ctx,span := tracer.Start(r.Context(), "ListEngines-Handler")
+	defer span.End()
+
+	var limit,offset int
+	for param,dest := range map[string]*int{"limit": &limit,"offset": &offset} {
+		if value := r.URL.Query().Get(param); value != "" {
+			n,err := strconv.Atoi(value)
+			if err != nil {
+				http.Error(w,"invalid "+param,http.StatusBadRequest)
+				return
+			}
+			*dest = n
+		}
+	}
+	engines,err := h.engineService.GetEngines(ctx,limit,offset)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	body,err := json.Marshal(h.version.Engines(engines))
 	if err != nil {
 		http.Error(w,err.Error(),http.StatusInternalServerError)
 		log.Println("Error: ",err)
@@ -63,21 +111,20 @@ func (h *EngineHandler)CreateEngine(w http.ResponseWriter,r *http.Request){
 		log.Println("Error: ",err)
 		return
 	}
-	var engineReq models.EngineRequest
-	err = json.Unmarshal(body,&engineReq)
+	engineReq,err := h.version.EngineRequest(body)
 	if err != nil {
 		http.Error(w,err.Error(),http.StatusInternalServerError)
 		log.Println("Error: ",err)
 		return
 	}
-	createdEngine,err := h.engineService.CreateEngine(ctx,&engineReq)
+	createdEngine,err := h.engineService.CreateEngine(ctx,engineReq)
 	if err != nil {
-		http.Error(w,err.Error(),http.StatusInternalServerError)
+		http.Error(w,err.Error(),statusFor(err))
 		log.Println("Error: ",err)
 		return
 	}
 	
-	responseBody ,err := json.Marshal(createdEngine)
+	responseBody ,err := json.Marshal(h.version.Engine(createdEngine))
 	if err != nil {
 		http.Error(w,err.Error(),http.StatusInternalServerError)
 		log.Println("Error while marshallin",err)
@@ -106,7 +153,7 @@ func (h *EngineHandler)DeleteEngine(w http.ResponseWriter,r *http.Request){
 		log.Println("Error deleting engine: ",err)
 		return
 	}
-	if err := json.NewEncoder(w).Encode(deletedEngine); err != nil {
+	if err := json.NewEncoder(w).Encode(h.version.Engine(deletedEngine)); err != nil {
 		http.Error(w,err.Error(),http.StatusInternalServerError)
 		log.Println("Error: ",err)
 		return
@@ -121,22 +168,39 @@ func (h *EngineHandler)UpdateEngine(w http.ResponseWriter,r *http.Request){
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	var engineReq models.EngineRequest
-	if err:= json.NewDecoder(r.Body).Decode(&engineReq); err != nil {
+	body,err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	engineReq,err := h.version.EngineRequest(body)
+	if err != nil {
 		http.Error(w,err.Error(),http.StatusBadRequest)
 		log.Println("Error decoding req: ",err)
 		return
 	}
-	updatedEngine,err := h.engineService.UpdateEngine(ctx,id,&engineReq)
+	updatedEngine,err := h.engineService.UpdateEngine(ctx,id,engineReq)
 	if err != nil {
-		http.Error(w,err.Error(),http.StatusInternalServerError)
+		http.Error(w,err.Error(),statusFor(err))
 		log.Println("Error updating engine: ",err)
 		return
 	}
-	if err := json.NewEncoder(w).Encode(updatedEngine); err != nil {
+	if err := json.NewEncoder(w).Encode(h.version.Engine(updatedEngine)); err != nil {
 		http.Error(w,err.Error(),http.StatusInternalServerError)
 		log.Println("Error: ",err)
 		return
 	}
 
-}
\ No newline at end of file
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err,models.ErrInvalidPagination),errors.Is(err,models.ErrInvalidEngine):
+		return http.StatusBadRequest
+	case errors.Is(err,models.ErrEngineFuelMismatch):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
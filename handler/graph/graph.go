@@ -0,0 +1,58 @@
+// Package graph serves the car and engine services over GraphQL at /graphql.
+package graph
+
+//go:generate go run github.com/99designs/gqlgen@v0.17.78 --config gqlgen.yml generate
+
+import (
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/iangechuki/go_carzone/service"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// listComplexity is what a list without its own page size is assumed to hold.
+const listComplexity = 20
+
+type Resolver struct {
+	carService service.CarServiceInterface
+	engineService service.EngineServiceInterface
+}
+
+func NewResolver(carService service.CarServiceInterface,engineService service.EngineServiceInterface) *Resolver {
+	return &Resolver{
+		carService: carService,
+		engineService: engineService,
+	}
+}
+
+// NewHandler rejects operations whose complexity, roughly the number of fields
+// resolved with lists counted at their page size, exceeds complexityLimit.
+func NewHandler(resolver *Resolver,complexityLimit int) http.Handler {
+	config := Config{Resolvers: resolver}
+	config.Complexity.Query.Cars = func(childComplexity int,filter *CarFilter,first *int,offset *int) int {
+		size := listComplexity
+		if first != nil && *first > 0 {
+			size = *first
+		}
+		return 1 + childComplexity * size
+	}
+	config.Complexity.Query.MyCars = func(childComplexity int) int {
+		return 1 + childComplexity * listComplexity
+	}
+	srv := handler.New(NewExecutableSchema(config))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.FixedComplexityLimit(complexityLimit))
+
+	return http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		// loaders batch and memoise for the duration of one request
+		ctx := withLoaders(r.Context(),resolver.engineService)
+		srv.ServeHTTP(w,r.WithContext(ctx))
+	})
+}
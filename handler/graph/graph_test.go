@@ -0,0 +1,249 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	carService "github.com/iangechuki/go_carzone/service/car"
+	"github.com/iangechuki/go_carzone/store"
+)
+
+// carStore lists cars with the statuses asked for and remembers the last filter.
+type carStore struct {
+	store.CarStoreInterface
+	cars []models.Car
+	filter models.CarFilter
+}
+
+func (s *carStore)GetCarByID(ctx context.Context,id string) (models.Car,error) {
+	for _,car := range s.cars {
+		if car.ID.String() == id {
+			return car,nil
+		}
+	}
+	return models.Car{},models.ErrRecordNotFound
+}
+
+func (s *carStore)GetCarsByBrand(ctx context.Context,brand string,isEngine bool,filter models.CarFilter) ([]models.Car,error) {
+	s.filter = filter
+	cars := []models.Car{}
+	for _,car := range s.cars {
+		if len(filter.Statuses) == 0 || contains(filter.Statuses,car.Status) {
+			cars = append(cars,car)
+		}
+	}
+	return cars,nil
+}
+
+func contains(values []string,value string) bool {
+	for _,v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// engineService records the batches the loader asks for.
+type engineService struct {
+	service.EngineServiceInterface
+	mu sync.Mutex
+	batches [][]string
+}
+
+func (s *engineService)GetEnginesByIDs(ctx context.Context,ids []string) ([]models.Engine,error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches,ids)
+	engines := []models.Engine{}
+	for _,id := range ids {
+		engines = append(engines,models.Engine{EngineID: uuid.MustParse(id),CarRange: 500})
+	}
+	return engines,nil
+}
+
+type response struct {
+	Data json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func query(t *testing.T,h http.Handler,principal *auth.Principal,q string) response {
+	t.Helper()
+	body,err := json.Marshal(map[string]string{"query": q})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodPost,"/graphql",strings.NewReader(string(body)))
+	r.Header.Set("Content-Type","application/json")
+	r = r.WithContext(auth.WithPrincipal(r.Context(),principal))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w,r)
+	var resp response
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding %d response: %v",w.Code,err)
+	}
+	return resp
+}
+
+var (
+	staff = &auth.Principal{UserID: "staff",Roles: []string{models.RoleStaff},Scopes: models.ScopesForRoles([]string{models.RoleStaff})}
+	buyer = &auth.Principal{UserID: "buyer",Roles: []string{models.RoleViewer},Scopes: models.ScopesForRoles([]string{models.RoleViewer})}
+)
+
+func newHandler(cars []models.Car,complexityLimit int) (http.Handler,*carStore,*engineService) {
+	cs := &carStore{cars: cars}
+	es := &engineService{}
+	return NewHandler(NewResolver(carService.NewCarService(cs),es),complexityLimit),cs,es
+}
+
+func TestComplexityLimit(t *testing.T) {
+	h,_,engines := newHandler([]models.Car{{ID: uuid.New(),Status: models.CarStatusAvailable,Engine: models.Engine{EngineID: uuid.New()}}},200)
+
+	// 1 + 100 cars * (id + name + engine and its id) is over the limit
+	resp := query(t,h,staff,`{ cars(first: 100) { items { id name engine { id } } } }`)
+	if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message,"exceeds the limit of 200") {
+		t.Errorf("errors = %+v, want the complexity limit",resp.Errors)
+	}
+	if len(engines.batches) != 0 {
+		t.Error("an operation over the limit was resolved")
+	}
+
+	// the default page of 20 counts the same way
+	resp = query(t,h,staff,`{ cars { items { id name engine { id } } } }`)
+	if len(resp.Errors) != 0 {
+		t.Errorf("errors = %+v, want none for a default page",resp.Errors)
+	}
+	resp = query(t,h,staff,`{ cars { items { id name status price year brand model vin colour engine { id carRange } } } }`)
+	if len(resp.Errors) == 0 {
+		t.Error("a default page asking for many fields passed the limit")
+	}
+}
+
+func TestEnginesBatched(t *testing.T) {
+	shared := uuid.New()
+	cars := []models.Car{}
+	engineIDs := []string{shared.String()}
+	for i := 0; i < 3; i++ {
+		cars = append(cars,models.Car{ID: uuid.New(),Status: models.CarStatusAvailable,Engine: models.Engine{EngineID: shared}})
+		other := uuid.New()
+		cars = append(cars,models.Car{ID: uuid.New(),Status: models.CarStatusAvailable,Engine: models.Engine{EngineID: other}})
+		engineIDs = append(engineIDs,other.String())
+	}
+	h,_,engines := newHandler(cars,1000)
+
+	resp := query(t,h,staff,`{ cars { items { id engine { id carRange } } } }`)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("errors = %+v",resp.Errors)
+	}
+	if len(engines.batches) != 1 {
+		t.Fatalf("engines loaded in %d batches, want 1",len(engines.batches))
+	}
+	got := append([]string{},engines.batches[0]...)
+	sort.Strings(got)
+	sort.Strings(engineIDs)
+	if !reflect.DeepEqual(got,engineIDs) {
+		t.Errorf("batch = %v, want each engine once: %v",got,engineIDs)
+	}
+	var data struct {
+		Cars struct {
+			Items []struct {
+				Engine struct {
+					ID string `json:"id"`
+					CarRange int `json:"carRange"`
+				} `json:"engine"`
+			} `json:"items"`
+		} `json:"cars"`
+	}
+	if err := json.Unmarshal(resp.Data,&data); err != nil {
+		t.Fatal(err)
+	}
+	for i,item := range data.Cars.Items {
+		if item.Engine.ID != cars[i].Engine.EngineID.String() || item.Engine.CarRange != 500 {
+			t.Errorf("car %d has engine %+v, want %s",i,item.Engine,cars[i].Engine.EngineID)
+		}
+	}
+}
+
+func TestBuyerVisibility(t *testing.T) {
+	available := models.Car{ID: uuid.New(),Name: "available",Status: models.CarStatusAvailable}
+	sold := models.Car{ID: uuid.New(),Name: "sold",Status: models.CarStatusSold}
+	mine := models.Car{ID: uuid.New(),Name: "mine",Status: models.CarStatusReserved,ReservedBy: "buyer"}
+	theirs := models.Car{ID: uuid.New(),Name: "theirs",Status: models.CarStatusReserved,ReservedBy: "other"}
+	h,cars,_ := newHandler([]models.Car{available,sold,mine,theirs},1000)
+
+	names := func(principal *auth.Principal,q string) []string {
+		t.Helper()
+		resp := query(t,h,principal,q)
+		if len(resp.Errors) != 0 {
+			t.Fatalf("errors = %+v",resp.Errors)
+		}
+		var data struct {
+			Cars struct {
+				Items []struct {
+					Name string `json:"name"`
+				} `json:"items"`
+			} `json:"cars"`
+		}
+		if err := json.Unmarshal(resp.Data,&data); err != nil {
+			t.Fatal(err)
+		}
+		names := []string{}
+		for _,item := range data.Cars.Items {
+			names = append(names,item.Name)
+		}
+		return names
+	}
+
+	if got := names(buyer,`{ cars(filter: {statuses: ["sold", "reserved"]}) { items { name } } }`); !reflect.DeepEqual(got,[]string{"available"}) {
+		t.Errorf("buyer listed %v, want only the available car",got)
+	}
+	if !reflect.DeepEqual(cars.filter.Statuses,[]string{models.CarStatusAvailable}) {
+		t.Errorf("buyer's statuses reached the store as %v",cars.filter.Statuses)
+	}
+	if got := names(staff,`{ cars(filter: {statuses: ["sold"]}) { items { name } } }`); !reflect.DeepEqual(got,[]string{"sold"}) {
+		t.Errorf("staff listed %v, want the sold car",got)
+	}
+
+	tests := []struct {
+		car models.Car
+		principal *auth.Principal
+		visible bool
+	}{
+		{car: available,principal: buyer,visible: true},
+		{car: sold,principal: buyer},
+		{car: mine,principal: buyer,visible: true},
+		{car: theirs,principal: buyer},
+		{car: sold,principal: staff,visible: true},
+		{car: theirs,principal: staff,visible: true},
+	}
+	for _,tt := range tests {
+		resp := query(t,h,tt.principal,`{ car(id: "`+tt.car.ID.String()+`") { name } }`)
+		if len(resp.Errors) != 0 {
+			t.Fatalf("errors = %+v",resp.Errors)
+		}
+		var data struct {
+			Car *struct {
+				Name string `json:"name"`
+			} `json:"car"`
+		}
+		if err := json.Unmarshal(resp.Data,&data); err != nil {
+			t.Fatal(err)
+		}
+		if visible := data.Car != nil; visible != tt.visible {
+			t.Errorf("%s sees the %s car: %v, want %v",tt.principal.UserID,tt.car.Name,visible,tt.visible)
+		}
+	}
+}
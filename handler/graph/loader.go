@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/otel"
+)
+
+type loadersKey struct{}
+
+type loaders struct {
+	engines *dataloader.Loader[string,*models.Engine]
+}
+
+func withLoaders(ctx context.Context,engineService service.EngineServiceInterface) context.Context {
+	return context.WithValue(ctx,loadersKey{},&loaders{
+		engines: dataloader.NewBatchedLoader(engineBatch(engineService),
+			dataloader.WithWait[string,*models.Engine](2 * time.Millisecond),
+			dataloader.WithBatchCapacity[string,*models.Engine](models.MaxPageSize),
+		),
+	})
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// engineBatch resolves the engines of every car in a response with one query,
+// engines that are gone or belong to another tenant load as nil.
+func engineBatch(engineService service.EngineServiceInterface) dataloader.BatchFunc[string,*models.Engine] {
+	return func(ctx context.Context,ids []string) []*dataloader.Result[*models.Engine] {
+		tracer := otel.Tracer("GraphQL")
+		ctx,span := tracer.Start(ctx, "LoadEngines-Loader")
+		defer span.End()
+
+		results := make([]*dataloader.Result[*models.Engine],len(ids))
+		engines,err := engineService.GetEnginesByIDs(ctx,ids)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*models.Engine]{Error: err}
+			}
+			return results
+		}
+		byID := make(map[string]*models.Engine,len(engines))
+		for i := range engines {
+			byID[engines[i].EngineID.String()] = &engines[i]
+		}
+		for i,id := range ids {
+			results[i] = &dataloader.Result[*models.Engine]{Data: byID[id]}
+		}
+		return results
+	}
+}
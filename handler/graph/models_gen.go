@@ -0,0 +1,56 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package graph
+
+import (
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/models"
+)
+
+// Empty fields match everything, buyers only get available cars whatever the statuses.
+type CarFilter struct {
+	Brand        *string  `json:"brand,omitempty"`
+	Statuses     []string `json:"statuses,omitempty"`
+	Vin          *string  `json:"vin,omitempty"`
+	Transmission *string  `json:"transmission,omitempty"`
+	Drivetrain   *string  `json:"drivetrain,omitempty"`
+	BodyType     *string  `json:"bodyType,omitempty"`
+	Condition    *string  `json:"condition,omitempty"`
+	// matched ignoring case
+	Colour        *string `json:"colour,omitempty"`
+	MaxOdometerKm *int    `json:"maxOdometerKm,omitempty"`
+	// cars having every one of these
+	Features []string `json:"features,omitempty"`
+}
+
+type CarInput struct {
+	Name string `json:"name"`
+	Year string `json:"year"`
+	// any spelling the catalogue knows
+	Brand        string    `json:"brand"`
+	Model        *string   `json:"model,omitempty"`
+	FuelType     string    `json:"fuelType"`
+	EngineID     uuid.UUID `json:"engineId"`
+	Price        float64   `json:"price"`
+	Vin          *string   `json:"vin,omitempty"`
+	OdometerKm   *int      `json:"odometerKm,omitempty"`
+	Transmission *string   `json:"transmission,omitempty"`
+	Drivetrain   *string   `json:"drivetrain,omitempty"`
+	Colour       *string   `json:"colour,omitempty"`
+	BodyType     *string   `json:"bodyType,omitempty"`
+	Condition    *string   `json:"condition,omitempty"`
+	Features     []string  `json:"features,omitempty"`
+	Status       *string   `json:"status,omitempty"`
+}
+
+type CarPage struct {
+	Items []models.Car `json:"items"`
+	// true when the page came back full, the next page may then be empty
+	HasMore bool `json:"hasMore"`
+}
+
+type Mutation struct {
+}
+
+type Query struct {
+}
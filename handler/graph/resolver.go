@@ -0,0 +1,213 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"go.opentelemetry.io/otel"
+)
+
+func (r *Resolver)Car() CarResolver { return &carResolver{r} }
+
+func (r *Resolver)Mutation() MutationResolver { return &mutationResolver{r} }
+
+func (r *Resolver)Query() QueryResolver { return &queryResolver{r} }
+
+type carResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+
+// requireScope applies the scopes the REST routes check in middleware.RequireScope.
+func requireScope(ctx context.Context,scope string) error {
+	principal,ok := auth.PrincipalFromContext(ctx)
+	if !ok || !principal.HasScope(scope) {
+		return errors.New("missing scope "+scope)
+	}
+	return nil
+}
+
+func (r *carResolver)Engine(ctx context.Context,obj *models.Car) (*models.Engine,error) {
+	if obj.Engine.EngineID == uuid.Nil {
+		return nil,nil
+	}
+	return loadersFrom(ctx).engines.Load(ctx,obj.Engine.EngineID.String())()
+}
+
+func (r *queryResolver)Car(ctx context.Context,id uuid.UUID) (*models.Car,error) {
+	tracer := otel.Tracer("GraphQL")
+	ctx,span := tracer.Start(ctx, "Car-Resolver")
+	defer span.End()
+
+	if err := requireScope(ctx,models.ScopeCarsRead); err != nil {
+		return nil,err
+	}
+	car,err := r.carService.GetCarByID(ctx,id.String())
+	if errors.Is(err,models.ErrRecordNotFound) {
+		return nil,nil
+	}
+	return car,err
+}
+
+func (r *queryResolver)Cars(ctx context.Context,filter *CarFilter,first *int,offset *int) (*CarPage,error) {
+	tracer := otel.Tracer("GraphQL")
+	ctx,span := tracer.Start(ctx, "Cars-Resolver")
+	defer span.End()
+
+	if err := requireScope(ctx,models.ScopeCarsRead); err != nil {
+		return nil,err
+	}
+	carFilter := models.CarFilter{Limit: 20}
+	if first != nil {
+		carFilter.Limit = *first
+	}
+	if offset != nil {
+		carFilter.Offset = *offset
+	}
+	if carFilter.Limit < 1 || carFilter.Limit > models.MaxPageSize {
+		return nil,errors.New("first must be between 1 and 100")
+	}
+	brand := ""
+	if filter != nil {
+		carFilter.Statuses = filter.Statuses
+		if filter.Brand != nil {
+			brand = *filter.Brand
+		}
+		carFilter.VIN = deref(filter.Vin)
+		carFilter.Transmission = deref(filter.Transmission)
+		carFilter.Drivetrain = deref(filter.Drivetrain)
+		carFilter.BodyType = deref(filter.BodyType)
+		carFilter.Condition = deref(filter.Condition)
+		carFilter.Colour = deref(filter.Colour)
+		carFilter.MaxOdometerKm = deref(filter.MaxOdometerKm)
+		carFilter.Features = filter.Features
+	}
+	cars,err := r.carService.GetCarsByBrand(ctx,brand,false,carFilter)
+	if err != nil {
+		return nil,err
+	}
+	return &CarPage{Items: cars,HasMore: len(cars) == carFilter.Limit},nil
+}
+
+func (r *queryResolver)MyCars(ctx context.Context) ([]models.Car,error) {
+	tracer := otel.Tracer("GraphQL")
+	ctx,span := tracer.Start(ctx, "MyCars-Resolver")
+	defer span.End()
+
+	if err := requireScope(ctx,models.ScopeCarsRead); err != nil {
+		return nil,err
+	}
+	return r.carService.GetMyCars(ctx)
+}
+
+func (r *queryResolver)Engine(ctx context.Context,id uuid.UUID) (*models.Engine,error) {
+	tracer := otel.Tracer("GraphQL")
+	ctx,span := tracer.Start(ctx, "Engine-Resolver")
+	defer span.End()
+
+	if err := requireScope(ctx,models.ScopeEnginesRead); err != nil {
+		return nil,err
+	}
+	return loadersFrom(ctx).engines.Load(ctx,id.String())()
+}
+
+func carRequest(input CarInput) *models.CarRequest {
+	carReq := &models.CarRequest{
+		Name: input.Name,
+		Year: input.Year,
+		Brand: input.Brand,
+		Model: deref(input.Model),
+		FuelType: input.FuelType,
+		Engine: models.Engine{EngineID: input.EngineID},
+		Price: input.Price,
+		VIN: deref(input.Vin),
+		OdometerKm: deref(input.OdometerKm),
+		Transmission: deref(input.Transmission),
+		Drivetrain: deref(input.Drivetrain),
+		Colour: deref(input.Colour),
+		BodyType: deref(input.BodyType),
+		Condition: deref(input.Condition),
+		Features: input.Features,
+	}
+	if input.Status != nil {
+		carReq.Status = *input.Status
+	}
+	return carReq
+}
+
+// deref reads an optional input field, leaving it out is its zero value.
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+func (r *mutationResolver)CreateCar(ctx context.Context,input CarInput) (*models.Car,error) {
+	tracer := otel.Tracer("GraphQL")
+	ctx,span := tracer.Start(ctx, "CreateCar-Resolver")
+	defer span.End()
+
+	if err := requireScope(ctx,models.ScopeCarsWrite); err != nil {
+		return nil,err
+	}
+	return r.carService.CreateCar(ctx,carRequest(input))
+}
+
+func (r *mutationResolver)UpdateCar(ctx context.Context,id uuid.UUID,input CarInput) (*models.Car,error) {
+	tracer := otel.Tracer("GraphQL")
+	ctx,span := tracer.Start(ctx, "UpdateCar-Resolver")
+	defer span.End()
+
+	if err := requireScope(ctx,models.ScopeCarsWrite); err != nil {
+		return nil,err
+	}
+	return r.carService.UpdateCar(ctx,id.String(),carRequest(input))
+}
+
+func (r *mutationResolver)DeleteCar(ctx context.Context,id uuid.UUID) (*models.Car,error) {
+	tracer := otel.Tracer("GraphQL")
+	ctx,span := tracer.Start(ctx, "DeleteCar-Resolver")
+	defer span.End()
+
+	if err := requireScope(ctx,models.ScopeCarsWrite); err != nil {
+		return nil,err
+	}
+	return r.carService.DeleteCar(ctx,id.String())
+}
+
+func (r *mutationResolver)CreateEngine(ctx context.Context,input models.EngineRequest) (*models.Engine,error) {
+	tracer := otel.Tracer("GraphQL")
+	ctx,span := tracer.Start(ctx, "CreateEngine-Resolver")
+	defer span.End()
+
+	if err := requireScope(ctx,models.ScopeEnginesWrite); err != nil {
+		return nil,err
+	}
+	return r.engineService.CreateEngine(ctx,&input)
+}
+
+func (r *mutationResolver)UpdateEngine(ctx context.Context,id uuid.UUID,input models.EngineRequest) (*models.Engine,error) {
+	tracer := otel.Tracer("GraphQL")
+	ctx,span := tracer.Start(ctx, "UpdateEngine-Resolver")
+	defer span.End()
+
+	if err := requireScope(ctx,models.ScopeEnginesWrite); err != nil {
+		return nil,err
+	}
+	return r.engineService.UpdateEngine(ctx,id.String(),&input)
+}
+
+func (r *mutationResolver)DeleteEngine(ctx context.Context,id uuid.UUID) (*models.Engine,error) {
+	tracer := otel.Tracer("GraphQL")
+	ctx,span := tracer.Start(ctx, "DeleteEngine-Resolver")
+	defer span.End()
+
+	if err := requireScope(ctx,models.ScopeEnginesWrite); err != nil {
+		return nil,err
+	}
+	return r.engineService.DeleteEngine(ctx,id.String())
+}
@@ -2,27 +2,49 @@ package login
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/iangechuki/go_carzone/auth"
 	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/otel"
 )
 
+type LoginHandler struct {
+	userService service.UserServiceInterface
+}
+
+// NewLoginHandler checks credentials against the users made with create-user.
+func NewLoginHandler(userService service.UserServiceInterface) *LoginHandler {
+	return &LoginHandler{
+		userService: userService,
+	}
+}
+
+func (h *LoginHandler)Login(w http.ResponseWriter, r *http.Request) {
+	tracer := otel.Tracer("LoginHandler")
+	ctx,span := tracer.Start(r.Context(), "Login-Handler")
+	defer span.End()
 
-func LoginHandler(w http.ResponseWriter, r *http.Request) {
 	var credentials models.Credientials
 	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	valid := (credentials.UserName == "admin") && (credentials.Password == "admin")
-	if !valid {
+	user,err := h.userService.Authenticate(ctx,credentials.UserName,credentials.Password)
+	if errors.Is(err,models.ErrInvalidCredentials) {
 		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
 		return
 	}
-	tokenString,err := GenerateToken(credentials.UserName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	tokenString,err := GenerateToken(user)
 	if err != nil {
 		http.Error(w, "Failed to generate token", http.StatusInternalServerError)
 		log.Println("Error: ",err)
@@ -33,17 +55,9 @@ func LoginHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
-func GenerateToken(username string) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour)
-	claims := jwt.RegisteredClaims{
-		Subject: username,
-		IssuedAt: jwt.NewNumericDate(time.Now()),
-		ExpiresAt: jwt.NewNumericDate(expirationTime),
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte("secret_key"))
-	if err != nil {
-		return "", err
-	}
-	return tokenString, nil
-}
\ No newline at end of file
+
+// GenerateToken uses the username as the subject, it is what created_by and
+// reserved_by have always recorded.
+func GenerateToken(user *models.User) (string, error) {
+	return auth.GenerateToken(user.Username, user.Username, user.Roles, user.TenantID, 24*time.Hour)
+}
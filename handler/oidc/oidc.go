@@ -0,0 +1,114 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/iangechuki/go_carzone/service"
+	oidcService "github.com/iangechuki/go_carzone/service/oidc"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/oauth2"
+)
+
+// the pending login (state, nonce and PKCE verifier) lives in a short lived cookie so any replica can finish it
+const loginCookie = "carzone_oidc"
+
+type OIDCHandler struct {
+	oidcService service.OIDCServiceInterface
+	secureCookie bool
+}
+
+func NewOIDCHandler(oidcService service.OIDCServiceInterface,secureCookie bool) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService: oidcService,
+		secureCookie: secureCookie,
+	}
+}
+
+func (h *OIDCHandler)Login(w http.ResponseWriter,r *http.Request){
+	state,err := randomToken()
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	nonce,err := randomToken()
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	verifier := oauth2.GenerateVerifier()
+	http.SetCookie(w,&http.Cookie{
+		Name: loginCookie,
+		Value: strings.Join([]string{state,nonce,verifier},"."),
+		Path: "/auth/oidc",
+		MaxAge: 600,
+		HttpOnly: true,
+		Secure: h.secureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w,r,h.oidcService.AuthCodeURL(state,nonce,verifier),http.StatusFound)
+}
+
+func (h *OIDCHandler)Callback(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("OIDCHandler")
+	ctx,span := tracer.Start(r.Context(), "Callback-Handler")
+	defer span.End()
+
+	query := r.URL.Query()
+	if idpErr := query.Get("error"); idpErr != "" {
+		http.Error(w,"Login failed: "+idpErr,http.StatusUnauthorized)
+		return
+	}
+	cookie,err := r.Cookie(loginCookie)
+	if err != nil {
+		http.Error(w,"Login session is missing or expired",http.StatusBadRequest)
+		return
+	}
+	http.SetCookie(w,&http.Cookie{Name: loginCookie,Path: "/auth/oidc",MaxAge: -1})
+	parts := strings.Split(cookie.Value,".")
+	if len(parts) != 3 || subtle.ConstantTimeCompare([]byte(parts[0]),[]byte(query.Get("state"))) != 1 {
+		http.Error(w,"Invalid login state",http.StatusBadRequest)
+		return
+	}
+	login,err := h.oidcService.Exchange(ctx,query.Get("code"),parts[2],parts[1])
+	if err != nil {
+		if errors.Is(err,oidcService.ErrNoRoles) {
+			http.Error(w,err.Error(),http.StatusForbidden)
+			return
+		}
+		http.Error(w,"Login failed",http.StatusUnauthorized)
+		log.Println("Error completing oidc login: ",err)
+		return
+	}
+	body,err := json.Marshal(login)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	w.Header().Set("Content-Type","application/json")
+	w.Header().Set("Cache-Control","no-store")
+	w.WriteHeader(http.StatusOK)
+	_,err = w.Write(body)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error writing messages ",err)
+		return
+	}
+}
+
+func randomToken() (string,error) {
+	b := make([]byte,24)
+	if _,err := rand.Read(b); err != nil {
+		return "",err
+	}
+	return base64.RawURLEncoding.EncodeToString(b),nil
+}
@@ -0,0 +1,197 @@
+package order
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/iangechuki/go_carzone/invoice"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/otel"
+)
+
+type OrderHandler struct {
+	orderService service.OrderServiceInterface
+}
+
+func NewOrderHandler(orderService service.OrderServiceInterface) *OrderHandler {
+	return &OrderHandler{
+		orderService: orderService,
+	}
+}
+
+func (h *OrderHandler)GetOrderByID(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("OrderHandler")
+	ctx,span := tracer.Start(r.Context(), "GetOrderByID-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	order,err := h.orderService.GetOrderByID(ctx,id)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,order)
+}
+
+func (h *OrderHandler)ListOrders(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("OrderHandler")
+	ctx,span := tracer.Start(r.Context(), "ListOrders-Handler")
+	defer span.End()
+
+	orders,err := h.orderService.ListOrders(ctx)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,orders)
+}
+
+func (h *OrderHandler)CreateOrder(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("OrderHandler")
+	ctx,span := tracer.Start(r.Context(), "CreateOrder-Handler")
+	defer span.End()
+
+	var orderReq models.OrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&orderReq); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	order,err := h.orderService.CreateOrder(ctx,&orderReq)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error creating order: ",err)
+		return
+	}
+	writeJSON(w,http.StatusCreated,order)
+}
+
+func (h *OrderHandler)RecordPayment(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("OrderHandler")
+	ctx,span := tracer.Start(r.Context(), "RecordPayment-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	var paymentReq models.PaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&paymentReq); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	order,err := h.orderService.RecordPayment(ctx,id,&paymentReq)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error recording payment: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,order)
+}
+
+func (h *OrderHandler)CancelOrder(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("OrderHandler")
+	ctx,span := tracer.Start(r.Context(), "CancelOrder-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	order,err := h.orderService.CancelOrder(ctx,id)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error cancelling order: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,order)
+}
+
+func (h *OrderHandler)RefundOrder(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("OrderHandler")
+	ctx,span := tracer.Start(r.Context(), "RefundOrder-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	order,err := h.orderService.RefundOrder(ctx,id)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error refunding order: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,order)
+}
+
+// GetInvoice renders the invoice as HTML, or as PDF for ?format=pdf or an Accept of application/pdf.
+func (h *OrderHandler)GetInvoice(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("OrderHandler")
+	ctx,span := tracer.Start(r.Context(), "GetInvoice-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	inv,err := h.orderService.GetInvoice(ctx,id)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	var body bytes.Buffer
+	format := r.URL.Query().Get("format")
+	if format == "pdf" || (format == "" && strings.Contains(r.Header.Get("Accept"),"application/pdf")) {
+		err = invoice.RenderPDF(&body,inv)
+		w.Header().Set("Content-Type","application/pdf")
+		w.Header().Set("Content-Disposition",`inline; filename="`+inv.Order.InvoiceNumber+`.pdf"`)
+	} else {
+		err = invoice.RenderHTML(&body,inv)
+		w.Header().Set("Content-Type","text/html; charset=utf-8")
+	}
+	if err != nil {
+		w.Header().Del("Content-Disposition")
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error rendering invoice: ",err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if _,err := w.Write(body.Bytes()); err != nil {
+		log.Println("Error writing messages ",err)
+	}
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err,models.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err,models.ErrTenantRequired):
+		return http.StatusBadRequest
+	case errors.Is(err,models.ErrCarNotForSale),errors.Is(err,models.ErrOrderClosed),errors.Is(err,models.ErrOrderHasPayments):
+		return http.StatusConflict
+	case errors.Is(err,models.ErrOverpayment):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err,models.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter,status int,v interface{}) {
+	body,err := json.Marshal(v)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	w.Header().Set("Content-Type","application/json")
+	w.WriteHeader(status)
+	if _,err := w.Write(body); err != nil {
+		log.Println("Error writing messages ",err)
+	}
+}
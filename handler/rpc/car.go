@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/iangechuki/go_carzone/models"
+	carzonev1 "github.com/iangechuki/go_carzone/proto/carzone/v1"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc"
+)
+
+type CarServer struct {
+	carzonev1.UnimplementedCarServiceServer
+	carService service.CarServiceInterface
+}
+
+func NewCarServer(carService service.CarServiceInterface) *CarServer {
+	return &CarServer{
+		carService: carService,
+	}
+}
+
+func (s *CarServer)GetCar(ctx context.Context,req *carzonev1.GetCarRequest) (*carzonev1.Car,error) {
+	tracer := otel.Tracer("CarRPC")
+	ctx,span := tracer.Start(ctx, "GetCar-RPC")
+	defer span.End()
+
+	car,err := s.carService.GetCarByID(ctx,req.GetId())
+	if err != nil {
+		return nil,statusFor(err)
+	}
+	return toCar(car),nil
+}
+
+// ListCars pages through the service so large listings are never held in memory at once.
+func (s *CarServer)ListCars(req *carzonev1.ListCarsRequest,stream grpc.ServerStreamingServer[carzonev1.Car]) error {
+	tracer := otel.Tracer("CarRPC")
+	ctx,span := tracer.Start(stream.Context(), "ListCars-RPC")
+	defer span.End()
+
+	filter := models.CarFilter{
+		Statuses: req.GetStatuses(),
+		VIN: req.GetVin(),
+		Transmission: req.GetTransmission(),
+		Drivetrain: req.GetDrivetrain(),
+		BodyType: req.GetBodyType(),
+		Condition: req.GetCondition(),
+		Colour: req.GetColour(),
+		MaxOdometerKm: int(req.GetMaxOdometerKm()),
+		Features: req.GetFeatures(),
+		Limit: models.MaxPageSize,
+	}
+	for {
+		cars,err := s.carService.GetCarsByBrand(ctx,req.GetBrand(),req.GetIncludeEngine(),filter)
+		if err != nil {
+			return statusFor(err)
+		}
+		for i := range cars {
+			if err := stream.Send(toCar(&cars[i])); err != nil {
+				return err
+			}
+		}
+		if len(cars) < filter.Limit {
+			return nil
+		}
+		filter.Offset += len(cars)
+	}
+}
+
+func (s *CarServer)ListMyCars(req *carzonev1.ListMyCarsRequest,stream grpc.ServerStreamingServer[carzonev1.Car]) error {
+	tracer := otel.Tracer("CarRPC")
+	ctx,span := tracer.Start(stream.Context(), "ListMyCars-RPC")
+	defer span.End()
+
+	cars,err := s.carService.GetMyCars(ctx)
+	if err != nil {
+		return statusFor(err)
+	}
+	for i := range cars {
+		if err := stream.Send(toCar(&cars[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CarServer)CreateCar(ctx context.Context,req *carzonev1.CreateCarRequest) (*carzonev1.Car,error) {
+	tracer := otel.Tracer("CarRPC")
+	ctx,span := tracer.Start(ctx, "CreateCar-RPC")
+	defer span.End()
+
+	carReq,err := carRequest(req.GetCar())
+	if err != nil {
+		return nil,statusFor(err)
+	}
+	car,err := s.carService.CreateCar(ctx,carReq)
+	if err != nil {
+		return nil,statusFor(err)
+	}
+	return toCar(car),nil
+}
+
+func (s *CarServer)UpdateCar(ctx context.Context,req *carzonev1.UpdateCarRequest) (*carzonev1.Car,error) {
+	tracer := otel.Tracer("CarRPC")
+	ctx,span := tracer.Start(ctx, "UpdateCar-RPC")
+	defer span.End()
+
+	carReq,err := carRequest(req.GetCar())
+	if err != nil {
+		return nil,statusFor(err)
+	}
+	car,err := s.carService.UpdateCar(ctx,req.GetId(),carReq)
+	if err != nil {
+		return nil,statusFor(err)
+	}
+	return toCar(car),nil
+}
+
+func (s *CarServer)DeleteCar(ctx context.Context,req *carzonev1.DeleteCarRequest) (*carzonev1.Car,error) {
+	tracer := otel.Tracer("CarRPC")
+	ctx,span := tracer.Start(ctx, "DeleteCar-RPC")
+	defer span.End()
+
+	car,err := s.carService.DeleteCar(ctx,req.GetId())
+	if err != nil {
+		return nil,statusFor(err)
+	}
+	return toCar(car),nil
+}
@@ -0,0 +1,104 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/models"
+	carzonev1 "github.com/iangechuki/go_carzone/proto/carzone/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toEngine(engine *models.Engine) *carzonev1.Engine {
+	return &carzonev1.Engine{
+		Id: engine.EngineID.String(),
+		TenantId: engine.TenantID.String(),
+		Displacement: engine.Displacement,
+		NoOfCylinders: engine.NoOfCylinders,
+		CarRange: engine.CarRange,
+		UpdatedAt: timestamp(&engine.UpdatedAt),
+		FuelType: engine.FuelType,
+		BatteryCapacityKwh: engine.BatteryCapacityKWh,
+		MotorPowerKw: engine.MotorPowerKW,
+		ChargingStandard: engine.ChargingStandard,
+		Horsepower: engine.Horsepower,
+		TorqueNm: engine.TorqueNm,
+		Co2GPerKm: engine.CO2GPerKm,
+	}
+}
+
+func toCar(car *models.Car) *carzonev1.Car {
+	return &carzonev1.Car{
+		Id: car.ID.String(),
+		TenantId: car.TenantID.String(),
+		CreatedBy: car.CreatedBy,
+		Name: car.Name,
+		Year: car.Year,
+		Brand: car.Brand,
+		FuelType: car.FuelType,
+		Engine: toEngine(&car.Engine),
+		Price: car.Price,
+		Status: car.Status,
+		ReservedBy: car.ReservedBy,
+		ReservedUntil: timestamp(car.ReservedUntil),
+		SoldAt: timestamp(car.SoldAt),
+		CreatedAt: timestamp(&car.CreatedAt),
+		UpdatedAt: timestamp(&car.UpdatedAt),
+		Vin: car.VIN,
+		OdometerKm: int64(car.OdometerKm),
+		Transmission: car.Transmission,
+		Drivetrain: car.Drivetrain,
+		Colour: car.Colour,
+		BodyType: car.BodyType,
+		Condition: car.Condition,
+		Features: car.Features,
+		Model: car.Model,
+	}
+}
+
+func carRequest(input *carzonev1.CarInput) (*models.CarRequest,error) {
+	engineID,err := uuid.Parse(input.GetEngineId())
+	if err != nil {
+		return nil,errInvalidEngineID
+	}
+	return &models.CarRequest{
+		Name: input.GetName(),
+		Year: input.GetYear(),
+		Brand: input.GetBrand(),
+		FuelType: input.GetFuelType(),
+		Engine: models.Engine{EngineID: engineID},
+		Price: input.GetPrice(),
+		Status: input.GetStatus(),
+		VIN: input.GetVin(),
+		OdometerKm: int(input.GetOdometerKm()),
+		Transmission: input.GetTransmission(),
+		Drivetrain: input.GetDrivetrain(),
+		Colour: input.GetColour(),
+		BodyType: input.GetBodyType(),
+		Condition: input.GetCondition(),
+		Features: input.GetFeatures(),
+		Model: input.GetModel(),
+	},nil
+}
+
+func engineRequest(input *carzonev1.EngineInput) *models.EngineRequest {
+	return &models.EngineRequest{
+		Displacement: input.GetDisplacement(),
+		NoOfCylinders: input.GetNoOfCylinders(),
+		CarRange: input.GetCarRange(),
+		FuelType: input.GetFuelType(),
+		BatteryCapacityKWh: input.GetBatteryCapacityKwh(),
+		MotorPowerKW: input.GetMotorPowerKw(),
+		ChargingStandard: input.GetChargingStandard(),
+		Horsepower: input.GetHorsepower(),
+		TorqueNm: input.GetTorqueNm(),
+		CO2GPerKm: input.GetCo2GPerKm(),
+	}
+}
@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"context"
+
+	carzonev1 "github.com/iangechuki/go_carzone/proto/carzone/v1"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc"
+)
+
+type EngineServer struct {
+	carzonev1.UnimplementedEngineServiceServer
+	engineService service.EngineServiceInterface
+}
+
+func NewEngineServer(engineService service.EngineServiceInterface) *EngineServer {
+	return &EngineServer{
+		engineService: engineService,
+	}
+}
+
+func (s *EngineServer)GetEngine(ctx context.Context,req *carzonev1.GetEngineRequest) (*carzonev1.Engine,error) {
+	tracer := otel.Tracer("EngineRPC")
+	ctx,span := tracer.Start(ctx, "GetEngine-RPC")
+	defer span.End()
+
+	engine,err := s.engineService.GetEngineByID(ctx,req.GetId())
+	if err != nil {
+		return nil,statusFor(err)
+	}
+	return toEngine(engine),nil
+}
+
+func (s *EngineServer)ListEngines(req *carzonev1.ListEnginesRequest,stream grpc.ServerStreamingServer[carzonev1.Engine]) error {
+	tracer := otel.Tracer("EngineRPC")
+	ctx,span := tracer.Start(stream.Context(), "ListEngines-RPC")
+	defer span.End()
+
+	engines,err := s.engineService.GetEnginesByIDs(ctx,req.GetIds())
+	if err != nil {
+		return statusFor(err)
+	}
+	for i := range engines {
+		if err := stream.Send(toEngine(&engines[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *EngineServer)CreateEngine(ctx context.Context,req *carzonev1.CreateEngineRequest) (*carzonev1.Engine,error) {
+	tracer := otel.Tracer("EngineRPC")
+	ctx,span := tracer.Start(ctx, "CreateEngine-RPC")
+	defer span.End()
+
+	engine,err := s.engineService.CreateEngine(ctx,engineRequest(req.GetEngine()))
+	if err != nil {
+		return nil,statusFor(err)
+	}
+	return toEngine(engine),nil
+}
+
+func (s *EngineServer)UpdateEngine(ctx context.Context,req *carzonev1.UpdateEngineRequest) (*carzonev1.Engine,error) {
+	tracer := otel.Tracer("EngineRPC")
+	ctx,span := tracer.Start(ctx, "UpdateEngine-RPC")
+	defer span.End()
+
+	engine,err := s.engineService.UpdateEngine(ctx,req.GetId(),engineRequest(req.GetEngine()))
+	if err != nil {
+		return nil,statusFor(err)
+	}
+	return toEngine(engine),nil
+}
+
+func (s *EngineServer)DeleteEngine(ctx context.Context,req *carzonev1.DeleteEngineRequest) (*carzonev1.Engine,error) {
+	tracer := otel.Tracer("EngineRPC")
+	ctx,span := tracer.Start(ctx, "DeleteEngine-RPC")
+	defer span.End()
+
+	engine,err := s.engineService.DeleteEngine(ctx,req.GetId())
+	if err != nil {
+		return nil,statusFor(err)
+	}
+	return toEngine(engine),nil
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"math"
+	"net"
+	"strconv"
+
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// callKeyFunc is the gRPC counterpart of middleware.KeyFunc, an empty key skips limiting.
+type callKeyFunc func(ctx context.Context) string
+
+// callerIP is the peer's address. Calls from loopback are the gateway's,
+// which the HTTP limiter already counted, and are not counted again.
+func callerIP(ctx context.Context) string {
+	p,ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host,_,err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return ""
+	}
+	return host
+}
+
+func callerUserID(ctx context.Context) string {
+	return auth.UserID(ctx)
+}
+
+// takeCall counts the call against limiter, routes are full method names.
+// Like the HTTP middleware it fails open when the store is unavailable.
+func takeCall(ctx context.Context,limiter *middleware.RateLimiter,key callKeyFunc,method string) error {
+	identity := key(ctx)
+	if limiter == nil || identity == "" {
+		return nil
+	}
+	_,result,err := limiter.Take(ctx,method,identity)
+	if err != nil {
+		log.Println("Error checking rate limit: ",err)
+		return nil
+	}
+	if !result.Allowed {
+		retryAfter := strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds())))
+		grpc.SetHeader(ctx,metadata.Pairs("retry-after",retryAfter))
+		return status.Error(codes.ResourceExhausted,"Too many requests, retry after "+retryAfter+"s")
+	}
+	return nil
+}
+
+func limitUnary(limiter *middleware.RateLimiter,key callKeyFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context,req interface{},info *grpc.UnaryServerInfo,handler grpc.UnaryHandler) (interface{},error) {
+		if err := takeCall(ctx,limiter,key,info.FullMethod); err != nil {
+			return nil,err
+		}
+		return handler(ctx,req)
+	}
+}
+
+func limitStream(limiter *middleware.RateLimiter,key callKeyFunc) grpc.StreamServerInterceptor {
+	return func(srv interface{},ss grpc.ServerStream,info *grpc.StreamServerInfo,handler grpc.StreamHandler) error {
+		if err := takeCall(ss.Context(),limiter,key,info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv,ss)
+	}
+}
@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/iangechuki/go_carzone/middleware"
+	"github.com/iangechuki/go_carzone/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func fromPeer(addr string) context.Context {
+	tcpAddr,_ := net.ResolveTCPAddr("tcp",addr)
+	return peer.NewContext(context.Background(),&peer.Peer{Addr: tcpAddr})
+}
+
+func TestLimitUnary(t *testing.T) {
+	limiter := middleware.NewRateLimiter("ip",middleware.NewMemoryRateLimitStore(),nil,middleware.Limit{Requests: 2,Period: time.Minute})
+	interceptor := limitUnary(limiter,callerIP)
+	info := &grpc.UnaryServerInfo{FullMethod: "/carzone.v1.CarService/GetCar"}
+	handler := func(ctx context.Context,req interface{}) (interface{},error) {
+		return "ok",nil
+	}
+
+	tests := []struct {
+		name string
+		ctx context.Context
+		want codes.Code
+	}{
+		{name: "first",ctx: fromPeer("203.0.113.7:5000"),want: codes.OK},
+		{name: "second",ctx: fromPeer("203.0.113.7:5001"),want: codes.OK},
+		{name: "over the limit",ctx: fromPeer("203.0.113.7:5002"),want: codes.ResourceExhausted},
+		{name: "another caller",ctx: fromPeer("198.51.100.1:5000"),want: codes.OK},
+		// the gateway's calls were counted by the HTTP limiter
+		{name: "gateway",ctx: fromPeer("127.0.0.1:5000"),want: codes.OK},
+		{name: "gateway again",ctx: fromPeer("127.0.0.1:5000"),want: codes.OK},
+		{name: "gateway once more",ctx: fromPeer("127.0.0.1:5000"),want: codes.OK},
+	}
+	for _,tt := range tests {
+		_,err := interceptor(tt.ctx,nil,info,handler)
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("%s: code = %v, want %v",tt.name,got,tt.want)
+		}
+	}
+}
+
+func TestStatusForHidesInternalErrors(t *testing.T) {
+	err := statusFor(errors.New(`pq: relation "car" does not exist`))
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("code = %v, want %v",status.Code(err),codes.Internal)
+	}
+	if msg := status.Convert(err).Message(); msg != "Internal server error" {
+		t.Errorf("message = %q, want a generic one",msg)
+	}
+	if got := status.Code(statusFor(models.ErrRecordNotFound)); got != codes.NotFound {
+		t.Errorf("not found maps to %v, want %v",got,codes.NotFound)
+	}
+}
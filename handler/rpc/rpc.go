@@ -0,0 +1,164 @@
+// Package rpc serves the car and engine services over gRPC, with a grpc-gateway
+// translating the same calls to JSON over HTTP.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/middleware"
+	"github.com/iangechuki/go_carzone/models"
+	carzonev1 "github.com/iangechuki/go_carzone/proto/carzone/v1"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var errInvalidEngineID = errors.New("engine_id must be a uuid")
+
+// methodScopes is what middleware.RequireScope checks on the matching REST routes.
+// Methods missing from it are refused.
+var methodScopes = map[string]string{
+	carzonev1.CarService_GetCar_FullMethodName: models.ScopeCarsRead,
+	carzonev1.CarService_ListCars_FullMethodName: models.ScopeCarsRead,
+	carzonev1.CarService_ListMyCars_FullMethodName: models.ScopeCarsRead,
+	carzonev1.CarService_CreateCar_FullMethodName: models.ScopeCarsWrite,
+	carzonev1.CarService_UpdateCar_FullMethodName: models.ScopeCarsWrite,
+	carzonev1.CarService_DeleteCar_FullMethodName: models.ScopeCarsWrite,
+	carzonev1.EngineService_GetEngine_FullMethodName: models.ScopeEnginesRead,
+	carzonev1.EngineService_ListEngines_FullMethodName: models.ScopeEnginesRead,
+	carzonev1.EngineService_CreateEngine_FullMethodName: models.ScopeEnginesWrite,
+	carzonev1.EngineService_UpdateEngine_FullMethodName: models.ScopeEnginesWrite,
+	carzonev1.EngineService_DeleteEngine_FullMethodName: models.ScopeEnginesWrite,
+}
+
+// NewServer registers the car and engine services behind the rate limit and
+// auth interceptors, traces go through the otel stats handler. The limiters
+// are the HTTP API's, so callers share one quota across both.
+func NewServer(carService service.CarServiceInterface,engineService service.EngineServiceInterface,apiKeys middleware.APIKeyAuthenticator,idp middleware.TokenVerifier,ipLimiter *middleware.RateLimiter,userLimiter *middleware.RateLimiter) *grpc.Server {
+	authn := &authenticator{apiKeys: apiKeys,idp: idp}
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(limitUnary(ipLimiter,callerIP),authn.unary,limitUnary(userLimiter,callerUserID)),
+		grpc.ChainStreamInterceptor(limitStream(ipLimiter,callerIP),authn.stream,limitStream(userLimiter,callerUserID)),
+	)
+	carzonev1.RegisterCarServiceServer(server,NewCarServer(carService))
+	carzonev1.RegisterEngineServiceServer(server,NewEngineServer(engineService))
+	return server
+}
+
+// NewGateway translates the HTTP bindings in inventory_http.yaml into calls on
+// the gRPC server at endpoint, passing the credential headers along as metadata.
+func NewGateway(ctx context.Context,endpoint string) (http.Handler,error) {
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(func(key string) (string,bool) {
+		switch http.CanonicalHeaderKey(key) {
+		case "X-Api-Key","X-Tenant-Id":
+			return key,true
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}))
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+	if err := carzonev1.RegisterCarServiceHandlerFromEndpoint(ctx,mux,endpoint,opts); err != nil {
+		return nil,err
+	}
+	if err := carzonev1.RegisterEngineServiceHandlerFromEndpoint(ctx,mux,endpoint,opts); err != nil {
+		return nil,err
+	}
+	return mux,nil
+}
+
+type authenticator struct {
+	apiKeys middleware.APIKeyAuthenticator
+	idp middleware.TokenVerifier
+}
+
+// authorize runs AuthMiddleware, TenantMiddleware and RequireScope on the call's metadata.
+func (a *authenticator)authorize(ctx context.Context,method string) (context.Context,error) {
+	scope,ok := methodScopes[method]
+	if !ok {
+		return nil,status.Error(codes.PermissionDenied,"method is not available")
+	}
+	md,_ := metadata.FromIncomingContext(ctx)
+	principal,err := middleware.Authenticate(ctx,a.apiKeys,a.idp,first(md,"x-api-key"),first(md,"authorization"))
+	if err != nil {
+		return nil,status.Error(codes.Unauthenticated,err.Error())
+	}
+	principal,err = middleware.ScopeTenant(principal,first(md,"x-tenant-id"))
+	if err != nil {
+		if errors.Is(err,middleware.ErrInvalidTenantHeader) {
+			return nil,status.Error(codes.InvalidArgument,err.Error())
+		}
+		return nil,status.Error(codes.PermissionDenied,err.Error())
+	}
+	if !principal.HasScope(scope) {
+		return nil,status.Error(codes.PermissionDenied,"Missing scope "+scope)
+	}
+	return auth.WithPrincipal(ctx,principal),nil
+}
+
+func (a *authenticator)unary(ctx context.Context,req interface{},info *grpc.UnaryServerInfo,handler grpc.UnaryHandler) (interface{},error) {
+	ctx,err := a.authorize(ctx,info.FullMethod)
+	if err != nil {
+		return nil,err
+	}
+	return handler(ctx,req)
+}
+
+func (a *authenticator)stream(srv interface{},ss grpc.ServerStream,info *grpc.StreamServerInfo,handler grpc.StreamHandler) error {
+	ctx,err := a.authorize(ss.Context(),info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv,&authenticatedStream{ServerStream: ss,ctx: ctx})
+}
+
+// authenticatedStream carries the principal to stream handlers.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream)Context() context.Context {
+	return s.ctx
+}
+
+func first(md metadata.MD,key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func statusFor(err error) error {
+	switch {
+	case errors.Is(err,models.ErrRecordNotFound):
+		return status.Error(codes.NotFound,err.Error())
+	case errors.Is(err,models.ErrForbidden):
+		return status.Error(codes.PermissionDenied,err.Error())
+	case errors.Is(err,models.ErrInvalidTransition),errors.Is(err,models.ErrTenantRequired),errors.Is(err,models.ErrEngineFuelMismatch):
+		return status.Error(codes.FailedPrecondition,err.Error())
+	case errors.Is(err,models.ErrStatusConflict):
+		return status.Error(codes.Aborted,err.Error())
+	case errors.Is(err,models.ErrVINTaken):
+		return status.Error(codes.AlreadyExists,err.Error())
+	case errors.Is(err,models.ErrInvalidPagination),errors.Is(err,models.ErrInvalidCarFilter),errors.Is(err,errInvalidEngineID),
+		errors.Is(err,models.ErrUnknownBrand),errors.Is(err,models.ErrUnknownModel),errors.Is(err,models.ErrInvalidEngine),
+		errors.Is(err,models.ErrInvalidVIN),errors.Is(err,models.ErrInvalidCarDetails),errors.Is(err,models.ErrEngineNotFound):
+		return status.Error(codes.InvalidArgument,err.Error())
+	default:
+		// unexpected errors can carry SQL and other internals, they stay in the log
+		log.Println("Error: ",err)
+		return status.Error(codes.Internal,"Internal server error")
+	}
+}
@@ -0,0 +1,238 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/handler/dto"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// comments on SSE, pings on websockets, so proxies don't close idle streams
+	keepAlive = 25 * time.Second
+	writeTimeout = 10 * time.Second
+	// how long an SSE client waits before reconnecting
+	sseRetry = 3 * time.Second
+)
+
+var upgrader = websocket.Upgrader{}
+
+type StreamHandler struct {
+	carStream service.CarStreamInterface
+	version dto.Version
+}
+
+// NewStreamHandler streams cars in the JSON of the given API version.
+func NewStreamHandler(carStream service.CarStreamInterface,version dto.Version) *StreamHandler {
+	return &StreamHandler{
+		carStream: carStream,
+		version: version,
+	}
+}
+
+// message is one car change on the wire, a reset tells the client it missed
+// too much to resume and should reload.
+type message struct {
+	ID string `json:"id,omitempty"`
+	Type string `json:"type"`
+	OccurredAt *time.Time `json:"occurred_at,omitempty"`
+	Car interface{} `json:"car,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+func (h *StreamHandler)toMessage(event *models.CarEvent) message {
+	return message{
+		ID: event.ID.String(),
+		Type: event.Type,
+		OccurredAt: &event.OccurredAt,
+		Car: h.version.Car(&event.Car),
+	}
+}
+
+// StreamCars pushes car changes over a websocket when the client asks for an
+// upgrade and as server sent events otherwise. ?brand= takes a comma separated
+// list, the dealership comes from the caller. Clients resume with the
+// Last-Event-ID header, or ?last_event_id= where they can't set headers.
+func (h *StreamHandler)StreamCars(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("StreamHandler")
+	ctx,span := tracer.Start(r.Context(), "StreamCars-Handler")
+
+	principal,ok := auth.PrincipalFromContext(ctx)
+	filter := models.CarEventFilter{
+		TenantID: auth.TenantID(ctx),
+		// staff see every car, anyone else what they could look up through /cars
+		Buyer: !ok || !principal.HasScope(models.ScopeCarsWrite),
+		UserID: auth.UserID(ctx),
+	}
+	if brand := r.URL.Query().Get("brand"); brand != "" {
+		filter.Brands = strings.Split(brand,",")
+	}
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	// subscribe before replaying so nothing falls between the two
+	events,cancel := h.carStream.Subscribe(filter)
+	defer cancel()
+
+	backlog := []message{}
+	seen := make(map[string]bool)
+	if lastEventID != "" {
+		replayed,err := h.carStream.Replay(ctx,lastEventID,filter)
+		switch {
+		case errors.Is(err,models.ErrResumeExpired):
+			backlog = append(backlog,message{Type: "reset",Error: err.Error()})
+		case err != nil:
+			span.End()
+			http.Error(w,err.Error(),http.StatusInternalServerError)
+			log.Println("Error: ",err)
+			return
+		}
+		for i := range replayed {
+			backlog = append(backlog,h.toMessage(&replayed[i]))
+			seen[replayed[i].ID.String()] = true
+		}
+	}
+	// the span covers setup, not the life of the connection
+	span.End()
+
+	live := make(chan message)
+	// a hijacked request's context isn't cancelled, done ends the goroutine either way
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(live)
+		for event := range events {
+			if seen[event.ID.String()] {
+				continue
+			}
+			select {
+			case live <- h.toMessage(&event):
+			case <-done:
+				return
+			}
+		}
+	}()
+	if websocket.IsWebSocketUpgrade(r) {
+		serveWebSocket(w,r,backlog,live)
+		return
+	}
+	serveSSE(w,r,backlog,live)
+}
+
+func serveSSE(w http.ResponseWriter,r *http.Request,backlog []message,live <-chan message) {
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type","text/event-stream")
+	w.Header().Set("Cache-Control","no-cache")
+	// stop nginx from buffering the stream
+	w.Header().Set("X-Accel-Buffering","no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w,"retry: %d\n\n",sseRetry.Milliseconds())
+	for _,msg := range backlog {
+		if err := writeEvent(w,msg); err != nil {
+			log.Println("Error writing messages ",err)
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		log.Println("Error: ",err)
+		return
+	}
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _,err := io.WriteString(w,": ping\n\n"); err != nil {
+				return
+			}
+		case msg,ok := <-live:
+			// dropped for falling behind, the client reconnects with Last-Event-ID
+			if !ok {
+				return
+			}
+			if err := writeEvent(w,msg); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w io.Writer,msg message) error {
+	data,err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if msg.ID != "" {
+		if _,err := fmt.Fprintf(w,"id: %s\n",msg.ID); err != nil {
+			return err
+		}
+	}
+	_,err = fmt.Fprintf(w,"event: %s\ndata: %s\n\n",msg.Type,data)
+	return err
+}
+
+func serveWebSocket(w http.ResponseWriter,r *http.Request,backlog []message,live <-chan message) {
+	conn,err := upgrader.Upgrade(w,r,nil)
+	if err != nil {
+		// Upgrade has already answered the client
+		log.Println("Error upgrading to websocket: ",err)
+		return
+	}
+	defer conn.Close()
+
+	// the stream is one way, reading only notices the client going away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _,_,err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+	for _,msg := range backlog {
+		conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := conn.WriteJSON(msg); err != nil {
+			log.Println("Error writing messages ",err)
+			return
+		}
+	}
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage,nil,time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		case msg,ok := <-live:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,websocket.FormatCloseMessage(websocket.CloseTryAgainLater,"fell behind, resume from the last event id"),time.Now().Add(writeTimeout))
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteJSON(msg); err != nil {
+				return
+			}
+		}
+	}
+}
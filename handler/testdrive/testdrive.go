@@ -0,0 +1,183 @@
+package testdrive
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/otel"
+)
+
+// bookings are listed a month ahead unless the caller asks for another window
+const defaultListWindow = 30 * 24 * time.Hour
+
+type TestDriveHandler struct {
+	testDriveService service.TestDriveServiceInterface
+}
+
+func NewTestDriveHandler(testDriveService service.TestDriveServiceInterface) *TestDriveHandler {
+	return &TestDriveHandler{
+		testDriveService: testDriveService,
+	}
+}
+
+func (h *TestDriveHandler)ListTestDrives(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("TestDriveHandler")
+	ctx,span := tracer.Start(r.Context(), "ListTestDrives-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	from := time.Now()
+	if v := r.URL.Query().Get("from"); v != "" {
+		t,err := time.Parse(time.RFC3339,v)
+		if err != nil {
+			http.Error(w,"from must be an RFC 3339 time",http.StatusBadRequest)
+			return
+		}
+		from = t
+	}
+	to := from.Add(defaultListWindow)
+	if v := r.URL.Query().Get("to"); v != "" {
+		t,err := time.Parse(time.RFC3339,v)
+		if err != nil {
+			http.Error(w,"to must be an RFC 3339 time",http.StatusBadRequest)
+			return
+		}
+		to = t
+	}
+	testDrives,err := h.testDriveService.ListTestDrives(ctx,id,from,to)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,testDrives)
+}
+
+func (h *TestDriveHandler)GetAvailability(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("TestDriveHandler")
+	ctx,span := tracer.Start(r.Context(), "GetAvailability-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	slots,err := h.testDriveService.GetAvailability(ctx,id,r.URL.Query().Get("date"))
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,slots)
+}
+
+func (h *TestDriveHandler)BookTestDrive(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("TestDriveHandler")
+	ctx,span := tracer.Start(r.Context(), "BookTestDrive-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	var testDriveReq models.TestDriveRequest
+	if err := json.NewDecoder(r.Body).Decode(&testDriveReq); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	testDrive,err := h.testDriveService.BookTestDrive(ctx,id,&testDriveReq)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error booking test drive: ",err)
+		return
+	}
+	writeJSON(w,http.StatusCreated,testDrive)
+}
+
+func (h *TestDriveHandler)CancelTestDrive(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("TestDriveHandler")
+	ctx,span := tracer.Start(r.Context(), "CancelTestDrive-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	testDrive,err := h.testDriveService.CancelTestDrive(ctx,vars["id"],vars["testDriveID"])
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error cancelling test drive: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,testDrive)
+}
+
+func (h *TestDriveHandler)GetSchedule(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("TestDriveHandler")
+	ctx,span := tracer.Start(r.Context(), "GetSchedule-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	schedule,err := h.testDriveService.GetSchedule(ctx,id)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,schedule)
+}
+
+func (h *TestDriveHandler)ReplaceSchedule(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("TestDriveHandler")
+	ctx,span := tracer.Start(r.Context(), "ReplaceSchedule-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	var schedule models.TestDriveSchedule
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	replaced,err := h.testDriveService.ReplaceSchedule(ctx,id,&schedule)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error replacing test drive schedule: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,replaced)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err,models.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err,models.ErrBookingConflict),errors.Is(err,models.ErrStatusConflict):
+		return http.StatusConflict
+	case errors.Is(err,models.ErrSlotUnavailable):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err,models.ErrInvalidTestDriveDate):
+		return http.StatusBadRequest
+	case errors.Is(err,models.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter,status int,v interface{}) {
+	body,err := json.Marshal(v)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	w.Header().Set("Content-Type","application/json")
+	w.WriteHeader(status)
+	if _,err := w.Write(body); err != nil {
+		log.Println("Error writing messages ",err)
+	}
+}
@@ -0,0 +1,61 @@
+package vin
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/otel"
+)
+
+type VINHandler struct {
+	vinService service.VINServiceInterface
+}
+
+func NewVINHandler(vinService service.VINServiceInterface) *VINHandler {
+	return &VINHandler{
+		vinService: vinService,
+	}
+}
+
+func (h *VINHandler)DecodeVIN(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("VINHandler")
+	ctx,span := tracer.Start(r.Context(), "DecodeVIN-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	info,err := h.vinService.DecodeVIN(ctx,vars["vin"])
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,info)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err,models.ErrInvalidVIN):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter,status int,v interface{}) {
+	body,err := json.Marshal(v)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	w.Header().Set("Content-Type","application/json")
+	w.WriteHeader(status)
+	if _,err := w.Write(body); err != nil {
+		log.Println("Error writing messages ",err)
+	}
+}
@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	"go.opentelemetry.io/otel"
+)
+
+type WebhookHandler struct {
+	webhookService service.WebhookServiceInterface
+}
+
+func NewWebhookHandler(webhookService service.WebhookServiceInterface) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+func (h *WebhookHandler)CreateWebhook(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("WebhookHandler")
+	ctx,span := tracer.Start(r.Context(), "CreateWebhook-Handler")
+	defer span.End()
+
+	var webhookReq models.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&webhookReq); err != nil {
+		http.Error(w,err.Error(),http.StatusBadRequest)
+		log.Println("Error decoding req: ",err)
+		return
+	}
+	webhook,err := h.webhookService.CreateWebhook(ctx,&webhookReq)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error creating webhook: ",err)
+		return
+	}
+	writeJSON(w,http.StatusCreated,webhook)
+}
+
+func (h *WebhookHandler)ListWebhooks(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("WebhookHandler")
+	ctx,span := tracer.Start(r.Context(), "ListWebhooks-Handler")
+	defer span.End()
+
+	webhooks,err := h.webhookService.ListWebhooks(ctx)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,webhooks)
+}
+
+func (h *WebhookHandler)DeleteWebhook(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("WebhookHandler")
+	ctx,span := tracer.Start(r.Context(), "DeleteWebhook-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	webhook,err := h.webhookService.DeleteWebhook(ctx,id)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error deleting webhook: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,webhook)
+}
+
+func (h *WebhookHandler)ListDeliveries(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("WebhookHandler")
+	ctx,span := tracer.Start(r.Context(), "ListDeliveries-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+	deliveries,err := h.webhookService.ListDeliveries(ctx,id)
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error: ",err)
+		return
+	}
+	writeJSON(w,http.StatusOK,deliveries)
+}
+
+func (h *WebhookHandler)ReplayDelivery(w http.ResponseWriter,r *http.Request){
+	tracer := otel.Tracer("WebhookHandler")
+	ctx,span := tracer.Start(r.Context(), "ReplayDelivery-Handler")
+	defer span.End()
+
+	vars := mux.Vars(r)
+	delivery,err := h.webhookService.ReplayDelivery(ctx,vars["id"],vars["deliveryID"])
+	if err != nil {
+		http.Error(w,err.Error(),statusFor(err))
+		log.Println("Error replaying webhook delivery: ",err)
+		return
+	}
+	writeJSON(w,http.StatusAccepted,delivery)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err,models.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err,models.ErrTenantRequired),errors.Is(err,models.ErrInvalidWebhookRequest):
+		return http.StatusBadRequest
+	case errors.Is(err,models.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter,status int,v interface{}) {
+	body,err := json.Marshal(v)
+	if err != nil {
+		http.Error(w,err.Error(),http.StatusInternalServerError)
+		log.Println("Error: ",err)
+		return
+	}
+	w.Header().Set("Content-Type","application/json")
+	w.WriteHeader(status)
+	if _,err := w.Write(body); err != nil {
+		log.Println("Error writing messages ",err)
+	}
+}
@@ -0,0 +1,135 @@
+// Package invoice renders order invoices as HTML or PDF.
+package invoice
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/iangechuki/go_carzone/models"
+)
+
+// line is one priced row of an invoice, shared by both renderers.
+type line struct {
+	Label string
+	Amount string
+}
+
+type view struct {
+	*models.Invoice
+	Lines []line
+	Subtotal string
+	TaxLabel string
+	Tax string
+	Total string
+	Paid string
+	Due string
+}
+
+func newView(inv *models.Invoice) view {
+	order := inv.Order
+	lines := []line{{Label: fmt.Sprintf("%s %s (%s)",inv.Car.Year,inv.Car.Name,inv.Car.Brand),Amount: money(order.AgreedPrice)}}
+	for _,fee := range order.Fees {
+		lines = append(lines,line{Label: fee.Name,Amount: money(fee.Amount)})
+	}
+	return view{
+		Invoice: inv,
+		Lines: lines,
+		Subtotal: money(order.AgreedPrice + order.FeesTotal),
+		TaxLabel: "Tax " + strconv.FormatFloat(order.TaxRate * 100,'f',-1,64) + "%",
+		Tax: money(order.Tax),
+		Total: money(order.Total),
+		Paid: money(order.AmountPaid),
+		Due: money(order.AmountDue()),
+	}
+}
+
+// money formats an amount with two decimals and thousands separators, 1234.5 is 1,234.50.
+func money(amount float64) string {
+	s := strconv.FormatFloat(amount,'f',2,64)
+	sign := ""
+	if strings.HasPrefix(s,"-") {
+		sign,s = "-",s[1:]
+	}
+	whole,cents,_ := strings.Cut(s,".")
+	var b strings.Builder
+	for i,r := range whole {
+		if i > 0 && (len(whole) - i) % 3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return sign + b.String() + "." + cents
+}
+
+var htmlTemplate = template.Must(template.New("invoice").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Invoice {{.Order.InvoiceNumber}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; margin: 40px; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-top: 24px; }
+td, th { padding: 6px 8px; border-bottom: 1px solid #ddd; text-align: left; }
+td.amount, th.amount { text-align: right; }
+tr.total td { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{.Dealership.Name}}</h1>
+<p>Invoice <strong>{{.Order.InvoiceNumber}}</strong><br>
+Issued {{.IssuedAt.Format "2 January 2006"}}<br>
+Order {{.Order.ID}}</p>
+<p>Billed to<br>{{.Order.BuyerName}}<br>{{.Order.BuyerEmail}}</p>
+<table>
+<tr><th>Item</th><th class="amount">Amount</th></tr>
+{{range .Lines}}<tr><td>{{.Label}}</td><td class="amount">{{.Amount}}</td></tr>
+{{end}}<tr><td>Subtotal</td><td class="amount">{{.Subtotal}}</td></tr>
+<tr><td>{{.TaxLabel}}</td><td class="amount">{{.Tax}}</td></tr>
+<tr class="total"><td>Total</td><td class="amount">{{.Total}}</td></tr>
+<tr><td>Paid</td><td class="amount">{{.Paid}}</td></tr>
+<tr class="total"><td>Amount due</td><td class="amount">{{.Due}}</td></tr>
+</table>
+<p>Payment status: {{.Order.PaymentStatus}}</p>
+</body>
+</html>
+`))
+
+func RenderHTML(w io.Writer,inv *models.Invoice) error {
+	return htmlTemplate.Execute(w,newView(inv))
+}
+
+// RenderPDF writes a single page A4 invoice.
+func RenderPDF(w io.Writer,inv *models.Invoice) error {
+	v := newView(inv)
+	page := newPDFPage()
+	page.text(50,790,18,inv.Dealership.Name)
+	page.text(50,760,11,"Invoice "+inv.Order.InvoiceNumber)
+	page.text(50,745,11,"Issued "+inv.IssuedAt.Format("2 January 2006"))
+	page.text(50,730,11,"Order "+inv.Order.ID.String())
+	page.text(50,700,11,"Billed to")
+	page.text(50,685,11,inv.Order.BuyerName)
+	page.text(50,670,11,inv.Order.BuyerEmail)
+
+	y := 630.0
+	row := func(label string,amount string,size float64) {
+		page.text(50,y,size,label)
+		page.textRight(545,y,size,amount)
+		y -= 18
+	}
+	row("Item","Amount",11)
+	page.rule(50,y+12,545)
+	for _,l := range v.Lines {
+		row(l.Label,l.Amount,11)
+	}
+	page.rule(50,y+12,545)
+	row("Subtotal",v.Subtotal,11)
+	row(v.TaxLabel,v.Tax,11)
+	row("Total",v.Total,13)
+	row("Paid",v.Paid,11)
+	row("Amount due",v.Due,13)
+	page.text(50,y-10,11,"Payment status: "+inv.Order.PaymentStatus)
+	return page.write(w)
+}
@@ -0,0 +1,77 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// pdfPage is just enough PDF to lay out text and rules on one A4 page with the
+// built in Helvetica font, which saves pulling in a PDF library for invoices.
+type pdfPage struct {
+	content bytes.Buffer
+}
+
+func newPDFPage() *pdfPage {
+	return &pdfPage{}
+}
+
+func (p *pdfPage) text(x,y,size float64,s string) {
+	fmt.Fprintf(&p.content,"BT /F1 %.1f Tf %.2f %.2f Td (%s) Tj ET\n",size,x,y,pdfString(s))
+}
+
+// textRight right aligns s at x, widths are approximated since Helvetica metrics are not embedded.
+func (p *pdfPage) textRight(x,y,size float64,s string) {
+	p.text(x - float64(len(s)) * size * 0.556,y,size,s)
+}
+
+func (p *pdfPage) rule(x1,y,x2 float64) {
+	fmt.Fprintf(&p.content,"0.5 w %.2f %.2f m %.2f %.2f l S\n",x1,y,x2,y)
+}
+
+func (p *pdfPage) write(w io.Writer) error {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream",p.content.Len(),p.content.String()),
+	}
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int,len(objects))
+	for i,obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf,"%d 0 obj\n%s\nendobj\n",i+1,obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf,"xref\n0 %d\n0000000000 65535 f \n",len(objects)+1)
+	for _,offset := range offsets {
+		fmt.Fprintf(&buf,"%010d 00000 n \n",offset)
+	}
+	fmt.Fprintf(&buf,"trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n",len(objects)+1,xref)
+	_,err := w.Write(buf.Bytes())
+	return err
+}
+
+// pdfString escapes s for a literal string, characters outside Latin-1 become '?'.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _,r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 128:
+			b.WriteRune(r)
+		case r < 256:
+			fmt.Fprintf(&b,"\\%03o",r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
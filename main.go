@@ -2,25 +2,69 @@ package main
 
 import (
 	"context"
-	"database/sql"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/iangechuki/go_carzone/broker"
 	"github.com/iangechuki/go_carzone/driver"
+	apiKeyHandler "github.com/iangechuki/go_carzone/handler/apikey"
 	carHandler "github.com/iangechuki/go_carzone/handler/car"
+	catalogueHandler "github.com/iangechuki/go_carzone/handler/catalogue"
+	dealershipHandler "github.com/iangechuki/go_carzone/handler/dealership"
 	engineHandler "github.com/iangechuki/go_carzone/handler/engine"
+	dtoV1 "github.com/iangechuki/go_carzone/handler/dto/v1"
+	dtoV2 "github.com/iangechuki/go_carzone/handler/dto/v2"
+	"github.com/iangechuki/go_carzone/handler/dto"
+	"github.com/iangechuki/go_carzone/handler/graph"
+	"github.com/iangechuki/go_carzone/handler/rpc"
 	loginHandler "github.com/iangechuki/go_carzone/handler/login"
+	oidcHandler "github.com/iangechuki/go_carzone/handler/oidc"
+	orderHandler "github.com/iangechuki/go_carzone/handler/order"
+	streamHandler "github.com/iangechuki/go_carzone/handler/stream"
+	testDriveHandler "github.com/iangechuki/go_carzone/handler/testdrive"
+	vinHandler "github.com/iangechuki/go_carzone/handler/vin"
+	webhookHandler "github.com/iangechuki/go_carzone/handler/webhook"
 	"github.com/iangechuki/go_carzone/middleware"
+	"github.com/iangechuki/go_carzone/openapi"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	apiKeyService "github.com/iangechuki/go_carzone/service/apikey"
 	carService "github.com/iangechuki/go_carzone/service/car"
+	catalogueService "github.com/iangechuki/go_carzone/service/catalogue"
+	dealershipService "github.com/iangechuki/go_carzone/service/dealership"
 	engineService "github.com/iangechuki/go_carzone/service/engine"
+	oidcService "github.com/iangechuki/go_carzone/service/oidc"
+	outboxService "github.com/iangechuki/go_carzone/service/outbox"
+	orderService "github.com/iangechuki/go_carzone/service/order"
+	streamService "github.com/iangechuki/go_carzone/service/stream"
+	testDriveService "github.com/iangechuki/go_carzone/service/testdrive"
+	userService "github.com/iangechuki/go_carzone/service/user"
+	vinService "github.com/iangechuki/go_carzone/service/vin"
+	webhookService "github.com/iangechuki/go_carzone/service/webhook"
+	apiKeyStore "github.com/iangechuki/go_carzone/store/apikey"
+	storeCache "github.com/iangechuki/go_carzone/store/cache"
 	carStore "github.com/iangechuki/go_carzone/store/car"
+	catalogueStore "github.com/iangechuki/go_carzone/store/catalogue"
+	dealershipStore "github.com/iangechuki/go_carzone/store/dealership"
 	engineStore "github.com/iangechuki/go_carzone/store/engine"
+	idempotencyStore "github.com/iangechuki/go_carzone/store/idempotency"
+	orderStore "github.com/iangechuki/go_carzone/store/order"
+	outboxStore "github.com/iangechuki/go_carzone/store/outbox"
+	"github.com/iangechuki/go_carzone/store/schema"
+	testDriveStore "github.com/iangechuki/go_carzone/store/testdrive"
+	userStore "github.com/iangechuki/go_carzone/store/user"
+	webhookStore "github.com/iangechuki/go_carzone/store/webhook"
 	"github.com/joho/godotenv"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 	otelmux "go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
@@ -29,14 +73,57 @@ import (
 	"go.opentelemetry.io/otel/sdk/trace"
 
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+
+	// test drive schedules are in dealership time zones, the alpine image ships no zoneinfo
+	_ "time/tzdata"
 )
 
 
+const usage = `Usage: main <command> [flags]
+
+Commands:
+  serve        serve the HTTP, gRPC and GraphQL APIs, the default
+  migrate      apply pending database migrations
+  seed         load fixture data into a migrated database
+  create-user  add a user who can log in through /login
+  healthcheck  exit non-zero unless a running server answers /health
+
+Run main <command> -h for the flags of a command.
+`
+
 func main(){
 	err := godotenv.Load()
 	if err != nil {
 		log.Fatal("Error loading .env file: ",err)
 	}
+	command,args := "serve",os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0],"-") {
+		command,args = args[0],args[1:]
+	}
+	switch command {
+	case "serve":
+		serve(args)
+	case "migrate":
+		migrate(args)
+	case "seed":
+		seed(args)
+	case "create-user":
+		createUser(args)
+	case "healthcheck":
+		healthcheck(args)
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr,"Unknown command %q\n\n%s",command,usage)
+		os.Exit(2)
+	}
+}
+
+func serve(args []string){
+	flags := flag.NewFlagSet("serve",flag.ExitOnError)
+	migrateFirst := flags.Bool("migrate",os.Getenv("MIGRATE_ON_START") == "true","apply pending migrations before serving")
+	flags.Parse(args)
+
 	traceProvider,err := startTracing()
 	if err != nil {
 		log.Fatal("Error starting tracing: ",err)
@@ -52,22 +139,98 @@ func main(){
 	driver.InitDB()
 	defer driver.CloseDB()
 	db := driver.GetDB()
-	carStore := carStore.New(db)
-	carService := carService.NewCarService(carStore)
-	carHandler := carHandler.NewCarHandler(carService)
+	webhookInterval,err := time.ParseDuration(getEnv("WEBHOOK_POLL_INTERVAL","5s"))
+	if err != nil {
+		log.Fatal("Error parsing webhook poll interval: ",err)
+	}
+	webhookStore := webhookStore.New(db)
+	webhookDispatcher := webhookService.NewDispatcher(webhookStore,webhookInterval)
+	webhookService := webhookService.NewWebhookService(webhookStore)
+	webhookHandler := webhookHandler.NewWebhookHandler(webhookService)
+
+	carCacheTTL,err := time.ParseDuration(getEnv("CACHE_CAR_TTL","30s"))
+	if err != nil {
+		log.Fatal("Error parsing car cache ttl: ",err)
+	}
+	engineCacheTTL,err := time.ParseDuration(getEnv("CACHE_ENGINE_TTL","5m"))
+	if err != nil {
+		log.Fatal("Error parsing engine cache ttl: ",err)
+	}
+	cache := storeCache.New(newCacheBackend())
+	engineStore := storeCache.NewEngineStore(engineStore.New(db),cache,engineCacheTTL)
+	carStore := storeCache.NewCarStore(carStore.New(db),engineStore,cache,carCacheTTL)
+	vinProvider,ok,err := vinService.ProviderFromEnv()
+	if err != nil {
+		log.Fatal("Error reading vin decoder config: ",err)
+	}
+	if ok {
+		log.Printf("VIN lookups through %s",vinProvider.Name())
+	}
+	vinService := vinService.NewVINService(vinProvider)
+	vinHandler := vinHandler.NewVINHandler(vinService)
+	catalogueStore := catalogueStore.New(db)
+	catalogueService := catalogueService.NewCatalogueService(catalogueStore)
+	catalogueHandler := catalogueHandler.NewCatalogueHandler(catalogueService)
+	carService := carService.NewCarService(carStore).WithVINDecoder(vinService).WithCatalogue(catalogueService)
 
-	engineStore := engineStore.New(db)
 	engineService := engineService.NewEngineService(engineStore)
-	engineHandler := engineHandler.NewEngineHandler(engineService)
+
+	apiKeyStore := apiKeyStore.New(db)
+	apiKeyService := apiKeyService.NewAPIKeyService(apiKeyStore)
+	apiKeyHandler := apiKeyHandler.NewAPIKeyHandler(apiKeyService)
+
+	dealershipStore := dealershipStore.New(db)
+	dealershipService := dealershipService.NewDealershipService(dealershipStore)
+	dealershipHandler := dealershipHandler.NewDealershipHandler(dealershipService)
+
+	testDriveStore := testDriveStore.New(db)
+	testDriveService := testDriveService.NewTestDriveService(testDriveStore,carStore)
+	testDriveHandler := testDriveHandler.NewTestDriveHandler(testDriveService)
+
+	pricing,err := orderService.PricingFromEnv()
+	if err != nil {
+		log.Fatal("Error reading order pricing: ",err)
+	}
+	orderStore := orderStore.New(db)
+	orderService := orderService.NewOrderService(orderStore,carStore,dealershipStore,pricing)
+	orderHandler := orderHandler.NewOrderHandler(orderService)
 
 	router := mux.NewRouter()
 
 	router.Use(otelmux.Middleware("CarZone"))
-	
-	schemaFile := "store/schema.sql"
 
-	if err := executeSchemaFile(db,schemaFile); err != nil {
-		log.Fatal("Error executing schema file: ",err)
+	rateLimitStore := newRateLimitStore()
+	routeLimits,err := middleware.ParseRouteLimits(getEnv("RATE_LIMIT_ROUTES","POST /login=5/1m"))
+	if err != nil {
+		log.Fatal("Error parsing route rate limits: ",err)
+	}
+	ipLimit,err := middleware.ParseLimit(getEnv("RATE_LIMIT_IP","100/1m"))
+	if err != nil {
+		log.Fatal("Error parsing ip rate limit: ",err)
+	}
+	userLimit,err := middleware.ParseLimit(getEnv("RATE_LIMIT_USER","60/1m"))
+	if err != nil {
+		log.Fatal("Error parsing user rate limit: ",err)
+	}
+	loginLimit,err := middleware.ParseLimit(getEnv("RATE_LIMIT_LOGIN_USERNAME","10/15m"))
+	if err != nil {
+		log.Fatal("Error parsing login rate limit: ",err)
+	}
+	ipLimiter := middleware.NewRateLimiter("ip",rateLimitStore,middleware.ClientIP,ipLimit).WithRoutes(routeLimits)
+	// per address limits alone let a spread out attacker keep guessing one account's password
+	loginLimiter := middleware.NewRateLimiter("login",rateLimitStore,middleware.LoginUsername,loginLimit)
+	userLimiter := middleware.NewRateLimiter("user",rateLimitStore,middleware.ClientUserID,userLimit).WithRoutes(routeLimits)
+	router.Use(ipLimiter.Middleware)
+
+	if *migrateFirst {
+		migrateDB(db)
+	}
+	pending,err := schema.Pending(context.Background(),db)
+	if err != nil {
+		log.Fatal("Error checking migrations: ",err)
+	}
+	if len(pending) > 0 {
+		log.Fatalf("Database is missing migrations %s, run main migrate or serve -migrate",strings.Join(pending,", "))
 	}
 	router.HandleFunc("/health",func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -78,23 +241,144 @@ func main(){
 			return
 		}
 	}).Methods("GET")
-	router.HandleFunc("/login",loginHandler.LoginHandler).Methods("POST")
+	apiDoc,err := openapi.Load()
+	if err != nil {
+		log.Fatal("Error loading openapi document: ",err)
+	}
+	apiDocHandler,err := openapi.Handler(apiDoc)
+	if err != nil {
+		log.Fatal("Error serving openapi document: ",err)
+	}
+	router.Handle("/openapi.json",apiDocHandler).Methods("GET")
+	router.Handle("/docs",openapi.SwaggerUI("/openapi.json")).Methods("GET")
+	apiDocV2,err := openapi.LoadV2()
+	if err != nil {
+		log.Fatal("Error loading v2 openapi document: ",err)
+	}
+	apiDocV2Handler,err := openapi.Handler(apiDocV2)
+	if err != nil {
+		log.Fatal("Error serving v2 openapi document: ",err)
+	}
+	router.Handle("/openapi/v2.json",apiDocV2Handler).Methods("GET")
+	router.Handle("/docs/v2",openapi.SwaggerUI("/openapi/v2.json")).Methods("GET")
+	validateResponses := os.Getenv("OPENAPI_VALIDATE_RESPONSES") == "true"
+	apiValidator,err := middleware.NewOpenAPIValidator(apiDoc)
+	if err != nil {
+		log.Fatal("Error building openapi validator: ",err)
+	}
+	apiValidator.WithResponseValidation(validateResponses)
+	// /v2 speaks its own JSON, so it is checked against its own document
+	apiValidatorV2,err := middleware.NewOpenAPIValidator(apiDocV2)
+	if err != nil {
+		log.Fatal("Error building v2 openapi validator: ",err)
+	}
+	apiValidatorV2.WithResponseValidation(validateResponses)
+
+	if os.Getenv("DISABLE_LOCAL_LOGIN") != "true" {
+		loginHandler := loginHandler.NewLoginHandler(userService.NewUserService(userStore.New(db)))
+		router.Handle("/login",loginLimiter.Middleware(apiValidator.Middleware(http.HandlerFunc(loginHandler.Login)))).Methods("POST")
+	}
+	idpVerifier := setupOIDC(router)
 	
 	protected := router.PathPrefix("/").Subrouter()
 
-	protected.Use(middleware.AuthMiddleware)
-	protected.HandleFunc("/cars/{id}",carHandler.GetCarByID).Methods("GET")
-	protected.HandleFunc("/cars",carHandler.GetCarByBrand).Methods("GET")
-	protected.HandleFunc("/cars",carHandler.CreateCar).Methods("POST")
-	protected.HandleFunc("/cars/{id}",carHandler.UpdateCar).Methods("PUT")
-	protected.HandleFunc("/cars/{id}",carHandler.DeleteCar).Methods("DELETE")
-
-	protected.HandleFunc("/engines/{id}",engineHandler.GetEngineByID).Methods("GET")
-	protected.HandleFunc("/engines",engineHandler.CreateEngine).Methods("POST")
-	protected.HandleFunc("/engines/{id}",engineHandler.UpdateEngine).Methods("PUT")
-	protected.HandleFunc("/engines/{id}",engineHandler.DeleteEngine).Methods("DELETE")
+	protected.Use(middleware.AuthMiddleware(apiKeyService,idpVerifier))
+	protected.Use(middleware.TenantMiddleware)
+	protected.Use(userLimiter.Middleware)
+	protected.Use(apiValidator.Middleware)
+	protected.Use(apiValidatorV2.Middleware)
+
+	idempotencyTTL,err := time.ParseDuration(getEnv("IDEMPOTENCY_TTL","24h"))
+	if err != nil {
+		log.Fatal("Error parsing idempotency ttl: ",err)
+	}
+	idempotency := middleware.NewIdempotency(idempotencyStore.New(db),idempotencyTTL)
+	go idempotency.Run(context.Background())
+
+	cachePolicies,err := middleware.ParseCachePolicies(os.Getenv("CACHE_CONTROL_ROUTES"))
+	if err != nil {
+		log.Fatal("Error parsing cache control policies: ",err)
+	}
+	httpCache := middleware.NewHTTPCache(getEnv("CACHE_CONTROL_DEFAULT","private, no-cache")).WithRoutes(cachePolicies)
+	carStream := streamService.NewHub(outboxStore.NewEventLog(db,driver.ConnString()))
+	go carStream.Run(context.Background())
+
+	routes := apiRoutes{
+		testDrive: testDriveHandler,
+		order: orderHandler,
+		webhook: webhookHandler,
+		apiKey: apiKeyHandler,
+		dealership: dealershipHandler,
+		vin: vinHandler,
+		catalogue: catalogueHandler,
+		carStream: carStream,
+		idempotency: idempotency,
+		httpCache: httpCache,
+	}
+	// v1 stays undeprecated until a date is configured
+	var deprecatedAt time.Time
+	if value := os.Getenv("API_V1_DEPRECATED_AT"); value != "" {
+		deprecatedAt,err = time.Parse(time.RFC3339,value)
+		if err != nil {
+			log.Fatal("Error parsing v1 deprecation date: ",err)
+		}
+	}
+	var sunset time.Time
+	if value := os.Getenv("API_V1_SUNSET"); value != "" {
+		sunset,err = time.Parse(time.RFC3339,value)
+		if err != nil {
+			log.Fatal("Error parsing v1 sunset date: ",err)
+		}
+	}
+	apiV1 := protected.PathPrefix("/v1").Subrouter()
+	apiV1.Use(middleware.NewDeprecation(deprecatedAt,sunset).WithSuccessor("/v1","/v2").Middleware)
+	routes.register(apiV1,dtoV1.Version{},carService,engineService)
+	apiV2 := protected.PathPrefix("/v2").Subrouter()
+	routes.register(apiV2,dtoV2.Version{},carService,engineService)
+	// the unversioned routes predate /v1 and serve its JSON until the v1 sunset
+	unversioned := protected.NewRoute().Subrouter()
+	unversioned.Use(middleware.NewDeprecation(deprecatedAt,sunset).WithSuccessor("","/v2").Middleware)
+	routes.register(unversioned,dtoV1.Version{},carService,engineService)
 	
+	complexityLimit,err := strconv.Atoi(getEnv("GRAPHQL_COMPLEXITY_LIMIT","2000"))
+	if err != nil {
+		log.Fatal("Error parsing graphql complexity limit: ",err)
+	}
+	protected.Handle("/graphql",graph.NewHandler(graph.NewResolver(carService,engineService),complexityLimit)).Methods("GET","POST")
+
+	grpcAddr := fmt.Sprintf(":%s",getEnv("GRPC_PORT","9090"))
+	grpcListener,err := net.Listen("tcp",grpcAddr)
+	if err != nil {
+		log.Fatal("Error listening for grpc: ",err)
+	}
+	grpcServer := rpc.NewServer(carService,engineService,apiKeyService,idpVerifier,ipLimiter,userLimiter)
+	go func() {
+		log.Printf("Serving grpc on %s",grpcAddr)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatal("Error serving grpc: ",err)
+		}
+	}()
+	gateway,err := rpc.NewGateway(context.Background(),"localhost"+grpcAddr)
+	if err != nil {
+		log.Fatal("Error starting grpc gateway: ",err)
+	}
+	router.PathPrefix("/gateway/").Handler(http.StripPrefix("/gateway",gateway))
+
 	router.Handle("/metrics",promhttp.Handler())
+
+	eventBroker,err := broker.FromEnv()
+	if err != nil {
+		log.Fatal("Error connecting to broker: ",err)
+	}
+	defer eventBroker.Close()
+	outboxInterval,err := time.ParseDuration(getEnv("OUTBOX_POLL_INTERVAL","1s"))
+	if err != nil {
+		log.Fatal("Error parsing outbox poll interval: ",err)
+	}
+	relay := outboxService.NewRelay(outboxStore.New(db),eventBroker,outboxInterval,webhookService,cache)
+	go relay.Run(context.Background())
+	go webhookDispatcher.Run(context.Background())
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
@@ -103,18 +387,130 @@ func main(){
 	log.Printf("Listening on %s",addr)
 	log.Fatal(http.ListenAndServe(addr,router))
 }
-func executeSchemaFile(db *sql.DB,fileName string)error{
-	sqlFile,err := os.ReadFile(fileName)
+// apiRoutes holds the handlers that are the same in every API version.
+type apiRoutes struct {
+	testDrive *testDriveHandler.TestDriveHandler
+	order *orderHandler.OrderHandler
+	webhook *webhookHandler.WebhookHandler
+	apiKey *apiKeyHandler.APIKeyHandler
+	dealership *dealershipHandler.DealershipHandler
+	vin *vinHandler.VINHandler
+	catalogue *catalogueHandler.CatalogueHandler
+	carStream service.CarStreamInterface
+	idempotency *middleware.Idempotency
+	httpCache *middleware.HTTPCache
+}
+// register mounts the REST API on r, with cars and engines in the JSON of version.
+func (h apiRoutes)register(r *mux.Router,version dto.Version,carService service.CarServiceInterface,engineService service.EngineServiceInterface) {
+	cars := carHandler.NewCarHandler(carService,version)
+	engines := engineHandler.NewEngineHandler(engineService,version)
+	streams := streamHandler.NewStreamHandler(h.carStream,version)
+	// ahead of /cars/{id}, which would take "stream" for an id
+	r.Handle("/cars/stream",middleware.RequireScope(models.ScopeCarsRead,streams.StreamCars)).Methods("GET")
+	r.Handle("/cars/{id}",h.httpCache.Wrap(middleware.RequireScope(models.ScopeCarsRead,cars.GetCarByID))).Methods("GET")
+	r.Handle("/cars",h.httpCache.Wrap(middleware.RequireScope(models.ScopeCarsRead,cars.GetCarByBrand))).Methods("GET")
+	r.Handle("/cars",middleware.RequireScope(models.ScopeCarsWrite,h.idempotency.Wrap(cars.CreateCar))).Methods("POST")
+	r.Handle("/cars/{id}",middleware.RequireScope(models.ScopeCarsWrite,cars.UpdateCar)).Methods("PUT")
+	r.Handle("/cars/{id}",middleware.RequireScope(models.ScopeCarsWrite,cars.DeleteCar)).Methods("DELETE")
+	r.Handle("/cars/{id}/status",middleware.RequireScope(models.ScopeCarsWrite,cars.ChangeCarStatus)).Methods("PUT")
+	r.Handle("/cars/{id}/reservation",middleware.RequireScope(models.ScopeCarsReserve,cars.ReserveCar)).Methods("POST")
+	r.Handle("/cars/{id}/reservation",middleware.RequireScope(models.ScopeCarsReserve,cars.CancelReservation)).Methods("DELETE")
+	r.Handle("/cars/{id}/sell",middleware.RequireScope(models.ScopeCarsWrite,cars.MarkCarSold)).Methods("POST")
+	r.Handle("/cars/{id}/test-drives",middleware.RequireScope(models.ScopeCarsRead,h.testDrive.ListTestDrives)).Methods("GET")
+	r.Handle("/cars/{id}/test-drives",middleware.RequireScope(models.ScopeCarsReserve,h.testDrive.BookTestDrive)).Methods("POST")
+	r.Handle("/cars/{id}/test-drives/availability",middleware.RequireScope(models.ScopeCarsRead,h.testDrive.GetAvailability)).Methods("GET")
+	r.Handle("/cars/{id}/test-drives/{testDriveID}",middleware.RequireScope(models.ScopeCarsReserve,h.testDrive.CancelTestDrive)).Methods("DELETE")
+	r.Handle("/me/cars",h.httpCache.Wrap(middleware.RequireScope(models.ScopeCarsRead,cars.GetMyCars))).Methods("GET")
+	r.Handle("/vin/{vin}",middleware.RequireScope(models.ScopeCarsWrite,h.vin.DecodeVIN)).Methods("GET")
+
+	r.Handle("/brands",middleware.RequireScope(models.ScopeCarsRead,h.catalogue.ListBrands)).Methods("GET")
+	r.Handle("/brands",middleware.RequireScope(models.ScopeCatalogueManage,h.catalogue.CreateBrand)).Methods("POST")
+	r.Handle("/brands/{id}",middleware.RequireScope(models.ScopeCarsRead,h.catalogue.GetBrandByID)).Methods("GET")
+	r.Handle("/brands/{id}",middleware.RequireScope(models.ScopeCatalogueManage,h.catalogue.UpdateBrand)).Methods("PUT")
+	r.Handle("/brands/{id}",middleware.RequireScope(models.ScopeCatalogueManage,h.catalogue.DeleteBrand)).Methods("DELETE")
+	r.Handle("/brands/{id}/models",middleware.RequireScope(models.ScopeCatalogueManage,h.catalogue.CreateModel)).Methods("POST")
+	r.Handle("/brands/{id}/models/{modelID}",middleware.RequireScope(models.ScopeCatalogueManage,h.catalogue.UpdateModel)).Methods("PUT")
+	r.Handle("/brands/{id}/models/{modelID}",middleware.RequireScope(models.ScopeCatalogueManage,h.catalogue.DeleteModel)).Methods("DELETE")
+
+	r.Handle("/engines",middleware.RequireScope(models.ScopeEnginesRead,engines.ListEngines)).Methods("GET")
+	r.Handle("/engines/{id}",h.httpCache.Wrap(middleware.RequireScope(models.ScopeEnginesRead,engines.GetEngineByID))).Methods("GET")
+	r.Handle("/engines",middleware.RequireScope(models.ScopeEnginesWrite,h.idempotency.Wrap(engines.CreateEngine))).Methods("POST")
+	r.Handle("/engines/{id}",middleware.RequireScope(models.ScopeEnginesWrite,engines.UpdateEngine)).Methods("PUT")
+	r.Handle("/engines/{id}",middleware.RequireScope(models.ScopeEnginesWrite,engines.DeleteEngine)).Methods("DELETE")
+
+	r.Handle("/orders",middleware.RequireScope(models.ScopeOrdersRead,h.order.ListOrders)).Methods("GET")
+	r.Handle("/orders",middleware.RequireScope(models.ScopeOrdersWrite,h.order.CreateOrder)).Methods("POST")
+	r.Handle("/orders/{id}",middleware.RequireScope(models.ScopeOrdersRead,h.order.GetOrderByID)).Methods("GET")
+	r.Handle("/orders/{id}/invoice",middleware.RequireScope(models.ScopeOrdersRead,h.order.GetInvoice)).Methods("GET")
+	r.Handle("/orders/{id}/payments",middleware.RequireScope(models.ScopeOrdersWrite,h.order.RecordPayment)).Methods("POST")
+	r.Handle("/orders/{id}/cancel",middleware.RequireScope(models.ScopeOrdersWrite,h.order.CancelOrder)).Methods("POST")
+	r.Handle("/orders/{id}/refund",middleware.RequireScope(models.ScopeOrdersWrite,h.order.RefundOrder)).Methods("POST")
+
+	r.Handle("/webhooks",middleware.RequireScope(models.ScopeWebhooksManage,h.webhook.CreateWebhook)).Methods("POST")
+	r.Handle("/webhooks",middleware.RequireScope(models.ScopeWebhooksManage,h.webhook.ListWebhooks)).Methods("GET")
+	r.Handle("/webhooks/{id}",middleware.RequireScope(models.ScopeWebhooksManage,h.webhook.DeleteWebhook)).Methods("DELETE")
+	r.Handle("/webhooks/{id}/deliveries",middleware.RequireScope(models.ScopeWebhooksManage,h.webhook.ListDeliveries)).Methods("GET")
+	r.Handle("/webhooks/{id}/deliveries/{deliveryID}/replay",middleware.RequireScope(models.ScopeWebhooksManage,h.webhook.ReplayDelivery)).Methods("POST")
+
+	r.Handle("/api-keys",middleware.RequireScope(models.ScopeAPIKeysManage,h.apiKey.CreateAPIKey)).Methods("POST")
+	r.Handle("/api-keys",middleware.RequireScope(models.ScopeAPIKeysManage,h.apiKey.ListAPIKeys)).Methods("GET")
+	r.Handle("/api-keys/{id}",middleware.RequireScope(models.ScopeAPIKeysManage,h.apiKey.RevokeAPIKey)).Methods("DELETE")
+
+	r.Handle("/dealerships",middleware.RequireScope(models.ScopeDealershipsManage,h.dealership.ListDealerships)).Methods("GET")
+	r.Handle("/dealerships",middleware.RequireScope(models.ScopeDealershipsManage,h.dealership.CreateDealership)).Methods("POST")
+	r.Handle("/dealerships/{id}",middleware.RequireScope(models.ScopeDealershipsManage,h.dealership.GetDealershipByID)).Methods("GET")
+	r.Handle("/dealerships/{id}",middleware.RequireScope(models.ScopeDealershipsManage,h.dealership.UpdateDealership)).Methods("PUT")
+	r.Handle("/dealerships/{id}",middleware.RequireScope(models.ScopeDealershipsManage,h.dealership.DeleteDealership)).Methods("DELETE")
+	r.Handle("/dealerships/{id}/test-drive-schedule",middleware.RequireScope(models.ScopeDealershipsManage,h.testDrive.GetSchedule)).Methods("GET")
+	r.Handle("/dealerships/{id}/test-drive-schedule",middleware.RequireScope(models.ScopeDealershipsManage,h.testDrive.ReplaceSchedule)).Methods("PUT")
+}
+// setupOIDC registers the OIDC login routes when OIDC_ISSUER_URL is set and returns the
+// verifier for identity provider tokens, nil when OIDC is disabled.
+func setupOIDC(router *mux.Router) middleware.TokenVerifier {
+	config,ok,err := oidcService.ConfigFromEnv()
 	if err != nil {
-		log.Fatal("Error reading schema file: ",err)
+		log.Fatal("Error reading oidc config: ",err)
 	}
-	_,err = db.Exec(string(sqlFile))
+	if !ok {
+		return nil
+	}
+	oidcService,err := oidcService.NewOIDCService(context.Background(),config)
 	if err != nil {
-		log.Fatal("Error executing schema file: ",err)
+		log.Fatal("Error starting oidc: ",err)
 	}
-	return nil
+	oidcHandler := oidcHandler.NewOIDCHandler(oidcService,strings.HasPrefix(config.RedirectURL,"https://"))
+	router.HandleFunc("/auth/oidc/login",oidcHandler.Login).Methods("GET")
+	router.HandleFunc("/auth/oidc/callback",oidcHandler.Callback).Methods("GET")
+	log.Printf("OIDC login enabled for issuer %s",config.IssuerURL)
+	return oidcService
+}
+func getEnv(key,fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+func newRateLimitStore() middleware.RateLimitStore {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		return middleware.NewMemoryRateLimitStore()
+	}
+	log.Printf("Using redis rate limit store at %s",addr)
+	return middleware.NewRedisRateLimitStore(redis.NewClient(&redis.Options{Addr: addr}))
+}
+// newCacheBackend shares the store cache through redis when REDIS_ADDR is set.
+func newCacheBackend() storeCache.Backend {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		size,err := strconv.Atoi(getEnv("CACHE_SIZE","10000"))
+		if err != nil || size <= 0 {
+			log.Fatal("Error parsing cache size: ",os.Getenv("CACHE_SIZE"))
+		}
+		return storeCache.NewMemoryBackend(size)
+	}
+	log.Printf("Using redis store cache at %s",addr)
+	return storeCache.NewRedisBackend(redis.NewClient(&redis.Options{Addr: addr}))
 }
-
 func startTracing()(*trace.TracerProvider,error){
 	header := map[string]string{
 		"Content-Type":"application/json",
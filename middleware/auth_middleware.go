@@ -2,40 +2,120 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"log"
 	"net/http"
 	"strings"
 
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
 )
-var jwtKey = []byte("secret_key")
-type Claims struct {
-    UserName string `json:"username"`
-    jwt.RegisteredClaims
+// APIKeyAuthenticator resolves the X-API-Key header, implemented by the api key service.
+type APIKeyAuthenticator interface {
+    AuthenticateAPIKey(ctx context.Context,rawKey string) (*models.APIKey,error)
 }
-func AuthMiddleware(next http.Handler)http.Handler{
-    return http.HandlerFunc(func(w http.ResponseWriter,r *http.Request){
-        authHeader := r.Header.Get("Authorization")
-        if authHeader == ""{
-            http.Error(w,"Authorization header is missing",http.StatusUnauthorized)
-            return
+// TokenVerifier checks bearer tokens issued by the identity provider, implemented by the oidc service.
+type TokenVerifier interface {
+    VerifyToken(ctx context.Context,rawToken string) (*models.Identity,error)
+}
+var (
+    ErrMissingCredentials = errors.New("Authorization header is missing")
+    ErrInvalidAPIKey = errors.New("Invalid API key")
+    ErrInvalidToken = errors.New("Invalid token")
+)
+// Authenticate resolves an X-API-Key value, or failing that an Authorization
+// header, to the caller. Tokens are checked against CarZone's signing key first
+// and then, when idp is not nil, against the identity provider. Shared by the
+// HTTP middleware and the gRPC interceptors.
+func Authenticate(ctx context.Context,apiKeys APIKeyAuthenticator,idp TokenVerifier,rawKey string,authHeader string) (*auth.Principal,error) {
+    if rawKey != ""{
+        key,err := apiKeys.AuthenticateAPIKey(ctx,rawKey)
+        if err != nil {
+            if !errors.Is(err,models.ErrInvalidAPIKey){
+                log.Println("Error authenticating api key: ",err)
+            }
+            return nil,ErrInvalidAPIKey
         }
-        tokenString := strings.TrimPrefix(authHeader,"Bearer ")
-        if tokenString == ""{
-            http.Error(w,"Authorization header is missing",http.StatusUnauthorized)
-            return
+        return &auth.Principal{
+            UserID: "apikey:"+key.ID.String(),
+            Username: key.Name,
+            Scopes: key.Scopes,
+            TokenID: key.ID.String(),
+            TenantID: key.TenantID,
+        },nil
+    }
+    tokenString := strings.TrimPrefix(authHeader,"Bearer ")
+    if tokenString == ""{
+        return nil,ErrMissingCredentials
+    }
+    if claims,err := auth.ParseToken(tokenString); err == nil{
+        username := claims.UserName
+        if username == ""{
+            username = claims.Subject
+        }
+        tenantID,err := auth.ParseTenantID(claims.TenantID)
+        if err != nil{
+            return nil,ErrInvalidToken
         }
-        claims := &Claims{}
-        token,err := jwt.ParseWithClaims(tokenString,claims,func(token *jwt.Token)(interface{},error){
-            return jwtKey,nil
+        return &auth.Principal{
+            UserID: claims.Subject,
+            Username: username,
+            Roles: claims.Roles,
+            Scopes: models.ScopesForRoles(claims.Roles),
+            TokenID: claims.ID,
+            TenantID: tenantID,
+        },nil
+    }
+    if idp == nil{
+        return nil,ErrInvalidToken
+    }
+    identity,err := idp.VerifyToken(ctx,tokenString)
+    if err != nil{
+        return nil,ErrInvalidToken
+    }
+    tenantID,err := auth.ParseTenantID(identity.TenantID)
+    if err != nil{
+        return nil,ErrInvalidToken
+    }
+    return &auth.Principal{
+        UserID: OIDCUserID(identity),
+        Username: identity.Username,
+        Roles: identity.Roles,
+        Scopes: models.ScopesForRoles(identity.Roles),
+        TokenID: identity.TokenID,
+        TenantID: tenantID,
+    },nil
+}
+// OIDCUserID keeps identity provider subjects apart from local usernames and
+// api keys, and subjects of different issuers apart from each other.
+func OIDCUserID(identity *models.Identity) string {
+    return "oidc:"+identity.Issuer+"|"+identity.Subject
+}
+// AuthMiddleware accepts either an X-API-Key header or a Bearer token, see
+// Authenticate. The caller is stored as an auth.Principal on the request context.
+func AuthMiddleware(apiKeys APIKeyAuthenticator,idp TokenVerifier) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter,r *http.Request){
+            principal,err := Authenticate(r.Context(),apiKeys,idp,r.Header.Get("X-API-Key"),r.Header.Get("Authorization"))
+            if err != nil {
+                http.Error(w,err.Error(),http.StatusUnauthorized)
+                return
+            }
+            next.ServeHTTP(w,r.WithContext(auth.WithPrincipal(r.Context(),principal)))
         })
-        if err != nil || !token.Valid{
-            http.Error(w,"Invalid token",http.StatusUnauthorized)
+    }
+}
+// RequireScope rejects callers whose credentials were not granted scope.
+func RequireScope(scope string,next http.HandlerFunc) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter,r *http.Request){
+        if !HasScope(r,scope){
+            http.Error(w,"Missing scope "+scope,http.StatusForbidden)
             return
         }
-        ctx := context.WithValue(r.Context(), "username", claims.UserName)
-        r = r.WithContext(ctx)
-        
         next.ServeHTTP(w,r)
     })
-	
-}
\ No newline at end of file
+}
+func HasScope(r *http.Request,scope string) bool {
+    principal,ok := auth.PrincipalFromContext(r.Context())
+    return ok && principal.HasScope(scope)
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+)
+
+type stubVerifier struct {
+	identity *models.Identity
+}
+
+func (v stubVerifier)VerifyToken(ctx context.Context,rawToken string) (*models.Identity,error) {
+	if v.identity == nil {
+		return nil,errors.New("unknown token")
+	}
+	return v.identity,nil
+}
+
+func TestAuthenticateUserIDs(t *testing.T) {
+	local,err := auth.GenerateToken("admin","admin",[]string{"admin"},uuid.NullUUID{},time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := stubVerifier{identity: &models.Identity{
+		Issuer: "https://idp.example.com",
+		Subject: "admin",
+		Username: "admin",
+		Roles: []string{"staff"},
+	}}
+
+	tests := []struct {
+		name string
+		token string
+		want string
+	}{
+		{name: "local user",token: local,want: "admin"},
+		{name: "identity provider subject",token: "idp-token",want: "oidc:https://idp.example.com|admin"},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			principal,err := Authenticate(context.Background(),nil,idp,"","Bearer "+tt.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if principal.UserID != tt.want {
+				t.Errorf("UserID = %q, want %q",principal.UserID,tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticateRejects(t *testing.T) {
+	if _,err := Authenticate(context.Background(),nil,nil,"",""); !errors.Is(err,ErrMissingCredentials) {
+		t.Errorf("no credentials: %v, want %v",err,ErrMissingCredentials)
+	}
+	if _,err := Authenticate(context.Background(),nil,stubVerifier{},"","Bearer nonsense"); !errors.Is(err,ErrInvalidToken) {
+		t.Errorf("unknown token: %v, want %v",err,ErrInvalidToken)
+	}
+}
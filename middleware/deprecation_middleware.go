@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Deprecation marks every response of an API version that is being retired
+// with the Deprecation (RFC 9745) and Sunset (RFC 8594) headers. Once the
+// sunset has passed the version answers 410 Gone.
+type Deprecation struct {
+	deprecatedAt time.Time
+	sunset time.Time
+	from string
+	to string
+}
+
+// NewDeprecation leaves out the Deprecation header while deprecatedAt is
+// zero and the Sunset header while sunset is zero.
+func NewDeprecation(deprecatedAt,sunset time.Time) *Deprecation {
+	return &Deprecation{
+		deprecatedAt: deprecatedAt,
+		sunset: sunset,
+	}
+}
+
+// WithSuccessor links each response to the same path under the version
+// replacing it, paths starting with from are rewritten to start with to.
+func (d *Deprecation)WithSuccessor(from,to string) *Deprecation {
+	d.from = from
+	d.to = to
+	return d
+}
+
+func (d *Deprecation)Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		header := w.Header()
+		if !d.deprecatedAt.IsZero() {
+			header.Set("Deprecation",fmt.Sprintf("@%d",d.deprecatedAt.Unix()))
+		}
+		if !d.sunset.IsZero() {
+			header.Set("Sunset",d.sunset.UTC().Format(http.TimeFormat))
+		}
+		if d.to != "" && strings.HasPrefix(r.URL.Path,d.from) {
+			successor := d.to + strings.TrimPrefix(r.URL.Path,d.from)
+			header.Add("Link",fmt.Sprintf(`<%s>; rel="successor-version"`,successor))
+		}
+		if !d.sunset.IsZero() && time.Now().After(d.sunset) {
+			http.Error(w,"This API version was retired on "+header.Get("Sunset"),http.StatusGone)
+			return
+		}
+		next.ServeHTTP(w,r)
+	})
+}
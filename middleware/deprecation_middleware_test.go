@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeprecation(t *testing.T) {
+	deprecatedAt := time.Date(2026,1,1,0,0,0,0,time.UTC)
+	tests := []struct {
+		name string
+		deprecation *Deprecation
+		path string
+		wantDeprecation string
+		wantLink string
+		wantStatus int
+	}{
+		{
+			name: "not deprecated",
+			deprecation: NewDeprecation(time.Time{},time.Time{}).WithSuccessor("/v1","/v2"),
+			path: "/v1/cars",
+			wantLink: `</v2/cars>; rel="successor-version"`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "deprecated",
+			deprecation: NewDeprecation(deprecatedAt,time.Time{}).WithSuccessor("/v1","/v2"),
+			path: "/v1/cars",
+			wantDeprecation: "@1767225600",
+			wantLink: `</v2/cars>; rel="successor-version"`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "unversioned",
+			deprecation: NewDeprecation(deprecatedAt,time.Time{}).WithSuccessor("","/v2"),
+			path: "/cars",
+			wantDeprecation: "@1767225600",
+			wantLink: `</v2/cars>; rel="successor-version"`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "past its sunset",
+			deprecation: NewDeprecation(deprecatedAt,deprecatedAt.Add(time.Hour)),
+			path: "/v1/cars",
+			wantDeprecation: "@1767225600",
+			wantStatus: http.StatusGone,
+		},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			handler := tt.deprecation.Middleware(http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w,httptest.NewRequest(http.MethodGet,tt.path,nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d",w.Code,tt.wantStatus)
+			}
+			if got := w.Header().Get("Deprecation"); got != tt.wantDeprecation {
+				t.Errorf("Deprecation = %q, want %q",got,tt.wantDeprecation)
+			}
+			if got := w.Header().Get("Link"); got != tt.wantLink {
+				t.Errorf("Link = %q, want %q",got,tt.wantLink)
+			}
+		})
+	}
+}
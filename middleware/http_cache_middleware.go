@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// HTTPCache adds validators and a Cache-Control policy to successful GET
+// responses and answers conditional requests with 304 Not Modified.
+type HTTPCache struct {
+	defaultPolicy string
+	routes map[string]string
+}
+
+// NewHTTPCache uses defaultPolicy as the Cache-Control of routes without their own.
+func NewHTTPCache(defaultPolicy string) *HTTPCache {
+	return &HTTPCache{
+		defaultPolicy: defaultPolicy,
+		routes: make(map[string]string),
+	}
+}
+
+// WithRoutes overrides the default policy per route, routes are "<METHOD> <path template>"
+// written without the /v1 or /v2 prefix so one policy covers every version.
+func (c *HTTPCache)WithRoutes(routes map[string]string) *HTTPCache {
+	for route,policy := range routes {
+		c.routes[route] = policy
+	}
+	return c
+}
+
+// ParseCachePolicies reads per route policies written as
+// "GET /engines/{id}=private, max-age=300;GET /cars=no-cache".
+func ParseCachePolicies(s string) (map[string]string,error) {
+	policies := make(map[string]string)
+	for _,entry := range strings.Split(s,";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		route,policy,ok := strings.Cut(entry,"=")
+		if !ok || strings.TrimSpace(policy) == "" {
+			return nil,fmt.Errorf("invalid route cache policy %q",entry)
+		}
+		policies[strings.TrimSpace(route)] = strings.TrimSpace(policy)
+	}
+	return policies,nil
+}
+
+// Wrap buffers the response of next so it can be hashed into a strong ETag.
+// Handlers that know when their resource last changed set Last-Modified
+// themselves, lists leave it out since a removed item would not move it.
+func (c *HTTPCache)Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w,r)
+			return
+		}
+		buf := &bufferedResponse{header: make(http.Header),statusCode: http.StatusOK}
+		next.ServeHTTP(buf,r)
+
+		header := w.Header()
+		for key,values := range buf.header {
+			header[key] = values
+		}
+		if buf.statusCode != http.StatusOK {
+			w.WriteHeader(buf.statusCode)
+			w.Write(buf.body.Bytes())
+			return
+		}
+		if header.Get("ETag") == "" {
+			sum := sha256.Sum256(buf.body.Bytes())
+			header.Set("ETag",`"` + hex.EncodeToString(sum[:16]) + `"`)
+		}
+		header.Set("Cache-Control",c.policyFor(r))
+		// responses depend on who is asking and, for platform admins, for which dealership
+		header.Add("Vary","Authorization, X-API-Key, X-Tenant-ID")
+
+		if notModified(r,header) {
+			for _,key := range []string{"Content-Type","Content-Length"} {
+				header.Del(key)
+			}
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(buf.body.Bytes())
+	})
+}
+
+func (c *HTTPCache)policyFor(r *http.Request) string {
+	if current := mux.CurrentRoute(r); current != nil {
+		if tmpl,err := current.GetPathTemplate(); err == nil {
+			if policy,ok := c.routes[r.Method + " " + tmpl]; ok {
+				return policy
+			}
+			if policy,ok := c.routes[r.Method + " " + unversioned(tmpl)]; ok {
+				return policy
+			}
+		}
+	}
+	return c.defaultPolicy
+}
+
+// unversioned strips a leading /v<n> from a path template.
+func unversioned(tmpl string) string {
+	rest,ok := strings.CutPrefix(tmpl,"/v")
+	if !ok {
+		return tmpl
+	}
+	version,path,_ := strings.Cut(rest,"/")
+	if version == "" || strings.Trim(version,"0123456789") != "" {
+		return tmpl
+	}
+	return "/" + path
+}
+
+// notModified evaluates If-None-Match, falling back to If-Modified-Since only
+// when the client sent no entity tags (RFC 9110 section 13.2.2).
+func notModified(r *http.Request,header http.Header) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		etag := strings.TrimPrefix(header.Get("ETag"),"W/")
+		for _,candidate := range strings.Split(inm,",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate,"W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+	ims,err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	lastModified,err := http.ParseTime(header.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(ims)
+}
+
+// bufferedResponse holds a response back until it is complete.
+type bufferedResponse struct {
+	header http.Header
+	statusCode int
+	wroteHeader bool
+	body bytes.Buffer
+}
+
+func (b *bufferedResponse)Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse)WriteHeader(statusCode int) {
+	if !b.wroteHeader {
+		b.statusCode = statusCode
+		b.wroteHeader = true
+	}
+}
+
+func (b *bufferedResponse)Write(p []byte) (int,error) {
+	b.wroteHeader = true
+	return b.body.Write(p)
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestNotModified(t *testing.T) {
+	const etag = `"abc"`
+	const lastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+	tests := []struct {
+		name string
+		ifNoneMatch string
+		ifModifiedSince string
+		lastModified string
+		want bool
+	}{
+		{name: "no conditions",want: false},
+		{name: "matching etag",ifNoneMatch: etag,want: true},
+		{name: "matching weak etag",ifNoneMatch: `W/"abc"`,want: true},
+		{name: "one of several",ifNoneMatch: `"xyz", "abc"`,want: true},
+		{name: "any",ifNoneMatch: "*",want: true},
+		{name: "other etag",ifNoneMatch: `"xyz"`,want: false},
+		{name: "unchanged since",ifModifiedSince: lastModified,lastModified: lastModified,want: true},
+		{name: "changed since",ifModifiedSince: "Wed, 21 Oct 2015 07:27:59 GMT",lastModified: lastModified,want: false},
+		{name: "no last modified",ifModifiedSince: lastModified,want: false},
+		{name: "unparsable date",ifModifiedSince: "yesterday",lastModified: lastModified,want: false},
+		// If-None-Match wins over If-Modified-Since
+		{name: "etag changed, date not",ifNoneMatch: `"xyz"`,ifModifiedSince: lastModified,lastModified: lastModified,want: false},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet,"/cars/1",nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set("If-None-Match",tt.ifNoneMatch)
+			}
+			if tt.ifModifiedSince != "" {
+				r.Header.Set("If-Modified-Since",tt.ifModifiedSince)
+			}
+			header := http.Header{}
+			header.Set("ETag",etag)
+			if tt.lastModified != "" {
+				header.Set("Last-Modified",tt.lastModified)
+			}
+			if got := notModified(r,header); got != tt.want {
+				t.Errorf("notModified = %v, want %v",got,tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPCachePolicies(t *testing.T) {
+	cache := NewHTTPCache("private, no-cache").WithRoutes(map[string]string{
+		"GET /engines/{id}": "private, max-age=300",
+	})
+	router := mux.NewRouter()
+	ok := func(w http.ResponseWriter,r *http.Request) {
+		w.Write([]byte("{}"))
+	}
+	for _,prefix := range []string{"","/v1","/v2"} {
+		sub := router.PathPrefix("/").Subrouter()
+		if prefix != "" {
+			sub = router.PathPrefix(prefix).Subrouter()
+		}
+		sub.Handle("/engines/{id}",cache.Wrap(http.HandlerFunc(ok))).Methods("GET")
+		sub.Handle("/cars/{id}",cache.Wrap(http.HandlerFunc(ok))).Methods("GET")
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/engines/1",want: "private, max-age=300"},
+		{path: "/v1/engines/1",want: "private, max-age=300"},
+		{path: "/v2/engines/1",want: "private, max-age=300"},
+		{path: "/v2/cars/1",want: "private, no-cache"},
+	}
+	for _,tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec,httptest.NewRequest(http.MethodGet,tt.path,nil))
+		if got := rec.Header().Get("Cache-Control"); got != tt.want {
+			t.Errorf("%s Cache-Control = %q, want %q",tt.path,got,tt.want)
+		}
+		if vary := rec.Header().Get("Vary"); !strings.Contains(vary,"X-Tenant-ID") {
+			t.Errorf("%s Vary = %q, want X-Tenant-ID in it",tt.path,vary)
+		}
+	}
+}
+
+func TestUnversioned(t *testing.T) {
+	tests := map[string]string{
+		"/v1/cars/{id}": "/cars/{id}",
+		"/v12/cars": "/cars",
+		"/cars/{id}": "/cars/{id}",
+		"/vin/{vin}": "/vin/{vin}",
+		"/v/cars": "/v/cars",
+	}
+	for tmpl,want := range tests {
+		if got := unversioned(tmpl); got != want {
+			t.Errorf("unversioned(%q) = %q, want %q",tmpl,got,want)
+		}
+	}
+}
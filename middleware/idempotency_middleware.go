@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+)
+
+// IdempotencyStore keeps the first response per user and key, implemented by store/idempotency.
+type IdempotencyStore interface {
+	// Begin claims key for a new request, started is false when the key was
+	// claimed before and record holds what is known about that request. An
+	// unfinished claim older than lease may be taken over.
+	Begin(ctx context.Context,userID string,key string,fingerprint string,ttl time.Duration,lease time.Duration) (record models.IdempotencyRecord,started bool,err error)
+	Complete(ctx context.Context,userID string,key string,record models.IdempotencyRecord) error
+	// Release forgets a key whose request failed so it can be retried.
+	Release(ctx context.Context,userID string,key string) error
+	// Purge deletes keys that expired before the given time.
+	Purge(ctx context.Context,before time.Time) (int64,error)
+}
+
+const (
+	maxIdempotencyKeyLength = 255
+	// how long a running request holds its key, longer than any request should take
+	idempotencyLease = 2 * time.Minute
+	idempotencyPurgeInterval = time.Hour
+)
+
+// Idempotency replays the stored response when a request is retried with the
+// same Idempotency-Key. Reusing a key for a different request is a 422, and a
+// retry that arrives while the first request is still running is a 409.
+// Requests without the header are passed through untouched.
+type Idempotency struct {
+	store IdempotencyStore
+	ttl time.Duration
+}
+
+func NewIdempotency(store IdempotencyStore,ttl time.Duration) *Idempotency {
+	return &Idempotency{
+		store: store,
+		ttl: ttl,
+	}
+}
+
+func (m *Idempotency)Wrap(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter,r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		userID := auth.UserID(r.Context())
+		if key == "" || userID == "" {
+			next(w,r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			http.Error(w,"Idempotency-Key is too long",http.StatusBadRequest)
+			return
+		}
+		body,err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w,err.Error(),http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		requestHash := fingerprint(r,body)
+		record,started,err := m.store.Begin(r.Context(),userID,key,requestHash,m.ttl,idempotencyLease)
+		if err != nil {
+			log.Println("Error checking idempotency key: ",err)
+			http.Error(w,"Internal server error",http.StatusInternalServerError)
+			return
+		}
+		if !started {
+			switch {
+			case record.Fingerprint != requestHash:
+				http.Error(w,"Idempotency-Key was already used for a different request",http.StatusUnprocessableEntity)
+			case !record.Completed():
+				http.Error(w,"A request with this Idempotency-Key is still in progress",http.StatusConflict)
+			default:
+				if record.ContentType != "" {
+					w.Header().Set("Content-Type",record.ContentType)
+				}
+				w.Header().Set("Idempotent-Replayed","true")
+				w.WriteHeader(record.StatusCode)
+				w.Write(record.Body)
+			}
+			return
+		}
+
+		defer func() {
+			// a handler that panics never completes, free the key for a retry
+			if p := recover(); p != nil {
+				if err := m.store.Release(r.Context(),userID,key); err != nil {
+					log.Println("Error releasing idempotency key: ",err)
+				}
+				panic(p)
+			}
+		}()
+		rec := &responseRecorder{ResponseWriter: w,statusCode: http.StatusOK}
+		next(rec,r)
+		// server errors are not final, the client may retry them with the same key
+		if rec.statusCode >= http.StatusInternalServerError {
+			if err := m.store.Release(r.Context(),userID,key); err != nil {
+				log.Println("Error releasing idempotency key: ",err)
+			}
+			return
+		}
+		err = m.store.Complete(r.Context(),userID,key,models.IdempotencyRecord{
+			StatusCode: rec.statusCode,
+			ContentType: rec.Header().Get("Content-Type"),
+			Body: rec.body.Bytes(),
+		})
+		if err != nil {
+			log.Println("Error storing idempotent response: ",err)
+		}
+	}
+}
+
+// Run purges expired keys until ctx is done.
+func (m *Idempotency)Run(ctx context.Context) {
+	ticker := time.NewTicker(idempotencyPurgeInterval)
+	defer ticker.Stop()
+	for {
+		if _,err := m.store.Purge(ctx,time.Now()); err != nil {
+			log.Println("Error purging idempotency keys: ",err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fingerprint identifies a request by method, path, dealership and body, so
+// a key reused on another endpoint, or by a platform admin for another
+// X-Tenant-ID, does not match.
+func fingerprint(r *http.Request,body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	if tenantID := auth.TenantID(r.Context()); tenantID.Valid {
+		h.Write([]byte(tenantID.UUID.String()))
+	}
+	h.Write([]byte("\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	wroteHeader bool
+	body bytes.Buffer
+}
+
+func (r *responseRecorder)WriteHeader(statusCode int) {
+	if !r.wroteHeader {
+		r.statusCode = statusCode
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder)Write(b []byte) (int,error) {
+	r.wroteHeader = true
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
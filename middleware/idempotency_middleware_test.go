@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+)
+
+// memoryIdempotencyStore keeps keys in a map, leases and expiry are not modelled.
+type memoryIdempotencyStore struct {
+	mu sync.Mutex
+	records map[string]models.IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]models.IdempotencyRecord)}
+}
+
+func (s *memoryIdempotencyStore)Begin(ctx context.Context,userID string,key string,fingerprint string,ttl time.Duration,lease time.Duration) (models.IdempotencyRecord,bool,error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record,ok := s.records[userID+"/"+key]; ok {
+		return record,false,nil
+	}
+	s.records[userID+"/"+key] = models.IdempotencyRecord{Fingerprint: fingerprint}
+	return models.IdempotencyRecord{Fingerprint: fingerprint},true,nil
+}
+
+func (s *memoryIdempotencyStore)Complete(ctx context.Context,userID string,key string,record models.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record.Fingerprint = s.records[userID+"/"+key].Fingerprint
+	s.records[userID+"/"+key] = record
+	return nil
+}
+
+func (s *memoryIdempotencyStore)Release(ctx context.Context,userID string,key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records,userID+"/"+key)
+	return nil
+}
+
+func (s *memoryIdempotencyStore)Purge(ctx context.Context,before time.Time) (int64,error) {
+	return 0,nil
+}
+
+var (
+	dealershipA = uuid.NullUUID{UUID: uuid.New(),Valid: true}
+	dealershipB = uuid.NullUUID{UUID: uuid.New(),Valid: true}
+)
+
+func idempotentRequest(key string,body string,tenantID uuid.NullUUID) *http.Request {
+	r := httptest.NewRequest(http.MethodPost,"/cars",strings.NewReader(body))
+	if key != "" {
+		r.Header.Set("Idempotency-Key",key)
+	}
+	principal := &auth.Principal{UserID: "admin",Roles: []string{models.RoleAdmin},TenantID: tenantID}
+	return r.WithContext(auth.WithPrincipal(r.Context(),principal))
+}
+
+func TestIdempotency(t *testing.T) {
+	tests := []struct {
+		name string
+		first *http.Request
+		retry *http.Request
+		firstStatus int
+		wantStatus int
+		wantCalls int
+		wantReplayed bool
+	}{
+		{name: "replayed",first: idempotentRequest("k","{}",dealershipA),retry: idempotentRequest("k","{}",dealershipA),
+			firstStatus: http.StatusCreated,wantStatus: http.StatusCreated,wantCalls: 1,wantReplayed: true},
+		{name: "different body",first: idempotentRequest("k","{}",dealershipA),retry: idempotentRequest("k",`{"a":1}`,dealershipA),
+			firstStatus: http.StatusCreated,wantStatus: http.StatusUnprocessableEntity,wantCalls: 1},
+		{name: "different dealership",first: idempotentRequest("k","{}",dealershipA),retry: idempotentRequest("k","{}",dealershipB),
+			firstStatus: http.StatusCreated,wantStatus: http.StatusUnprocessableEntity,wantCalls: 1},
+		{name: "client errors are final",first: idempotentRequest("k","{}",dealershipA),retry: idempotentRequest("k","{}",dealershipA),
+			firstStatus: http.StatusBadRequest,wantStatus: http.StatusBadRequest,wantCalls: 1,wantReplayed: true},
+		{name: "server errors are retried",first: idempotentRequest("k","{}",dealershipA),retry: idempotentRequest("k","{}",dealershipA),
+			firstStatus: http.StatusInternalServerError,wantStatus: http.StatusInternalServerError,wantCalls: 2},
+		{name: "no key",first: idempotentRequest("","{}",dealershipA),retry: idempotentRequest("","{}",dealershipA),
+			firstStatus: http.StatusCreated,wantStatus: http.StatusCreated,wantCalls: 2},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			calls := 0
+			handler := NewIdempotency(newMemoryIdempotencyStore(),time.Hour).Wrap(func(w http.ResponseWriter,r *http.Request) {
+				calls++
+				w.WriteHeader(tt.firstStatus)
+			})
+			handler(httptest.NewRecorder(),tt.first)
+			rec := httptest.NewRecorder()
+			handler(rec,tt.retry)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("retry status = %d, want %d",rec.Code,tt.wantStatus)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("handler called %d times, want %d",calls,tt.wantCalls)
+			}
+			if replayed := rec.Header().Get("Idempotent-Replayed") == "true"; replayed != tt.wantReplayed {
+				t.Errorf("replayed = %v, want %v",replayed,tt.wantReplayed)
+			}
+		})
+	}
+}
+
+func TestIdempotencyInFlight(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	m := NewIdempotency(store,time.Hour)
+	var retry *httptest.ResponseRecorder
+	handler := m.Wrap(func(w http.ResponseWriter,r *http.Request) {
+		// the retry arrives while the first request is still running
+		if retry == nil {
+			retry = httptest.NewRecorder()
+			m.Wrap(func(http.ResponseWriter,*http.Request) {
+				t.Error("retry ran while the first request was in flight")
+			})(retry,idempotentRequest("k","{}",dealershipA))
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	handler(httptest.NewRecorder(),idempotentRequest("k","{}",dealershipA))
+	if retry.Code != http.StatusConflict {
+		t.Errorf("retry status = %d, want %d",retry.Code,http.StatusConflict)
+	}
+}
+
+func TestIdempotencyReleasesKeyOnPanic(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	handler := NewIdempotency(store,time.Hour).Wrap(func(w http.ResponseWriter,r *http.Request) {
+		panic("handler failed")
+	})
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("the panic was swallowed")
+			}
+		}()
+		handler(httptest.NewRecorder(),idempotentRequest("k","{}",dealershipA))
+	}()
+	if len(store.records) != 0 {
+		t.Errorf("key is still held after the handler panicked: %v",store.records)
+	}
+}
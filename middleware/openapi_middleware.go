@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+// OpenAPIValidator rejects requests that don't match the OpenAPI document
+// before they reach a handler. Routes the document doesn't describe pass through.
+type OpenAPIValidator struct {
+	router routers.Router
+	validateResponses bool
+}
+
+func NewOpenAPIValidator(doc *openapi3.T) (*OpenAPIValidator,error) {
+	router,err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil,err
+	}
+	return &OpenAPIValidator{router: router},nil
+}
+
+// WithResponseValidation also checks what handlers send back. Mismatches are
+// logged rather than failing a request that has already been served.
+func (v *OpenAPIValidator)WithResponseValidation(enabled bool) *OpenAPIValidator {
+	v.validateResponses = enabled
+	return v
+}
+
+// openAPIOptions leaves credentials to AuthMiddleware.
+var openAPIOptions = &openapi3filter.Options{
+	AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	IncludeResponseStatus: true,
+}
+
+func (v *OpenAPIValidator)Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		route,pathParams,err := v.router.FindRoute(r)
+		if err != nil {
+			next.ServeHTTP(w,r)
+			return
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request: r,
+			PathParams: pathParams,
+			Route: route,
+			Options: openAPIOptions,
+		}
+		if err := openapi3filter.ValidateRequest(r.Context(),input); err != nil {
+			http.Error(w,err.Error(),http.StatusBadRequest)
+			return
+		}
+		if !v.validateResponses || streaming(route) {
+			next.ServeHTTP(w,r)
+			return
+		}
+
+		buf := &bufferedResponse{header: make(http.Header),statusCode: http.StatusOK}
+		next.ServeHTTP(buf,r)
+
+		err = openapi3filter.ValidateResponse(r.Context(),&openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status: buf.statusCode,
+			Header: buf.header,
+			Body: io.NopCloser(bytes.NewReader(buf.body.Bytes())),
+			Options: openAPIOptions,
+		})
+		if err != nil {
+			log.Printf("Error: %s %s response does not match the openapi document: %v",r.Method,route.Path,err)
+		}
+		header := w.Header()
+		for key,values := range buf.header {
+			header[key] = values
+		}
+		w.WriteHeader(buf.statusCode)
+		w.Write(buf.body.Bytes())
+	})
+}
+
+// streaming routes never finish a response that could be buffered and checked.
+func streaming(route *routers.Route) bool {
+	response := route.Operation.Responses.Status(http.StatusOK)
+	return response != nil && response.Value.Content.Get("text/event-stream") != nil
+}
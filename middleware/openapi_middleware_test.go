@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/iangechuki/go_carzone/openapi"
+)
+
+func TestOpenAPIValidatorVersions(t *testing.T) {
+	var validators []*OpenAPIValidator
+	for _,load := range []func() (*openapi3.T,error){openapi.Load,openapi.LoadV2} {
+		doc,err := load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		validator,err := NewOpenAPIValidator(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		validators = append(validators,validator)
+	}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	for _,validator := range validators {
+		handler = validator.Middleware(handler)
+	}
+
+	const v1Engine = `{"displacement":1998,"no_of_cylinders":4,"car_range":600}`
+	const v2Engine = `{"displacement_cc":1998,"cylinders":4,"range_km":600}`
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{name: "v1 body on v1",path: "/v1/engines",body: v1Engine,want: http.StatusOK},
+		{name: "v1 body unversioned",path: "/engines",body: v1Engine,want: http.StatusOK},
+		{name: "v2 body on v2",path: "/v2/engines",body: v2Engine,want: http.StatusOK},
+		{name: "v1 body on v2",path: "/v2/engines",body: v1Engine,want: http.StatusBadRequest},
+		{name: "v2 body on v1",path: "/v1/engines",body: v2Engine,want: http.StatusBadRequest},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost,tt.path,strings.NewReader(tt.body))
+			r.Header.Set("Content-Type","application/json")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w,r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s",w.Code,tt.want,w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/iangechuki/go_carzone/auth"
+)
+
+// Limit allows Requests per Period, with bursts of up to Requests.
+type Limit struct {
+	Requests int
+	Period time.Duration
+}
+
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// ParseLimit reads a limit written as "<requests>/<period>", e.g. "5/1m".
+func ParseLimit(s string) (Limit,error) {
+	parts := strings.SplitN(strings.TrimSpace(s),"/",2)
+	if len(parts) != 2 {
+		return Limit{},fmt.Errorf("invalid rate limit %q",s)
+	}
+	requests,err := strconv.Atoi(parts[0])
+	if err != nil || requests <= 0 {
+		return Limit{},fmt.Errorf("invalid rate limit requests %q",parts[0])
+	}
+	period,err := time.ParseDuration(parts[1])
+	if err != nil || period <= 0 {
+		return Limit{},fmt.Errorf("invalid rate limit period %q",parts[1])
+	}
+	return Limit{Requests: requests,Period: period},nil
+}
+
+// ParseRouteLimits reads per route quotas written as "POST /login=5/1m;GET /cars=100/1m".
+func ParseRouteLimits(s string) (map[string]Limit,error) {
+	limits := make(map[string]Limit)
+	for _,entry := range strings.Split(s,";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		route,limitStr,ok := strings.Cut(entry,"=")
+		if !ok {
+			return nil,fmt.Errorf("invalid route rate limit %q",entry)
+		}
+		limit,err := ParseLimit(limitStr)
+		if err != nil {
+			return nil,err
+		}
+		limits[strings.TrimSpace(route)] = limit
+	}
+	return limits,nil
+}
+
+// KeyFunc picks the identity a request is counted against, an empty key skips limiting.
+type KeyFunc func(r *http.Request) string
+
+func ClientIP(r *http.Request) string {
+	host,_,err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func ClientUserID(r *http.Request) string {
+	return auth.UserID(r.Context())
+}
+
+// maxLoginPeek is how much of a /login body LoginUsername reads for the username.
+const maxLoginPeek = 4096
+
+// LoginUsername keys /login attempts on the username being tried, so
+// guessing one account's password from many addresses still runs into a
+// limit. The body is left for the handler to read in full.
+func LoginUsername(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+	peeked,err := io.ReadAll(io.LimitReader(r.Body,maxLoginPeek))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(peeked),r.Body),r.Body}
+	if err != nil {
+		return ""
+	}
+	var credentials struct {
+		Username string `json:"username"`
+	}
+	if err := json.Unmarshal(peeked,&credentials); err != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(credentials.Username))
+}
+
+type RateLimiter struct {
+	name string
+	store RateLimitStore
+	keyFunc KeyFunc
+	defaultLimit Limit
+	routes map[string]Limit
+}
+
+func NewRateLimiter(name string,store RateLimitStore,keyFunc KeyFunc,defaultLimit Limit) *RateLimiter {
+	return &RateLimiter{
+		name: name,
+		store: store,
+		keyFunc: keyFunc,
+		defaultLimit: defaultLimit,
+		routes: make(map[string]Limit),
+	}
+}
+
+// WithRoute overrides the default limit for one route, route is "<METHOD> <path template>".
+func (rl *RateLimiter)WithRoute(route string,limit Limit) *RateLimiter {
+	rl.routes[route] = limit
+	return rl
+}
+
+func (rl *RateLimiter)WithRoutes(routes map[string]Limit) *RateLimiter {
+	for route,limit := range routes {
+		rl.routes[route] = limit
+	}
+	return rl
+}
+
+func (rl *RateLimiter)Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		identity := rl.keyFunc(r)
+		if identity == "" {
+			next.ServeHTTP(w,r)
+			return
+		}
+		limit,result,err := rl.Take(r.Context(),routeOf(r),identity)
+		if err != nil {
+			// fail open, an unavailable store should not take the API down with it
+			log.Println("Error checking rate limit: ",err)
+			next.ServeHTTP(w,r)
+			return
+		}
+		w.Header().Set("RateLimit-Limit",strconv.Itoa(limit.Requests))
+		w.Header().Set("RateLimit-Remaining",strconv.Itoa(result.Remaining))
+		w.Header().Set("RateLimit-Reset",strconv.Itoa(ceilSeconds(result.Reset)))
+		if !result.Allowed {
+			w.Header().Set("Retry-After",strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			http.Error(w,"Too many requests",http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w,r)
+	})
+}
+
+// Take counts one call to route by identity. Routes without an override
+// share one bucket, so the gRPC interceptors, whose routes are full method
+// names, draw on the same default quota as HTTP requests.
+func (rl *RateLimiter)Take(ctx context.Context,route string,identity string) (Limit,RateLimitResult,error) {
+	limit,ok := rl.routes[route]
+	if !ok {
+		route,limit = "default",rl.defaultLimit
+	}
+	result,err := rl.store.Take(ctx,rl.name + ":" + route + ":" + identity,limit)
+	return limit,result,err
+}
+
+// routeOf names the matched route as "<METHOD> <path template>".
+func routeOf(r *http.Request) string {
+	if current := mux.CurrentRoute(r); current != nil {
+		if tmpl,err := current.GetPathTemplate(); err == nil {
+			return r.Method + " " + tmpl
+		}
+	}
+	return ""
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in string
+		want Limit
+		wantErr bool
+	}{
+		{in: "5/1m",want: Limit{Requests: 5,Period: time.Minute}},
+		{in: " 100/1s ",want: Limit{Requests: 100,Period: time.Second}},
+		{in: "5/1h",want: Limit{Requests: 5,Period: time.Hour}},
+		{in: "5",wantErr: true},
+		{in: "",wantErr: true},
+		{in: "0/1m",wantErr: true},
+		{in: "-1/1m",wantErr: true},
+		{in: "x/1m",wantErr: true},
+		{in: "5/0s",wantErr: true},
+		{in: "5/minute",wantErr: true},
+	}
+	for _,tt := range tests {
+		got,err := ParseLimit(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseLimit(%q) = %v, want an error",tt.in,got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseLimit(%q) = %v, %v, want %v",tt.in,got,err,tt.want)
+		}
+	}
+}
+
+func TestLoginUsernameLimit(t *testing.T) {
+	limiter := NewRateLimiter("login",NewMemoryRateLimitStore(),LoginUsername,Limit{Requests: 2,Period: time.Minute})
+	var bodies []string
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		body,_ := io.ReadAll(r.Body)
+		bodies = append(bodies,string(body))
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	attempt := func(addr,body string) int {
+		r := httptest.NewRequest(http.MethodPost,"/login",strings.NewReader(body))
+		r.RemoteAddr = addr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w,r)
+		return w.Code
+	}
+	const alice = `{"username":"alice","password":"guess"}`
+	// each attempt comes from another address, only the username ties them together
+	for i,addr := range []string{"203.0.113.1:1000","203.0.113.2:1000"} {
+		if code := attempt(addr,alice); code != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: status = %d, want %d",i+1,code,http.StatusUnauthorized)
+		}
+	}
+	if code := attempt("203.0.113.3:1000",`{"username":" Alice ","password":"guess"}`); code != http.StatusTooManyRequests {
+		t.Errorf("third attempt on alice: status = %d, want %d",code,http.StatusTooManyRequests)
+	}
+	if code := attempt("203.0.113.3:1000",`{"username":"bob","password":"guess"}`); code != http.StatusUnauthorized {
+		t.Errorf("attempt on bob: status = %d, want %d",code,http.StatusUnauthorized)
+	}
+	if len(bodies) == 0 || bodies[0] != alice {
+		t.Errorf("handler read %q, want the whole body",bodies)
+	}
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimitStore keeps the token buckets used by RateLimiter.
+type RateLimitStore interface {
+	Take(ctx context.Context,key string,limit Limit) (RateLimitResult,error)
+}
+
+type RateLimitResult struct {
+	Allowed bool
+	Remaining int
+	// time until the bucket is full again
+	Reset time.Duration
+	// time until the next token is available, zero when allowed
+	RetryAfter time.Duration
+}
+
+type bucket struct {
+	tokens float64
+	last time.Time
+	// time an empty bucket takes to refill
+	period time.Duration
+}
+
+// MemoryRateLimitStore is a process local RateLimitStore, buckets are not shared between replicas.
+type MemoryRateLimitStore struct {
+	mu sync.Mutex
+	buckets map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *MemoryRateLimitStore)Take(ctx context.Context,key string,limit Limit) (RateLimitResult,error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+	b,ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Requests),last: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(limit.Requests),b.tokens + now.Sub(b.last).Seconds() * limit.rate())
+	b.last = now
+	b.period = limit.Period
+
+	return takeToken(&b.tokens,limit),nil
+}
+
+// sweep drops buckets that have been idle long enough to be full again.
+func (s *MemoryRateLimitStore)sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for key,b := range s.buckets {
+		if now.Sub(b.last) >= b.period {
+			delete(s.buckets,key)
+		}
+	}
+}
+
+func takeToken(tokens *float64,limit Limit) RateLimitResult {
+	rate := limit.rate()
+	if *tokens < 1 {
+		return RateLimitResult{
+			Allowed: false,
+			Remaining: 0,
+			Reset: secondsToDuration((float64(limit.Requests) - *tokens) / rate),
+			RetryAfter: secondsToDuration((1 - *tokens) / rate),
+		}
+	}
+	*tokens--
+	return RateLimitResult{
+		Allowed: true,
+		Remaining: int(*tokens),
+		Reset: secondsToDuration((float64(limit.Requests) - *tokens) / rate),
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// RedisRateLimitStore shares buckets between replicas through any Redis compatible server.
+type RedisRateLimitStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func NewRedisRateLimitStore(client redis.UniversalClient) *RedisRateLimitStore {
+	return &RedisRateLimitStore{
+		client: client,
+		prefix: "carzone:ratelimit:",
+	}
+}
+
+// the bucket is refilled and drained atomically on the server so concurrent replicas agree on the count
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "last")
+local tokens = tonumber(state[1])
+local last = tonumber(state[2])
+if tokens == nil then
+	tokens = capacity
+	last = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - last) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "last", tostring(now))
+redis.call("EXPIRE", KEYS[1], ttl)
+return {allowed, tostring(tokens)}
+`)
+
+func (s *RedisRateLimitStore)Take(ctx context.Context,key string,limit Limit) (RateLimitResult,error) {
+	now := float64(time.Now().UnixMicro()) / 1e6
+	ttl := int(math.Ceil(limit.Period.Seconds())) + 1
+	res,err := tokenBucketScript.Run(ctx,s.client,[]string{s.prefix + key},limit.Requests,limit.rate(),now,ttl).Slice()
+	if err != nil {
+		return RateLimitResult{},err
+	}
+	allowed,_ := res[0].(int64)
+	tokensStr,_ := res[1].(string)
+	tokens,err := strconv.ParseFloat(tokensStr,64)
+	if err != nil {
+		return RateLimitResult{},err
+	}
+	rate := limit.rate()
+	result := RateLimitResult{
+		Allowed: allowed == 1,
+		Remaining: int(tokens),
+		Reset: secondsToDuration((float64(limit.Requests) - tokens) / rate),
+	}
+	if !result.Allowed {
+		result.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+	return result,nil
+}
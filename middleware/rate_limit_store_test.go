@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryRateLimitStoreTake(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	limit := Limit{Requests: 2,Period: time.Hour}
+	for i,want := range []bool{true,true,false} {
+		res,err := store.Take(context.Background(),"k",limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Allowed != want {
+			t.Errorf("take %d allowed = %v, want %v",i,res.Allowed,want)
+		}
+	}
+}
+
+func TestMemoryRateLimitStoreSweep(t *testing.T) {
+	tests := []struct {
+		name string
+		period time.Duration
+		idle time.Duration
+		kept bool
+	}{
+		{name: "idle shorter than the period",period: time.Hour,idle: 30 * time.Minute,kept: true},
+		{name: "idle for the period",period: time.Hour,idle: time.Hour,kept: false},
+		{name: "short period",period: time.Minute,idle: 2 * time.Minute,kept: false},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			store := NewMemoryRateLimitStore()
+			now := time.Now()
+			store.buckets["k"] = &bucket{last: now.Add(-tt.idle),period: tt.period}
+			store.lastSweep = now.Add(-2 * time.Minute)
+			store.sweep(now)
+			if _,ok := store.buckets["k"]; ok != tt.kept {
+				t.Errorf("bucket kept = %v, want %v",ok,tt.kept)
+			}
+		})
+	}
+}
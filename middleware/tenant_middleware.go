@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/iangechuki/go_carzone/auth"
+)
+
+var (
+	ErrNoDealership = errors.New("Credentials are not assigned to a dealership")
+	ErrInvalidTenantHeader = errors.New("Invalid X-Tenant-ID")
+	ErrNotMember = errors.New("Not a member of this dealership")
+)
+
+// ScopeTenant lets platform admins pick the dealership they act for with
+// the X-Tenant-ID header. Everyone else is pinned to the dealership of their
+// credentials and may only repeat it in the header, credentials without a
+// dealership are rejected. Shared by the HTTP middleware and the gRPC interceptors.
+func ScopeTenant(principal *auth.Principal,header string) (*auth.Principal,error) {
+	if !principal.TenantID.Valid && !principal.IsPlatformAdmin() {
+		return nil,ErrNoDealership
+	}
+	if header == "" {
+		return principal,nil
+	}
+	tenantID,err := auth.ParseTenantID(header)
+	if err != nil {
+		return nil,ErrInvalidTenantHeader
+	}
+	if principal.TenantID.Valid {
+		if principal.TenantID != tenantID {
+			return nil,ErrNotMember
+		}
+		return principal,nil
+	}
+	scoped := *principal
+	scoped.TenantID = tenantID
+	return &scoped,nil
+}
+
+// TenantMiddleware applies ScopeTenant to the caller. Must run after AuthMiddleware.
+func TenantMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		principal,ok := auth.PrincipalFromContext(r.Context())
+		if !ok {
+			next.ServeHTTP(w,r)
+			return
+		}
+		scoped,err := ScopeTenant(principal,r.Header.Get("X-Tenant-ID"))
+		if err != nil {
+			status := http.StatusForbidden
+			if errors.Is(err,ErrInvalidTenantHeader) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w,err.Error(),status)
+			return
+		}
+		next.ServeHTTP(w,r.WithContext(auth.WithPrincipal(r.Context(),scoped)))
+	})
+}
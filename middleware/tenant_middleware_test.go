@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+)
+
+func TestScopeTenant(t *testing.T) {
+	home := uuid.NullUUID{UUID: uuid.New(),Valid: true}
+	other := uuid.NullUUID{UUID: uuid.New(),Valid: true}
+	staff := &auth.Principal{UserID: "staff",Roles: []string{"staff"},TenantID: home}
+	dealerAdmin := &auth.Principal{UserID: "owner",Roles: []string{"admin"},TenantID: home}
+	platformAdmin := &auth.Principal{UserID: "root",Roles: []string{"admin"}}
+	homeless := &auth.Principal{UserID: "drifter",Roles: []string{"staff"}}
+
+	tests := []struct {
+		name string
+		principal *auth.Principal
+		header string
+		want uuid.NullUUID
+		wantErr error
+	}{
+		{name: "staff in their dealership",principal: staff,want: home},
+		{name: "staff repeating their dealership",principal: staff,header: home.UUID.String(),want: home},
+		{name: "staff picking another dealership",principal: staff,header: other.UUID.String(),wantErr: ErrNotMember},
+		{name: "dealership admin picking another dealership",principal: dealerAdmin,header: other.UUID.String(),wantErr: ErrNotMember},
+		{name: "no dealership",principal: homeless,wantErr: ErrNoDealership},
+		{name: "no dealership picking one",principal: homeless,header: home.UUID.String(),wantErr: ErrNoDealership},
+		{name: "platform admin across dealerships",principal: platformAdmin,want: uuid.NullUUID{}},
+		{name: "platform admin picking a dealership",principal: platformAdmin,header: other.UUID.String(),want: other},
+		{name: "malformed header",principal: platformAdmin,header: "dealership-1",wantErr: ErrInvalidTenantHeader},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			scoped,err := ScopeTenant(tt.principal,tt.header)
+			if tt.wantErr != nil {
+				if !errors.Is(err,tt.wantErr) {
+					t.Fatalf("ScopeTenant = %v, want %v",err,tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if scoped.TenantID != tt.want {
+				t.Errorf("tenant = %v, want %v",scoped.TenantID,tt.want)
+			}
+		})
+	}
+	if platformAdmin.TenantID.Valid {
+		t.Error("ScopeTenant changed the principal it was given")
+	}
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	home := uuid.NullUUID{UUID: uuid.New(),Valid: true}
+	var seen uuid.NullUUID
+	handler := TenantMiddleware(http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		seen = auth.TenantID(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+	tests := []struct {
+		name string
+		principal *auth.Principal
+		header string
+		want int
+	}{
+		{name: "member",principal: &auth.Principal{UserID: "staff",Roles: []string{"staff"},TenantID: home},want: http.StatusOK},
+		{name: "override",principal: &auth.Principal{UserID: "staff",Roles: []string{"staff"},TenantID: home},header: uuid.NewString(),want: http.StatusForbidden},
+		{name: "no dealership",principal: &auth.Principal{UserID: "drifter",Roles: []string{"viewer"}},want: http.StatusForbidden},
+		{name: "malformed header",principal: &auth.Principal{UserID: "root",Roles: []string{"admin"}},header: "x",want: http.StatusBadRequest},
+		{name: "platform admin",principal: &auth.Principal{UserID: "root",Roles: []string{"admin"}},header: home.UUID.String(),want: http.StatusOK},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			seen = uuid.NullUUID{}
+			r := httptest.NewRequest(http.MethodGet,"/cars",nil)
+			r = r.WithContext(auth.WithPrincipal(r.Context(),tt.principal))
+			if tt.header != "" {
+				r.Header.Set("X-Tenant-ID",tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w,r)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d",w.Code,tt.want)
+			}
+			if w.Code == http.StatusOK && seen != home {
+				t.Errorf("handler ran for dealership %v, want %v",seen,home)
+			}
+		})
+	}
+}
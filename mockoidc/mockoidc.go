@@ -0,0 +1,224 @@
+// Package mockoidc is a minimal OpenID Connect provider for exercising the
+// CarZone login flow locally and in tests. It approves every authorization
+// request as the configured user and supports only the authorization code
+// flow with S256 PKCE.
+package mockoidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const keyID = "mockoidc"
+
+type User struct {
+	Subject string
+	Username string
+	Email string
+	Groups []string
+}
+
+type authRequest struct {
+	redirectURI string
+	nonce string
+	challenge string
+	user User
+}
+
+type Server struct {
+	Issuer string
+	ClientID string
+	ClientSecret string
+	User User
+
+	key *rsa.PrivateKey
+	mu sync.Mutex
+	codes map[string]authRequest
+}
+
+func New(clientID,clientSecret string,user User) (*Server,error) {
+	key,err := rsa.GenerateKey(rand.Reader,2048)
+	if err != nil {
+		return nil,err
+	}
+	return &Server{
+		ClientID: clientID,
+		ClientSecret: clientSecret,
+		User: user,
+		key: key,
+		codes: make(map[string]authRequest),
+	},nil
+}
+
+// Start serves the provider on a random local port and sets Issuer to its URL.
+func (s *Server)Start() *httptest.Server {
+	srv := httptest.NewServer(s.Handler())
+	s.Issuer = srv.URL
+	return srv
+}
+
+func (s *Server)Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration",s.discovery)
+	mux.HandleFunc("GET /authorize",s.authorize)
+	mux.HandleFunc("POST /token",s.token)
+	mux.HandleFunc("GET /jwks",s.jwks)
+	return mux
+}
+
+// IssueToken signs an id_token for user directly, for tests that only need a bearer token.
+func (s *Server)IssueToken(user User,nonce string) (string,error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss": s.Issuer,
+		"sub": user.Subject,
+		"aud": s.ClientID,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+		"preferred_username": user.Username,
+		"email": user.Email,
+		"groups": user.Groups,
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256,claims)
+	token.Header["kid"] = keyID
+	return token.SignedString(s.key)
+}
+
+func (s *Server)discovery(w http.ResponseWriter,r *http.Request) {
+	writeJSON(w,http.StatusOK,map[string]interface{}{
+		"issuer": s.Issuer,
+		"authorization_endpoint": s.Issuer + "/authorize",
+		"token_endpoint": s.Issuer + "/token",
+		"jwks_uri": s.Issuer + "/jwks",
+		"response_types_supported": []string{"code"},
+		"subject_types_supported": []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported": []string{"S256"},
+	})
+}
+
+func (s *Server)authorize(w http.ResponseWriter,r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != s.ClientID || query.Get("response_type") != "code" {
+		http.Error(w,"invalid authorization request",http.StatusBadRequest)
+		return
+	}
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w,"PKCE with S256 is required",http.StatusBadRequest)
+		return
+	}
+	redirectURI,err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" {
+		http.Error(w,"invalid redirect_uri",http.StatusBadRequest)
+		return
+	}
+	code := randomString()
+	s.mu.Lock()
+	s.codes[code] = authRequest{
+		redirectURI: query.Get("redirect_uri"),
+		nonce: query.Get("nonce"),
+		challenge: query.Get("code_challenge"),
+		user: s.User,
+	}
+	s.mu.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code",code)
+	params.Set("state",query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+	http.Redirect(w,r,redirectURI.String(),http.StatusFound)
+}
+
+func (s *Server)token(w http.ResponseWriter,r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w,"invalid_request")
+		return
+	}
+	clientID,clientSecret,ok := r.BasicAuth()
+	if !ok {
+		clientID,clientSecret = r.PostForm.Get("client_id"),r.PostForm.Get("client_secret")
+	}
+	if clientID != s.ClientID || clientSecret != s.ClientSecret {
+		tokenError(w,"invalid_client")
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w,"unsupported_grant_type")
+		return
+	}
+	code := r.PostForm.Get("code")
+	s.mu.Lock()
+	req,ok := s.codes[code]
+	delete(s.codes,code)
+	s.mu.Unlock()
+	if !ok || req.redirectURI != r.PostForm.Get("redirect_uri") {
+		tokenError(w,"invalid_grant")
+		return
+	}
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != req.challenge {
+		tokenError(w,"invalid_grant")
+		return
+	}
+	idToken,err := s.IssueToken(req.user,req.nonce)
+	if err != nil {
+		log.Println("Error signing id_token: ",err)
+		tokenError(w,"server_error")
+		return
+	}
+	writeJSON(w,http.StatusOK,map[string]interface{}{
+		"access_token": randomString(),
+		"token_type": "Bearer",
+		"expires_in": 3600,
+		"id_token": idToken,
+	})
+}
+
+func (s *Server)jwks(w http.ResponseWriter,r *http.Request) {
+	pub := s.key.PublicKey
+	writeJSON(w,http.StatusOK,map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n": base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func tokenError(w http.ResponseWriter,code string) {
+	writeJSON(w,http.StatusBadRequest,map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter,status int,v interface{}) {
+	w.Header().Set("Content-Type","application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println("Error writing messages ",err)
+	}
+}
+
+func randomString() string {
+	b := make([]byte,24)
+	if _,err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ScopeCarsRead = "cars:read"
+	ScopeCarsWrite = "cars:write"
+	ScopeCarsReserve = "cars:reserve"
+	ScopeEnginesRead = "engines:read"
+	ScopeEnginesWrite = "engines:write"
+	ScopeAPIKeysManage = "api_keys:manage"
+	ScopeDealershipsManage = "dealerships:manage"
+	ScopeOrdersRead = "orders:read"
+	ScopeOrdersWrite = "orders:write"
+	ScopeWebhooksManage = "webhooks:manage"
+	ScopeCatalogueManage = "catalogue:manage"
+)
+
+var AllScopes = []string{ScopeCarsRead,ScopeCarsWrite,ScopeCarsReserve,ScopeEnginesRead,ScopeEnginesWrite,ScopeAPIKeysManage,ScopeDealershipsManage,ScopeOrdersRead,ScopeOrdersWrite,ScopeWebhooksManage,ScopeCatalogueManage}
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	ErrInvalidAPIKeyRequest = errors.New("invalid api key request")
+)
+
+type APIKey struct {
+	ID uuid.UUID `json:"id"`
+	Name string `json:"name"`
+	Prefix string `json:"prefix"`
+	Scopes []string `json:"scopes"`
+	CreatedBy string `json:"created_by"`
+	TenantID uuid.NullUUID `json:"tenant_id"`
+	CreatedAt time.Time `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+// IssuedAPIKey is returned once on creation, the plain key is never stored.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type APIKeyRequest struct {
+	Name string `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+func (k *APIKey) HasScope(scope string) bool {
+	for _,s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func ValidateAPIKeyRequest(keyReq *APIKeyRequest) error {
+	if keyReq.Name == "" {
+		return fmt.Errorf("%w: name is required",ErrInvalidAPIKeyRequest)
+	}
+	if len(keyReq.Scopes) == 0 {
+		return fmt.Errorf("%w: at least one scope is required",ErrInvalidAPIKeyRequest)
+	}
+	for _,scope := range keyReq.Scopes {
+		if err := validateScope(scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func validateScope(scope string) error {
+	for _,v := range AllScopes {
+		if v == scope {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: invalid scope %s",ErrInvalidAPIKeyRequest,scope)
+}
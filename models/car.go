@@ -2,7 +2,9 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,24 +14,66 @@ var (
 )
 type Car struct {
 	ID uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	CreatedBy string `json:"created_by"`
 	Name string `json:"name"`
 	Year string `json:"year"`
 	Brand string `json:"brand"`
+	Model string `json:"model,omitempty"`
 	FuelType string `json:"fuelType"`
 	Engine Engine `json:"engine"`
 	Price float64 `json:"price"`
+	VIN string `json:"vin,omitempty"`
+	OdometerKm int `json:"odometer_km"`
+	Transmission string `json:"transmission,omitempty"`
+	Drivetrain string `json:"drivetrain,omitempty"`
+	Colour string `json:"colour,omitempty"`
+	BodyType string `json:"body_type,omitempty"`
+	Condition string `json:"condition,omitempty"`
+	Features []string `json:"features"`
+	Status string `json:"status"`
+	ReservedBy string `json:"reserved_by,omitempty"`
+	ReservedUntil *time.Time `json:"reserved_until,omitempty"`
+	SoldAt *time.Time `json:"sold_at,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+// LastModified covers the embedded engine specs as well as the car itself,
+// whose UpdatedAt the store moves up to the expiry of a lapsed reservation.
+func (c *Car)LastModified() time.Time {
+	if c.Engine.UpdatedAt.After(c.UpdatedAt) {
+		return c.Engine.UpdatedAt
+	}
+	return c.UpdatedAt
+}
 type CarRequest struct {
 	Name string `json:"name"`
 	Year string `json:"year"`
 	Brand string `json:"brand"`
+	Model string `json:"model,omitempty"`
 	FuelType string `json:"fuelType"`
 	Engine Engine `json:"engine"`
 	Price float64 `json:"price"`
+	VIN string `json:"vin,omitempty"`
+	OdometerKm int `json:"odometer_km"`
+	Transmission string `json:"transmission,omitempty"`
+	Drivetrain string `json:"drivetrain,omitempty"`
+	Colour string `json:"colour,omitempty"`
+	BodyType string `json:"body_type,omitempty"`
+	Condition string `json:"condition,omitempty"`
+	Features []string `json:"features,omitempty"`
+	// only draft or available, later statuses are reached through the status endpoints
+	Status string `json:"status,omitempty"`
 }
-func ValidateRequest(carReq *CarRequest) error {
+// ValidateRequest normalizes the VIN and features before checking them. The
+// brand and model are checked against the catalogue and take its spelling,
+// a nil catalogue skips that check.
+func ValidateRequest(carReq *CarRequest,catalogue *Catalogue) error {
+	carReq.Brand = strings.TrimSpace(carReq.Brand)
+	carReq.Model = strings.TrimSpace(carReq.Model)
+	carReq.VIN = NormalizeVIN(carReq.VIN)
+	carReq.Colour = strings.TrimSpace(carReq.Colour)
+	carReq.Features = NormalizeFeatures(carReq.Features)
 	if err := validateName(carReq.Name); err != nil {
 		return err
 	}
@@ -39,6 +83,14 @@ func ValidateRequest(carReq *CarRequest) error {
 	if err := validateBrand(carReq.Brand); err != nil {
 		return err
 	}
+	if err := validateModel(carReq.Model); err != nil {
+		return err
+	}
+	if catalogue != nil {
+		if err := catalogue.resolve(carReq); err != nil {
+			return err
+		}
+	}
 	if err := validateFuelType(carReq.FuelType); err != nil {
 		return err
 	}
@@ -48,6 +100,12 @@ func ValidateRequest(carReq *CarRequest) error {
 	if err := validatePrice(carReq.Price); err != nil {
 		return err
 	}
+	if err := validateInitialStatus(carReq.Status); err != nil {
+		return err
+	}
+	if err := validateDetails(carReq); err != nil {
+		return err
+	}
 	return nil
 }
 func validateName(name string) error {
@@ -77,27 +135,41 @@ func validateBrand(brand string) error {
 	}
 	return nil
 }
+func validateModel(model string) error {
+	if len(model) > MaxCatalogueNameLength {
+		return fmt.Errorf("model must be at most %d characters",MaxCatalogueNameLength)
+	}
+	return nil
+}
 func validateFuelType(fuelType string) error {
-	validateFuelTypes := []string{"Persol","Diesel","Electric","Hybrid"}
-	for _,v := range validateFuelTypes {
+	for _,v := range FuelTypes {
 		if v == fuelType {
 			return nil
 		}
 	}
 	return errors.New("invalid fuel type")
 }
+// validateEngine only needs the engine's id, the store reads the specs from the
+// engine itself. Specs a client does send still have to make sense.
 func validateEngine(engine Engine) error {
 	if engine.EngineID == uuid.Nil {
 		return errors.New("engine is required")
 	}
-	if engine.Displacement <= 0 {
-		return errors.New("displacement must be greater than 0")
+	if engine.Displacement < 0 {
+		return errors.New("displacement must not be negative")
 	}
-	if engine.NoOfCylinders <= 0 {
-		return errors.New("no_of_cylinders must be greater than 0")
+	if engine.NoOfCylinders < 0 {
+		return errors.New("no_of_cylinders must not be negative")
 	}
-	if engine.CarRange <= 0 {
-		return errors.New("car_range must be greater than 0")
+	if engine.CarRange < 0 {
+		return errors.New("car_range must not be negative")
+	}
+	return nil
+}
+
+func validateInitialStatus(status string) error {
+	if status != "" && status != CarStatusDraft && status != CarStatusAvailable {
+		return errors.New("status must be draft or available")
 	}
 	return nil
 }
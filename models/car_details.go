@@ -0,0 +1,201 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	TransmissionManual = "manual"
+	TransmissionAutomatic = "automatic"
+	TransmissionCVT = "cvt"
+	TransmissionDCT = "dct"
+)
+
+const (
+	DrivetrainFWD = "fwd"
+	DrivetrainRWD = "rwd"
+	DrivetrainAWD = "awd"
+	Drivetrain4WD = "4wd"
+)
+
+const (
+	ConditionNew = "new"
+	ConditionUsed = "used"
+	ConditionCertifiedPreOwned = "certified_pre_owned"
+)
+
+// the listing details a car can take, empty is always allowed and means not given
+var (
+	Transmissions = []string{TransmissionManual,TransmissionAutomatic,TransmissionCVT,TransmissionDCT}
+	Drivetrains = []string{DrivetrainFWD,DrivetrainRWD,DrivetrainAWD,Drivetrain4WD}
+	BodyTypes = []string{"sedan","hatchback","wagon","coupe","convertible","suv","pickup","van","minivan"}
+	Conditions = []string{ConditionNew,ConditionUsed,ConditionCertifiedPreOwned}
+)
+
+const (
+	MaxOdometerKm = 2000000
+	MaxColourLength = 50
+	MaxFeatures = 50
+	MaxFeatureLength = 100
+)
+
+var (
+	ErrInvalidVIN = errors.New("invalid VIN")
+	ErrInvalidCarDetails = errors.New("invalid car details")
+	ErrVINTaken = errors.New("a car with this VIN is already listed")
+	ErrInvalidCarFilter = errors.New("invalid car filter")
+)
+
+// vinValues transliterates VIN characters for the check digit, I, O and Q
+// never appear in a VIN.
+var vinValues = map[rune]int{
+	'A': 1,'B': 2,'C': 3,'D': 4,'E': 5,'F': 6,'G': 7,'H': 8,
+	'J': 1,'K': 2,'L': 3,'M': 4,'N': 5,'P': 7,'R': 9,
+	'S': 2,'T': 3,'U': 4,'V': 5,'W': 6,'X': 7,'Y': 8,'Z': 9,
+	'0': 0,'1': 1,'2': 2,'3': 3,'4': 4,'5': 5,'6': 6,'7': 7,'8': 8,'9': 9,
+}
+
+var vinWeights = [17]int{8,7,6,5,4,3,2,10,0,9,8,7,6,5,4,3,2}
+
+// NormalizeVIN trims and upper-cases a VIN, which is how VINs are stored.
+func NormalizeVIN(vin string) string {
+	return strings.ToUpper(strings.TrimSpace(vin))
+}
+
+// ValidateVIN checks a normalized VIN's length, alphabet and check digit,
+// the ninth character. The check digit is only mandatory for vehicles built
+// for North America and China, elsewhere manufacturers use the ninth
+// character freely, so it is only checked when the VIN starts with 1-5 or L.
+func ValidateVIN(vin string) error {
+	if len(vin) != 17 {
+		return fmt.Errorf("%w: must be 17 characters",ErrInvalidVIN)
+	}
+	sum := 0
+	for i,c := range vin {
+		value,ok := vinValues[c]
+		if !ok {
+			return fmt.Errorf("%w: character %q not allowed",ErrInvalidVIN,c)
+		}
+		sum += value * vinWeights[i]
+	}
+	if !strings.ContainsRune("12345L",rune(vin[0])) {
+		return nil
+	}
+	check := byte('0' + sum%11)
+	if sum%11 == 10 {
+		check = 'X'
+	}
+	if vin[8] != check {
+		return fmt.Errorf("%w: check digit is %c, expected %c",ErrInvalidVIN,vin[8],check)
+	}
+	return nil
+}
+
+// NormalizeFeatures trims the features, dropping empty ones and repeats that
+// differ only in case.
+func NormalizeFeatures(features []string) []string {
+	seen := make(map[string]bool)
+	out := []string{}
+	for _,feature := range features {
+		feature = strings.TrimSpace(feature)
+		key := strings.ToLower(feature)
+		if feature == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out,feature)
+	}
+	return out
+}
+
+func validateVIN(vin string) error {
+	if vin == "" {
+		return nil
+	}
+	return ValidateVIN(vin)
+}
+
+func validateOdometer(km int) error {
+	if km < 0 || km > MaxOdometerKm {
+		return fmt.Errorf("odometer_km must be between 0 and %d",MaxOdometerKm)
+	}
+	return nil
+}
+
+func validateOneOf(field,value string,allowed []string) error {
+	if value == "" {
+		return nil
+	}
+	for _,v := range allowed {
+		if v == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s must be one of %s",field,strings.Join(allowed,", "))
+}
+
+func validateColour(colour string) error {
+	if len(colour) > MaxColourLength {
+		return fmt.Errorf("colour must be at most %d characters",MaxColourLength)
+	}
+	return nil
+}
+
+func validateFeatures(features []string) error {
+	if len(features) > MaxFeatures {
+		return fmt.Errorf("at most %d features",MaxFeatures)
+	}
+	for _,feature := range features {
+		if len(feature) > MaxFeatureLength {
+			return fmt.Errorf("features must be at most %d characters",MaxFeatureLength)
+		}
+	}
+	return nil
+}
+
+// validateDetails fails with ErrInvalidVIN or ErrInvalidCarDetails.
+func validateDetails(carReq *CarRequest) error {
+	if err := validateVIN(carReq.VIN); err != nil {
+		return err
+	}
+	for _,err := range []error{
+		validateOdometer(carReq.OdometerKm),
+		validateOneOf("transmission",carReq.Transmission,Transmissions),
+		validateOneOf("drivetrain",carReq.Drivetrain,Drivetrains),
+		validateColour(carReq.Colour),
+		validateOneOf("body_type",carReq.BodyType,BodyTypes),
+		validateOneOf("condition",carReq.Condition,Conditions),
+		validateFeatures(carReq.Features),
+	} {
+		if err != nil {
+			return fmt.Errorf("%w: %v",ErrInvalidCarDetails,err)
+		}
+	}
+	return nil
+}
+
+// ValidateCarFilter checks the pagination and the listing details a filter
+// asks for, the filter's VIN and features are normalized like a car's.
+func ValidateCarFilter(filter *CarFilter) error {
+	if err := ValidatePagination(*filter); err != nil {
+		return err
+	}
+	filter.VIN = NormalizeVIN(filter.VIN)
+	filter.Features = NormalizeFeatures(filter.Features)
+	if filter.MaxOdometerKm < 0 {
+		return fmt.Errorf("%w: max_odometer_km must not be negative",ErrInvalidCarFilter)
+	}
+	for _,check := range []struct{ field,value string; allowed []string }{
+		{"transmission",filter.Transmission,Transmissions},
+		{"drivetrain",filter.Drivetrain,Drivetrains},
+		{"body_type",filter.BodyType,BodyTypes},
+		{"condition",filter.Condition,Conditions},
+	} {
+		if err := validateOneOf(check.field,check.value,check.allowed); err != nil {
+			return fmt.Errorf("%w: %v",ErrInvalidCarFilter,err)
+		}
+	}
+	return nil
+}
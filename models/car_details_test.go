@@ -0,0 +1,82 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateVIN(t *testing.T) {
+	tests := []struct {
+		name string
+		vin string
+		wantErr bool
+	}{
+		{name: "north american",vin: "1HGCM82633A004352"},
+		{name: "check digit X",vin: "1M8GDM9AXKP042788"},
+		{name: "chinese",vin: "LVSHCAMB1CE054249"},
+		{name: "european without check digit",vin: "WVWZZZ1JZXW000001"},
+		{name: "wrong check digit",vin: "5YJ3E1EA7KF317000",wantErr: true},
+		{name: "too short",vin: "1HGCM82633A00435",wantErr: true},
+		{name: "too long",vin: "1HGCM82633A0043521",wantErr: true},
+		{name: "letter O",vin: "1HGCM82633A0O4352",wantErr: true},
+		{name: "lower case",vin: "1hgcm82633a004352",wantErr: true},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			err := ValidateVIN(tt.vin)
+			if tt.wantErr {
+				if !errors.Is(err,ErrInvalidVIN) {
+					t.Errorf("ValidateVIN(%q) = %v, want %v",tt.vin,err,ErrInvalidVIN)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ValidateVIN(%q) = %v",tt.vin,err)
+			}
+		})
+	}
+}
+
+func TestValidateRequestDetails(t *testing.T) {
+	valid := func() *CarRequest {
+		return &CarRequest{
+			Name: "Corolla",
+			Year: "2020",
+			Brand: "Toyota",
+			FuelType: FuelTypes[0],
+			Engine: Engine{EngineID: uuid.New()},
+			Price: 15000,
+		}
+	}
+	tests := []struct {
+		name string
+		change func(*CarRequest)
+		want error
+	}{
+		{name: "valid",change: func(*CarRequest) {}},
+		{name: "normalized VIN",change: func(r *CarRequest) { r.VIN = " 1hgcm82633a004352 " }},
+		{name: "bad VIN",change: func(r *CarRequest) { r.VIN = "1HGCM82643A004352" },want: ErrInvalidVIN},
+		{name: "transmission",change: func(r *CarRequest) { r.Transmission = "sequential" },want: ErrInvalidCarDetails},
+		{name: "drivetrain",change: func(r *CarRequest) { r.Drivetrain = "6wd" },want: ErrInvalidCarDetails},
+		{name: "condition",change: func(r *CarRequest) { r.Condition = "mint" },want: ErrInvalidCarDetails},
+		{name: "odometer",change: func(r *CarRequest) { r.OdometerKm = -1 },want: ErrInvalidCarDetails},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			carReq := valid()
+			tt.change(carReq)
+			err := ValidateRequest(carReq,nil)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("ValidateRequest = %v",err)
+				}
+				return
+			}
+			if !errors.Is(err,tt.want) {
+				t.Errorf("ValidateRequest = %v, want %v",err,tt.want)
+			}
+		})
+	}
+}
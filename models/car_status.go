@@ -0,0 +1,115 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	CarStatusDraft = "draft"
+	CarStatusAvailable = "available"
+	CarStatusReserved = "reserved"
+	CarStatusSold = "sold"
+	CarStatusArchived = "archived"
+)
+
+const (
+	DefaultReservationPeriod = 48 * time.Hour
+	MaxReservationPeriod = 14 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidTransition = errors.New("car status transition not allowed")
+	ErrStatusConflict = errors.New("car status was changed by another request")
+	ErrInvalidReservationExpiry = errors.New("reservation expiry must be in the future and within 14 days")
+	ErrInvalidPagination = errors.New("limit must not be negative or over 100, offset not negative")
+)
+
+const MaxPageSize = 100
+
+var carTransitions = map[string][]string{
+	CarStatusDraft: {CarStatusAvailable,CarStatusArchived},
+	CarStatusAvailable: {CarStatusDraft,CarStatusReserved,CarStatusSold,CarStatusArchived},
+	CarStatusReserved: {CarStatusAvailable,CarStatusSold},
+	CarStatusSold: {CarStatusArchived},
+	CarStatusArchived: {CarStatusDraft},
+}
+
+func CanTransition(from,to string) bool {
+	for _,next := range carTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// VisibleToBuyer reports whether userID, browsing inventory rather than
+// managing it, may see car: available cars and their own reservations.
+func VisibleToBuyer(car *Car,userID string) bool {
+	if car.Status == CarStatusAvailable {
+		return true
+	}
+	return car.Status == CarStatusReserved && car.ReservedBy == userID
+}
+
+// CarStatusChange is the new status of a car along with its reservation, if any.
+type CarStatusChange struct {
+	Status string
+	ReservedBy string
+	ReservedUntil *time.Time
+}
+
+type StatusRequest struct {
+	Status string `json:"status"`
+}
+
+type ReservationRequest struct {
+	// defaults to DefaultReservationPeriod from now
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CarFilter narrows car listings, empty fields match everything.
+type CarFilter struct {
+	Statuses []string
+	VIN string
+	Transmission string
+	Drivetrain string
+	BodyType string
+	Condition string
+	// matched ignoring case
+	Colour string
+	// zero for no limit
+	MaxOdometerKm int
+	// cars having every one of these
+	Features []string
+	// zero returns every match, newest first
+	Limit int
+	Offset int
+}
+
+func ValidatePagination(filter CarFilter) error {
+	if filter.Limit < 0 || filter.Limit > MaxPageSize || filter.Offset < 0 {
+		return ErrInvalidPagination
+	}
+	return nil
+}
+
+func ValidateStatus(status string) error {
+	if _,ok := carTransitions[status]; !ok {
+		return errors.New("invalid status")
+	}
+	return nil
+}
+
+// ReservationExpiry resolves the requested expiry against now.
+func ReservationExpiry(req *ReservationRequest,now time.Time) (time.Time,error) {
+	if req == nil || req.ExpiresAt == nil {
+		return now.Add(DefaultReservationPeriod),nil
+	}
+	expiresAt := *req.ExpiresAt
+	if !expiresAt.After(now) || expiresAt.Sub(now) > MaxReservationPeriod {
+		return time.Time{},ErrInvalidReservationExpiry
+	}
+	return expiresAt,nil
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from string
+		to string
+		want bool
+	}{
+		{from: CarStatusDraft,to: CarStatusAvailable,want: true},
+		{from: CarStatusDraft,to: CarStatusReserved,want: false},
+		{from: CarStatusAvailable,to: CarStatusReserved,want: true},
+		{from: CarStatusAvailable,to: CarStatusSold,want: true},
+		{from: CarStatusReserved,to: CarStatusAvailable,want: true},
+		{from: CarStatusReserved,to: CarStatusReserved,want: false},
+		{from: CarStatusReserved,to: CarStatusArchived,want: false},
+		{from: CarStatusSold,to: CarStatusAvailable,want: false},
+		{from: CarStatusSold,to: CarStatusArchived,want: true},
+		{from: CarStatusArchived,to: CarStatusDraft,want: true},
+		{from: CarStatusArchived,to: CarStatusAvailable,want: false},
+		{from: "unknown",to: CarStatusDraft,want: false},
+	}
+	for _,tt := range tests {
+		if got := CanTransition(tt.from,tt.to); got != tt.want {
+			t.Errorf("CanTransition(%q, %q) = %v, want %v",tt.from,tt.to,got,tt.want)
+		}
+	}
+}
+
+func TestReservationExpiry(t *testing.T) {
+	now := time.Date(2024,3,1,12,0,0,0,time.UTC)
+	at := func(d time.Duration) *ReservationRequest {
+		expiresAt := now.Add(d)
+		return &ReservationRequest{ExpiresAt: &expiresAt}
+	}
+	tests := []struct {
+		name string
+		req *ReservationRequest
+		want time.Time
+		wantErr error
+	}{
+		{name: "no request",req: nil,want: now.Add(DefaultReservationPeriod)},
+		{name: "no expiry",req: &ReservationRequest{},want: now.Add(DefaultReservationPeriod)},
+		{name: "within the limit",req: at(24 * time.Hour),want: now.Add(24 * time.Hour)},
+		{name: "at the limit",req: at(MaxReservationPeriod),want: now.Add(MaxReservationPeriod)},
+		{name: "past the limit",req: at(MaxReservationPeriod + time.Second),wantErr: ErrInvalidReservationExpiry},
+		{name: "now",req: at(0),wantErr: ErrInvalidReservationExpiry},
+		{name: "in the past",req: at(-time.Hour),wantErr: ErrInvalidReservationExpiry},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			got,err := ReservationExpiry(tt.req,now)
+			if !errors.Is(err,tt.wantErr) {
+				t.Fatalf("error = %v, want %v",err,tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("expiry = %v, want %v",got,tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxCatalogueNameLength = 100
+	MaxAliases = 20
+)
+
+var (
+	ErrInvalidCatalogueEntry = errors.New("invalid catalogue entry")
+	ErrCatalogueConflict = errors.New("the name or an alias already belongs to another entry")
+	ErrCatalogueEntryInUse = errors.New("the entry is still used by cars")
+	ErrUnknownBrand = errors.New("brand is not in the catalogue")
+	ErrUnknownModel = errors.New("model is not in the catalogue for this brand")
+)
+
+// Brand is a make in the reference catalogue car listings are checked against.
+type Brand struct {
+	ID uuid.UUID `json:"id"`
+	Name string `json:"name"`
+	// other spellings that resolve to this brand
+	Aliases []string `json:"aliases"`
+	Models []CarModel `json:"models"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CarModel struct {
+	ID uuid.UUID `json:"id"`
+	BrandID uuid.UUID `json:"brand_id"`
+	Name string `json:"name"`
+	Aliases []string `json:"aliases"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type BrandRequest struct {
+	Name string `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+type CarModelRequest struct {
+	Name string `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// CatalogueKey is what names and aliases are matched on, lower case letters
+// and digits only, so "BMW", "bmw" and "B.M.W." are the same brand. The
+// 0004 migration computes the same key in SQL.
+func CatalogueKey(name string) string {
+	var b strings.Builder
+	for _,r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ValidateBrandRequest trims the name and drops aliases that are empty or
+// spell the name or another alias.
+func ValidateBrandRequest(brandReq *BrandRequest) error {
+	var err error
+	brandReq.Name,brandReq.Aliases,err = validateCatalogueEntry(brandReq.Name,brandReq.Aliases)
+	return err
+}
+
+func ValidateCarModelRequest(modelReq *CarModelRequest) error {
+	var err error
+	modelReq.Name,modelReq.Aliases,err = validateCatalogueEntry(modelReq.Name,modelReq.Aliases)
+	return err
+}
+
+func validateCatalogueEntry(name string,aliases []string) (string,[]string,error) {
+	name = strings.TrimSpace(name)
+	if CatalogueKey(name) == "" {
+		return "",nil,fmt.Errorf("%w: name must contain a letter or digit",ErrInvalidCatalogueEntry)
+	}
+	if len(name) > MaxCatalogueNameLength {
+		return "",nil,fmt.Errorf("%w: name must be at most %d characters",ErrInvalidCatalogueEntry,MaxCatalogueNameLength)
+	}
+	seen := map[string]bool{CatalogueKey(name): true}
+	normalized := []string{}
+	for _,alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		key := CatalogueKey(alias)
+		if key == "" || seen[key] {
+			continue
+		}
+		if len(alias) > MaxCatalogueNameLength {
+			return "",nil,fmt.Errorf("%w: aliases must be at most %d characters",ErrInvalidCatalogueEntry,MaxCatalogueNameLength)
+		}
+		seen[key] = true
+		normalized = append(normalized,alias)
+	}
+	if len(normalized) > MaxAliases {
+		return "",nil,fmt.Errorf("%w: at most %d aliases",ErrInvalidCatalogueEntry,MaxAliases)
+	}
+	return name,normalized,nil
+}
+
+// Catalogue looks brands and models up by any of their spellings.
+type Catalogue struct {
+	brands map[string]*Brand
+	models map[uuid.UUID]map[string]*CarModel
+}
+
+func NewCatalogue(brands []Brand) *Catalogue {
+	c := &Catalogue{
+		brands: make(map[string]*Brand),
+		models: make(map[uuid.UUID]map[string]*CarModel),
+	}
+	for i := range brands {
+		brand := &brands[i]
+		for _,name := range append([]string{brand.Name},brand.Aliases...) {
+			c.brands[CatalogueKey(name)] = brand
+		}
+		byKey := make(map[string]*CarModel)
+		for j := range brand.Models {
+			model := &brand.Models[j]
+			for _,name := range append([]string{model.Name},model.Aliases...) {
+				byKey[CatalogueKey(name)] = model
+			}
+		}
+		c.models[brand.ID] = byKey
+	}
+	return c
+}
+
+// Brand finds a brand by any of its spellings, a nil catalogue knows none.
+func (c *Catalogue)Brand(name string) (Brand,bool) {
+	if c == nil {
+		return Brand{},false
+	}
+	brand,ok := c.brands[CatalogueKey(name)]
+	if !ok {
+		return Brand{},false
+	}
+	return *brand,true
+}
+
+func (c *Catalogue)Model(brand Brand,name string) (CarModel,bool) {
+	if c == nil {
+		return CarModel{},false
+	}
+	model,ok := c.models[brand.ID][CatalogueKey(name)]
+	if !ok {
+		return CarModel{},false
+	}
+	return *model,true
+}
+
+// resolve replaces the brand and model with their catalogue names. Brands
+// without any models listed yet accept any model.
+func (c *Catalogue)resolve(carReq *CarRequest) error {
+	brand,ok := c.Brand(carReq.Brand)
+	if !ok {
+		return fmt.Errorf("%w: %s",ErrUnknownBrand,carReq.Brand)
+	}
+	carReq.Brand = brand.Name
+	if carReq.Model == "" || len(brand.Models) == 0 {
+		return nil
+	}
+	model,ok := c.Model(brand,carReq.Model)
+	if !ok {
+		return fmt.Errorf("%w: %s %s",ErrUnknownModel,brand.Name,carReq.Model)
+	}
+	carReq.Model = model.Name
+	return nil
+}
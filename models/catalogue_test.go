@@ -0,0 +1,91 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCatalogueKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "BMW",want: "bmw"},
+		{name: "B.M.W.",want: "bmw"},
+		{name: " bmw ",want: "bmw"},
+		{name: "Mercedes-Benz",want: "mercedesbenz"},
+		{name: "Mercedes Benz",want: "mercedesbenz"},
+		{name: "Rolls-Royce",want: "rollsroyce"},
+		{name: "3 Series",want: "3series"},
+		{name: "Škoda",want: "škoda"},
+		{name: "Citroën",want: "citroën"},
+		{name: "-- ..",want: ""},
+		{name: "",want: ""},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			if got := CatalogueKey(tt.name); got != tt.want {
+				t.Errorf("CatalogueKey(%q) = %q, want %q",tt.name,got,tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBrandRequest(t *testing.T) {
+	brandReq := &BrandRequest{Name: " Mercedes-Benz ",Aliases: []string{"Mercedes Benz","Mercedes"," ","mercedes","Benz"}}
+	if err := ValidateBrandRequest(brandReq); err != nil {
+		t.Fatal(err)
+	}
+	if brandReq.Name != "Mercedes-Benz" {
+		t.Errorf("name = %q, want Mercedes-Benz",brandReq.Name)
+	}
+	if want := []string{"Mercedes","Benz"}; !reflect.DeepEqual(brandReq.Aliases,want) {
+		t.Errorf("aliases = %q, want %q",brandReq.Aliases,want)
+	}
+	if err := ValidateBrandRequest(&BrandRequest{Name: "..."}); !errors.Is(err,ErrInvalidCatalogueEntry) {
+		t.Errorf("name without letters or digits: %v, want %v",err,ErrInvalidCatalogueEntry)
+	}
+}
+
+func TestCatalogueResolve(t *testing.T) {
+	bmw := Brand{ID: uuid.New(),Name: "BMW",Aliases: []string{"Bayerische Motoren Werke"},Models: []CarModel{{Name: "3 Series",Aliases: []string{"3er"}}}}
+	tesla := Brand{ID: uuid.New(),Name: "Tesla"}
+	catalogue := NewCatalogue([]Brand{bmw,tesla})
+
+	tests := []struct {
+		name string
+		brand string
+		model string
+		wantBrand string
+		wantModel string
+		wantErr error
+	}{
+		{name: "alias",brand: "b.m.w.",model: "3-series",wantBrand: "BMW",wantModel: "3 Series"},
+		{name: "model alias",brand: "Bayerische Motoren Werke",model: "3ER",wantBrand: "BMW",wantModel: "3 Series"},
+		{name: "no model",brand: "bmw",wantBrand: "BMW"},
+		{name: "brand without models",brand: "TESLA",model: "Model 3",wantBrand: "Tesla",wantModel: "Model 3"},
+		{name: "unknown brand",brand: "Trabant",wantErr: ErrUnknownBrand},
+		{name: "unknown model",brand: "BMW",model: "Corolla",wantErr: ErrUnknownModel},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			carReq := &CarRequest{Brand: tt.brand,Model: tt.model}
+			err := catalogue.resolve(carReq)
+			if tt.wantErr != nil {
+				if !errors.Is(err,tt.wantErr) {
+					t.Fatalf("resolve = %v, want %v",err,tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if carReq.Brand != tt.wantBrand || carReq.Model != tt.wantModel {
+				t.Errorf("resolved to %q %q, want %q %q",carReq.Brand,carReq.Model,tt.wantBrand,tt.wantModel)
+			}
+		})
+	}
+}
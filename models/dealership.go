@@ -0,0 +1,40 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTenantRequired = errors.New("a dealership is required, set X-Tenant-ID")
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Dealership is a tenant, every car and engine belongs to exactly one.
+type Dealership struct {
+	ID uuid.UUID `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type DealershipRequest struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+func ValidateDealershipRequest(dealershipReq *DealershipRequest) error {
+	if dealershipReq.Name == "" {
+		return errors.New("name is required")
+	}
+	if !slugPattern.MatchString(dealershipReq.Slug) {
+		return errors.New("slug must be lowercase letters, digits and dashes")
+	}
+	return nil
+}
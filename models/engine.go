@@ -2,31 +2,133 @@ package models
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const (
+	FuelTypePetrol = "Petrol"
+	FuelTypeDiesel = "Diesel"
+	FuelTypeElectric = "Electric"
+	FuelTypeHybrid = "Hybrid"
+)
+
+// the fuel types of cars and the powertrains of engines, an engine may leave it empty
+var (
+	FuelTypes = []string{FuelTypePetrol,FuelTypeDiesel,FuelTypeElectric,FuelTypeHybrid}
+	ChargingStandards = []string{"type1","type2","ccs1","ccs2","chademo","nacs","gbt"}
+)
+
+var (
+	ErrInvalidEngine = errors.New("invalid engine")
+	ErrEngineFuelMismatch = errors.New("the car's fuel type doesn't match its engine's")
+	ErrEngineNotFound = errors.New("engine not found")
+)
+
 type Engine struct {
 	EngineID uuid.UUID `json:"engine_id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	// empty for engines recorded before powertrains were tracked
+	FuelType string `json:"fuel_type,omitempty"`
 	Displacement int64 `json:"displacement"`
 	NoOfCylinders int64 `json:"no_of_cylinders"`
 	CarRange int64 `json:"car_range"`
+	BatteryCapacityKWh float64 `json:"battery_capacity_kwh,omitempty"`
+	MotorPowerKW int64 `json:"motor_power_kw,omitempty"`
+	ChargingStandard string `json:"charging_standard,omitempty"`
+	Horsepower int64 `json:"horsepower,omitempty"`
+	TorqueNm int64 `json:"torque_nm,omitempty"`
+	// tailpipe emissions
+	CO2GPerKm int64 `json:"co2_g_per_km,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
 }
 type EngineRequest struct {
+	FuelType string `json:"fuel_type,omitempty"`
 	Displacement int64 `json:"displacement"`
 	NoOfCylinders int64 `json:"no_of_cylinders"`
 	CarRange int64 `json:"car_range"`
+	BatteryCapacityKWh float64 `json:"battery_capacity_kwh,omitempty"`
+	MotorPowerKW int64 `json:"motor_power_kw,omitempty"`
+	ChargingStandard string `json:"charging_standard,omitempty"`
+	Horsepower int64 `json:"horsepower,omitempty"`
+	TorqueNm int64 `json:"torque_nm,omitempty"`
+	CO2GPerKm int64 `json:"co2_g_per_km,omitempty"`
 }
+// ValidateEngineRequest checks the specs against the powertrain. Combustion
+// engines need a displacement and cylinders and have no battery, electric
+// ones need a battery, motor and charging standard and have neither, hybrids
+// need both. Engines without a fuel type keep the combustion rules.
 func ValidateEngineRequest(engineRequest EngineRequest) error {
-	if err := validateDisplacement(engineRequest.Displacement); err != nil {
+	if err := validateEngineRequest(engineRequest); err != nil {
+		return fmt.Errorf("%w: %v",ErrInvalidEngine,err)
+	}
+	return nil
+}
+func validateEngineRequest(engineRequest EngineRequest) error {
+	if err := validateOneOf("fuel_type",engineRequest.FuelType,FuelTypes); err != nil {
 		return err
 	}
-	if err := validateNoOfCylinders(engineRequest.NoOfCylinders); err != nil {
+	if err := validateOneOf("charging_standard",engineRequest.ChargingStandard,ChargingStandards); err != nil {
 		return err
 	}
 	if err := validateCarRange(engineRequest.CarRange); err != nil {
 		return err
 	}
+	if err := validatePerformance(engineRequest); err != nil {
+		return err
+	}
+	switch engineRequest.FuelType {
+	case FuelTypeElectric:
+		if engineRequest.Displacement != 0 || engineRequest.NoOfCylinders != 0 {
+			return errors.New("electric engines have no displacement or cylinders")
+		}
+		if engineRequest.CO2GPerKm != 0 {
+			return errors.New("electric engines have no tailpipe emissions")
+		}
+		if engineRequest.ChargingStandard == "" {
+			return errors.New("charging_standard is required for electric engines")
+		}
+		return validateElectricMotor(engineRequest)
+	case FuelTypeHybrid:
+		if err := validateCombustion(engineRequest); err != nil {
+			return err
+		}
+		// only plug-in hybrids have a charging standard
+		return validateElectricMotor(engineRequest)
+	default:
+		if engineRequest.BatteryCapacityKWh != 0 || engineRequest.MotorPowerKW != 0 || engineRequest.ChargingStandard != "" {
+			return errors.New("only electric and hybrid engines have a battery, motor or charging standard")
+		}
+		return validateCombustion(engineRequest)
+	}
+}
+func validateCombustion(engineRequest EngineRequest) error {
+	if err := validateDisplacement(engineRequest.Displacement); err != nil {
+		return err
+	}
+	return validateNoOfCylinders(engineRequest.NoOfCylinders)
+}
+func validateElectricMotor(engineRequest EngineRequest) error {
+	if engineRequest.BatteryCapacityKWh <= 0 {
+		return errors.New("battery_capacity_kwh must be greater than 0")
+	}
+	if engineRequest.MotorPowerKW <= 0 {
+		return errors.New("motor_power_kw must be greater than 0")
+	}
+	return nil
+}
+func validatePerformance(engineRequest EngineRequest) error {
+	if engineRequest.BatteryCapacityKWh < 0 || engineRequest.MotorPowerKW < 0 {
+		return errors.New("battery_capacity_kwh and motor_power_kw must not be negative")
+	}
+	if engineRequest.Horsepower < 0 || engineRequest.TorqueNm < 0 {
+		return errors.New("horsepower and torque_nm must not be negative")
+	}
+	if engineRequest.CO2GPerKm < 0 {
+		return errors.New("co2_g_per_km must not be negative")
+	}
 	return nil
 }
 func validateDisplacement(displacement int64) error {
@@ -48,4 +150,3 @@ func validateCarRange(carRange int64) error {
 	}
 	return nil
 }
-
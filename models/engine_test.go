@@ -0,0 +1,58 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateEngineRequest(t *testing.T) {
+	petrol := EngineRequest{FuelType: FuelTypePetrol,Displacement: 1998,NoOfCylinders: 4,CarRange: 600,Horsepower: 150,CO2GPerKm: 140}
+	electric := EngineRequest{FuelType: FuelTypeElectric,CarRange: 510,BatteryCapacityKWh: 57.5,MotorPowerKW: 208,ChargingStandard: "ccs2"}
+	hybrid := EngineRequest{FuelType: FuelTypeHybrid,Displacement: 1798,NoOfCylinders: 4,CarRange: 900,BatteryCapacityKWh: 1.3,MotorPowerKW: 53}
+
+	tests := []struct {
+		name string
+		request EngineRequest
+		change func(*EngineRequest)
+		wantErr bool
+	}{
+		{name: "petrol",request: petrol},
+		{name: "diesel",request: petrol,change: func(r *EngineRequest) { r.FuelType = FuelTypeDiesel }},
+		{name: "no fuel type",request: petrol,change: func(r *EngineRequest) { r.FuelType = "" }},
+		{name: "misspelled fuel type",request: petrol,change: func(r *EngineRequest) { r.FuelType = "Persol" },wantErr: true},
+		{name: "combustion without displacement",request: petrol,change: func(r *EngineRequest) { r.Displacement = 0 },wantErr: true},
+		{name: "combustion without cylinders",request: petrol,change: func(r *EngineRequest) { r.NoOfCylinders = 0 },wantErr: true},
+		{name: "combustion with a battery",request: petrol,change: func(r *EngineRequest) { r.BatteryCapacityKWh = 10 },wantErr: true},
+		{name: "combustion with a charging standard",request: petrol,change: func(r *EngineRequest) { r.ChargingStandard = "type2" },wantErr: true},
+		{name: "no range",request: petrol,change: func(r *EngineRequest) { r.CarRange = 0 },wantErr: true},
+		{name: "negative horsepower",request: petrol,change: func(r *EngineRequest) { r.Horsepower = -1 },wantErr: true},
+		{name: "electric",request: electric},
+		{name: "electric with cylinders",request: electric,change: func(r *EngineRequest) { r.NoOfCylinders = 4 },wantErr: true},
+		{name: "electric with emissions",request: electric,change: func(r *EngineRequest) { r.CO2GPerKm = 10 },wantErr: true},
+		{name: "electric without charging standard",request: electric,change: func(r *EngineRequest) { r.ChargingStandard = "" },wantErr: true},
+		{name: "unknown charging standard",request: electric,change: func(r *EngineRequest) { r.ChargingStandard = "tesla" },wantErr: true},
+		{name: "electric without battery",request: electric,change: func(r *EngineRequest) { r.BatteryCapacityKWh = 0 },wantErr: true},
+		{name: "hybrid",request: hybrid},
+		{name: "plug-in hybrid",request: hybrid,change: func(r *EngineRequest) { r.ChargingStandard = "type2" }},
+		{name: "hybrid without motor",request: hybrid,change: func(r *EngineRequest) { r.MotorPowerKW = 0 },wantErr: true},
+		{name: "hybrid without displacement",request: hybrid,change: func(r *EngineRequest) { r.Displacement = 0 },wantErr: true},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			request := tt.request
+			if tt.change != nil {
+				tt.change(&request)
+			}
+			err := ValidateEngineRequest(request)
+			if tt.wantErr {
+				if !errors.Is(err,ErrInvalidEngine) {
+					t.Errorf("ValidateEngineRequest = %v, want %v",err,ErrInvalidEngine)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ValidateEngineRequest = %v",err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventCarCreated = "car.created"
+	EventCarUpdated = "car.updated"
+	EventCarDeleted = "car.deleted"
+	EventEngineCreated = "engine.created"
+	EventEngineUpdated = "engine.updated"
+	EventEngineDeleted = "engine.deleted"
+)
+
+var AllEventTypes = []string{EventCarCreated,EventCarUpdated,EventCarDeleted,EventEngineCreated,EventEngineUpdated,EventEngineDeleted}
+
+// Event is something that happened to a dealership's data, Data is the car or
+// engine as it was after the change.
+type Event struct {
+	ID uuid.UUID `json:"id"`
+	Type string `json:"type"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	Actor string `json:"actor"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data interface{} `json:"data"`
+}
+
+func NewEvent(eventType string,tenantID uuid.UUID,actor string,data interface{}) Event {
+	return Event{
+		ID: uuid.New(),
+		Type: eventType,
+		TenantID: tenantID,
+		Actor: actor,
+		OccurredAt: time.Now().UTC(),
+		Data: data,
+	}
+}
+
+// MatchEvent reports whether eventType matches filter, which is an event type,
+// a family such as "engine.*" or "*" for everything.
+func MatchEvent(filter string,eventType string) bool {
+	if filter == "*" || filter == eventType {
+		return true
+	}
+	family,ok := strings.CutSuffix(filter,".*")
+	return ok && strings.HasPrefix(eventType,family + ".")
+}
+
+func validateEventFilter(filter string) bool {
+	for _,eventType := range AllEventTypes {
+		if MatchEvent(filter,eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// OutboxMessage is an event waiting in the outbox, Payload is the encoded Event.
+type OutboxMessage struct {
+	ID uuid.UUID
+	EventType string
+	TenantID uuid.UUID
+	Payload []byte
+	Attempts int
+	CreatedAt time.Time
+}
+
+// ErrResumeExpired means a stream can't be resumed from the given event, it was
+// purged or too much has happened since. Clients should reload instead.
+var ErrResumeExpired = errors.New("stream can not be resumed from last event id, reload")
+
+// CarEvent is a car change as pushed to stream subscribers, Car is the car as
+// it was after the change, or before it for car.deleted.
+type CarEvent struct {
+	ID uuid.UUID
+	Type string
+	TenantID uuid.UUID
+	OccurredAt time.Time
+	Car Car
+}
+
+// CarEventFilter narrows a stream to one dealership and a set of brands. A
+// null tenant means every dealership, no brands means every brand. Buyers,
+// anyone who can't manage inventory, only get the cars VisibleToBuyer shows
+// UserID and never who reserved them.
+type CarEventFilter struct {
+	TenantID uuid.NullUUID
+	Brands []string
+	Buyer bool
+	UserID string
+}
+
+func (f CarEventFilter)Match(event CarEvent) bool {
+	if f.TenantID.Valid && f.TenantID.UUID != event.TenantID {
+		return false
+	}
+	if f.Buyer && !VisibleToBuyer(&event.Car,f.UserID) {
+		return false
+	}
+	if len(f.Brands) == 0 {
+		return true
+	}
+	for _,brand := range f.Brands {
+		if brand == event.Car.Brand {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact strips what the subscriber may not see from an event it matched.
+func (f CarEventFilter)Redact(event CarEvent) CarEvent {
+	if f.Buyer {
+		event.Car.ReservedBy = ""
+	}
+	return event
+}
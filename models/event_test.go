@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCarEventFilter(t *testing.T) {
+	tenantID := uuid.New()
+	event := func(status,reservedBy string) CarEvent {
+		return CarEvent{
+			ID: uuid.New(),
+			Type: EventCarUpdated,
+			TenantID: tenantID,
+			Car: Car{Brand: "Toyota",Status: status,ReservedBy: reservedBy},
+		}
+	}
+	tests := []struct {
+		name string
+		filter CarEventFilter
+		event CarEvent
+		want bool
+	}{
+		{name: "staff see drafts",filter: CarEventFilter{},event: event(CarStatusDraft,""),want: true},
+		{name: "buyer sees available",filter: CarEventFilter{Buyer: true,UserID: "alice"},event: event(CarStatusAvailable,""),want: true},
+		{name: "buyer misses drafts",filter: CarEventFilter{Buyer: true,UserID: "alice"},event: event(CarStatusDraft,""),want: false},
+		{name: "buyer sees own reservation",filter: CarEventFilter{Buyer: true,UserID: "alice"},event: event(CarStatusReserved,"alice"),want: true},
+		{name: "buyer misses others' reservations",filter: CarEventFilter{Buyer: true,UserID: "alice"},event: event(CarStatusReserved,"bob"),want: false},
+		{name: "buyer misses sold",filter: CarEventFilter{Buyer: true,UserID: "alice"},event: event(CarStatusSold,""),want: false},
+		{name: "other dealership",filter: CarEventFilter{TenantID: uuid.NullUUID{UUID: uuid.New(),Valid: true}},event: event(CarStatusAvailable,""),want: false},
+		{name: "other brand",filter: CarEventFilter{Brands: []string{"Honda"}},event: event(CarStatusAvailable,""),want: false},
+		{name: "listed brand",filter: CarEventFilter{Brands: []string{"Honda","Toyota"}},event: event(CarStatusAvailable,""),want: true},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			if got := tt.filter.Match(tt.event); got != tt.want {
+				t.Errorf("Match = %v, want %v",got,tt.want)
+			}
+		})
+	}
+}
+
+func TestCarEventFilterRedact(t *testing.T) {
+	event := CarEvent{Car: Car{Status: CarStatusReserved,ReservedBy: "alice"}}
+	if got := (CarEventFilter{Buyer: true,UserID: "alice"}).Redact(event); got.Car.ReservedBy != "" {
+		t.Errorf("buyer got reserved_by %q",got.Car.ReservedBy)
+	}
+	if got := (CarEventFilter{}).Redact(event); got.Car.ReservedBy != "alice" {
+		t.Errorf("staff got reserved_by %q, want alice",got.Car.ReservedBy)
+	}
+	if event.Car.ReservedBy != "alice" {
+		t.Error("Redact changed the event it was given")
+	}
+}
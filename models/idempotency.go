@@ -0,0 +1,14 @@
+package models
+
+// IdempotencyRecord is the first response to a request sent with an
+// Idempotency-Key, StatusCode is zero while that request is still running.
+type IdempotencyRecord struct {
+	Fingerprint string
+	StatusCode int
+	ContentType string
+	Body []byte
+}
+
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
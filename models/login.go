@@ -3,4 +3,23 @@ package models
 type Credientials struct {
 	UserName    string `json:"username"`
 	Password string `json:"password"`
-}
\ No newline at end of file
+}
+
+// Identity is a user authenticated by the corporate identity provider.
+type Identity struct {
+	Issuer string `json:"issuer"`
+	Subject string `json:"subject"`
+	Username string `json:"username"`
+	Groups []string `json:"groups"`
+	Roles []string `json:"roles"`
+	TokenID string `json:"-"`
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+// OIDCLogin is returned from the OIDC callback, Token is the identity provider's id_token.
+type OIDCLogin struct {
+	Identity Identity `json:"identity"`
+	Token string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresAt int64 `json:"expires_at"`
+}
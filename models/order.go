@@ -0,0 +1,204 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"net/mail"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PaymentPending = "pending"
+	PaymentPartiallyPaid = "partially_paid"
+	PaymentPaid = "paid"
+	PaymentRefunded = "refunded"
+	PaymentCancelled = "cancelled"
+)
+
+var (
+	ErrCarNotForSale = errors.New("car is not available for sale")
+	ErrOrderClosed = errors.New("order is cancelled or refunded")
+	ErrOrderHasPayments = errors.New("order has payments, refund it instead")
+	ErrOverpayment = errors.New("payment exceeds the amount due")
+)
+
+// Order sells one car to one buyer at an agreed price, the car is marked sold
+// when the order is placed and released again if it is cancelled.
+type Order struct {
+	ID uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	InvoiceNumber string `json:"invoice_number"`
+	CarID uuid.UUID `json:"car_id"`
+	BuyerID string `json:"buyer_id"`
+	BuyerName string `json:"buyer_name"`
+	BuyerEmail string `json:"buyer_email"`
+	AgreedPrice float64 `json:"agreed_price"`
+	Fees []OrderFee `json:"fees"`
+	FeesTotal float64 `json:"fees_total"`
+	TaxRate float64 `json:"tax_rate"`
+	Tax float64 `json:"tax"`
+	Total float64 `json:"total"`
+	AmountPaid float64 `json:"amount_paid"`
+	PaymentStatus string `json:"payment_status"`
+	Payments []Payment `json:"payments"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	PaidAt *time.Time `json:"paid_at,omitempty"`
+}
+
+type OrderFee struct {
+	Name string `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+type Payment struct {
+	ID uuid.UUID `json:"id"`
+	Amount float64 `json:"amount"`
+	Method string `json:"method"`
+	Reference string `json:"reference"`
+	RecordedBy string `json:"recorded_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OrderRequest struct {
+	CarID uuid.UUID `json:"car_id"`
+	BuyerID string `json:"buyer_id"`
+	BuyerName string `json:"buyer_name"`
+	BuyerEmail string `json:"buyer_email"`
+	AgreedPrice float64 `json:"agreed_price"`
+	// added to the dealership's default fees
+	Fees []OrderFee `json:"fees"`
+}
+
+type PaymentRequest struct {
+	Amount float64 `json:"amount"`
+	Method string `json:"method"`
+	Reference string `json:"reference"`
+}
+
+// Pricing is how a dealership prices an order on top of the agreed price.
+type Pricing struct {
+	// charged on the agreed price plus fees, 0.16 is 16%
+	TaxRate float64
+	DefaultFees []OrderFee
+}
+
+// Invoice is everything an invoice document shows.
+type Invoice struct {
+	Order Order
+	Car Car
+	Dealership Dealership
+	IssuedAt time.Time
+}
+
+var paymentMethods = map[string]bool{"cash": true,"card": true,"bank_transfer": true,"mobile_money": true,"financing": true}
+
+func ValidateOrderRequest(orderReq *OrderRequest) error {
+	if orderReq.CarID == uuid.Nil {
+		return errors.New("car_id is required")
+	}
+	if orderReq.BuyerID == "" {
+		return errors.New("buyer_id is required")
+	}
+	if orderReq.BuyerName == "" {
+		return errors.New("buyer_name is required")
+	}
+	if _,err := mail.ParseAddress(orderReq.BuyerEmail); err != nil {
+		return errors.New("buyer_email must be a valid email address")
+	}
+	if orderReq.AgreedPrice <= 0 {
+		return errors.New("agreed_price must be greater than zero")
+	}
+	for _,fee := range orderReq.Fees {
+		if fee.Name == "" || fee.Amount < 0 {
+			return errors.New("fees need a name and a non negative amount")
+		}
+	}
+	return nil
+}
+
+func ValidatePaymentRequest(paymentReq *PaymentRequest) error {
+	if paymentReq.Amount <= 0 {
+		return errors.New("amount must be greater than zero")
+	}
+	if !paymentMethods[paymentReq.Method] {
+		return errors.New("method must be one of cash, card, bank_transfer, mobile_money or financing")
+	}
+	return nil
+}
+
+// PriceOrder fills in the fees, tax and total of order, amounts are rounded to cents.
+func (p Pricing) PriceOrder(order *Order,extraFees []OrderFee) {
+	order.Fees = append(append([]OrderFee{},p.DefaultFees...),extraFees...)
+	order.FeesTotal = 0
+	for _,fee := range order.Fees {
+		order.FeesTotal += roundCents(fee.Amount)
+	}
+	order.AgreedPrice = roundCents(order.AgreedPrice)
+	order.FeesTotal = roundCents(order.FeesTotal)
+	order.TaxRate = p.TaxRate
+	order.Tax = roundCents((order.AgreedPrice + order.FeesTotal) * p.TaxRate)
+	order.Total = roundCents(order.AgreedPrice + order.FeesTotal + order.Tax)
+}
+
+// PaymentStatusFor is the status of an open order that has received paid so far.
+func PaymentStatusFor(total float64,paid float64) string {
+	switch {
+	case paid <= 0:
+		return PaymentPending
+	case roundCents(paid) < roundCents(total):
+		return PaymentPartiallyPaid
+	default:
+		return PaymentPaid
+	}
+}
+
+func (o *Order) AmountDue() float64 {
+	return roundCents(o.Total - o.AmountPaid)
+}
+
+func (o *Order) Closed() bool {
+	return o.PaymentStatus == PaymentCancelled || o.PaymentStatus == PaymentRefunded
+}
+
+// CheckPayment reports why a payment of amount can't be taken on o, if it can't.
+func (o *Order) CheckPayment(amount float64) error {
+	if o.Closed() {
+		return ErrOrderClosed
+	}
+	if amount > o.AmountDue() {
+		return ErrOverpayment
+	}
+	return nil
+}
+
+// CheckClose reports why o can't be moved to status, cancelled or refunded.
+func (o *Order) CheckClose(status string) error {
+	if o.Closed() {
+		return ErrOrderClosed
+	}
+	if status == PaymentCancelled && o.AmountPaid > 0 {
+		return ErrOrderHasPayments
+	}
+	return nil
+}
+
+// ForSaleTo reports whether car can be sold to buyerID at now. An expired
+// reservation counts as available, a live one only for its holder.
+func ForSaleTo(car *Car,buyerID string,now time.Time) bool {
+	switch car.Status {
+	case CarStatusAvailable:
+		return true
+	case CarStatusReserved:
+		return car.ReservedUntil == nil || !car.ReservedUntil.After(now) || car.ReservedBy == buyerID
+	default:
+		return false
+	}
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount * 100) / 100
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPriceOrder(t *testing.T) {
+	pricing := Pricing{TaxRate: 0.16,DefaultFees: []OrderFee{{Name: "Documentation",Amount: 5000}}}
+	order := Order{AgreedPrice: 1250000.004}
+	pricing.PriceOrder(&order,[]OrderFee{{Name: "Registration",Amount: 2499.995}})
+
+	if len(order.Fees) != 2 || order.Fees[0].Name != "Documentation" || order.Fees[1].Name != "Registration" {
+		t.Errorf("fees = %+v, want the default fee then the extra one",order.Fees)
+	}
+	if order.AgreedPrice != 1250000 {
+		t.Errorf("agreed price = %v, want 1250000",order.AgreedPrice)
+	}
+	if order.FeesTotal != 7500 {
+		t.Errorf("fees total = %v, want 7500",order.FeesTotal)
+	}
+	if order.TaxRate != 0.16 || order.Tax != 201200 {
+		t.Errorf("tax = %v at %v, want 201200 at 0.16",order.Tax,order.TaxRate)
+	}
+	if order.Total != 1458700 {
+		t.Errorf("total = %v, want 1458700",order.Total)
+	}
+
+	// pricing an order again starts from the defaults, not the last fees
+	pricing.PriceOrder(&order,nil)
+	if len(order.Fees) != 1 || order.FeesTotal != 5000 {
+		t.Errorf("repriced fees = %+v totalling %v",order.Fees,order.FeesTotal)
+	}
+	if len(pricing.DefaultFees) != 1 {
+		t.Errorf("pricing the order changed the default fees: %+v",pricing.DefaultFees)
+	}
+}
+
+func TestPriceOrderRounding(t *testing.T) {
+	order := Order{AgreedPrice: 10.01}
+	Pricing{TaxRate: 0.155}.PriceOrder(&order,nil)
+	if order.Tax != 1.55 || order.Total != 11.56 {
+		t.Errorf("tax %v and total %v, want 1.55 and 11.56",order.Tax,order.Total)
+	}
+}
+
+func TestPaymentStatusFor(t *testing.T) {
+	tests := []struct {
+		total float64
+		paid float64
+		want string
+	}{
+		{total: 1000,paid: 0,want: PaymentPending},
+		{total: 1000,paid: 0.01,want: PaymentPartiallyPaid},
+		{total: 1000,paid: 999.99,want: PaymentPartiallyPaid},
+		{total: 1000,paid: 1000,want: PaymentPaid},
+		// float sums that land a hair under the total still pay it off
+		{total: 0.3,paid: 0.1 + 0.2,want: PaymentPaid},
+		{total: 0.3,paid: 0.29999999,want: PaymentPaid},
+	}
+	for _,tt := range tests {
+		if got := PaymentStatusFor(tt.total,tt.paid); got != tt.want {
+			t.Errorf("PaymentStatusFor(%v, %v) = %q, want %q",tt.total,tt.paid,got,tt.want)
+		}
+	}
+}
+
+func TestCheckPayment(t *testing.T) {
+	tests := []struct {
+		name string
+		order Order
+		amount float64
+		want error
+	}{
+		{name: "first payment",order: Order{Total: 1000,PaymentStatus: PaymentPending},amount: 400},
+		{name: "settling",order: Order{Total: 1000,AmountPaid: 400,PaymentStatus: PaymentPartiallyPaid},amount: 600},
+		{name: "overpaying",order: Order{Total: 1000,AmountPaid: 400,PaymentStatus: PaymentPartiallyPaid},amount: 600.01,want: ErrOverpayment},
+		{name: "paid off",order: Order{Total: 1000,AmountPaid: 1000,PaymentStatus: PaymentPaid},amount: 1,want: ErrOverpayment},
+		{name: "cancelled",order: Order{Total: 1000,PaymentStatus: PaymentCancelled},amount: 1,want: ErrOrderClosed},
+		{name: "refunded",order: Order{Total: 1000,AmountPaid: 1000,PaymentStatus: PaymentRefunded},amount: 1,want: ErrOrderClosed},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			if err := tt.order.CheckPayment(tt.amount); !errors.Is(err,tt.want) {
+				t.Errorf("CheckPayment(%v) = %v, want %v",tt.amount,err,tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckClose(t *testing.T) {
+	tests := []struct {
+		name string
+		order Order
+		status string
+		want error
+	}{
+		{name: "cancel unpaid",order: Order{PaymentStatus: PaymentPending},status: PaymentCancelled},
+		{name: "cancel with payments",order: Order{AmountPaid: 10,PaymentStatus: PaymentPartiallyPaid},status: PaymentCancelled,want: ErrOrderHasPayments},
+		{name: "refund partially paid",order: Order{AmountPaid: 10,PaymentStatus: PaymentPartiallyPaid},status: PaymentRefunded},
+		{name: "refund paid",order: Order{AmountPaid: 1000,PaymentStatus: PaymentPaid},status: PaymentRefunded},
+		{name: "cancel twice",order: Order{PaymentStatus: PaymentCancelled},status: PaymentCancelled,want: ErrOrderClosed},
+		{name: "refund twice",order: Order{PaymentStatus: PaymentRefunded},status: PaymentRefunded,want: ErrOrderClosed},
+		{name: "refund cancelled",order: Order{PaymentStatus: PaymentCancelled},status: PaymentRefunded,want: ErrOrderClosed},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			if err := tt.order.CheckClose(tt.status); !errors.Is(err,tt.want) {
+				t.Errorf("CheckClose(%q) = %v, want %v",tt.status,err,tt.want)
+			}
+		})
+	}
+}
+
+func TestForSaleTo(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+	tests := []struct {
+		name string
+		car Car
+		want bool
+	}{
+		{name: "available",car: Car{Status: CarStatusAvailable},want: true},
+		{name: "reserved for the buyer",car: Car{Status: CarStatusReserved,ReservedBy: "buyer",ReservedUntil: &later},want: true},
+		{name: "reserved for someone else",car: Car{Status: CarStatusReserved,ReservedBy: "other",ReservedUntil: &later}},
+		{name: "someone else's reservation expired",car: Car{Status: CarStatusReserved,ReservedBy: "other",ReservedUntil: &earlier},want: true},
+		{name: "reservation ending now",car: Car{Status: CarStatusReserved,ReservedBy: "other",ReservedUntil: &now},want: true},
+		{name: "sold",car: Car{Status: CarStatusSold}},
+		{name: "draft",car: Car{Status: CarStatusDraft}},
+		{name: "archived",car: Car{Status: CarStatusArchived}},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			if got := ForSaleTo(&tt.car,"buyer",now); got != tt.want {
+				t.Errorf("ForSaleTo = %v, want %v",got,tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import "errors"
+
+const (
+	RoleAdmin = "admin"
+	RoleStaff = "staff"
+	RoleViewer = "viewer"
+)
+
+var roleScopes = map[string][]string{
+	RoleAdmin: AllScopes,
+	RoleStaff: {ScopeCarsRead,ScopeCarsWrite,ScopeCarsReserve,ScopeEnginesRead,ScopeEnginesWrite,ScopeOrdersRead,ScopeOrdersWrite},
+	RoleViewer: {ScopeCarsRead,ScopeCarsReserve,ScopeEnginesRead,ScopeOrdersRead},
+}
+
+func ValidateRole(role string) error {
+	if _,ok := roleScopes[role]; !ok {
+		return errors.New("invalid role " + role)
+	}
+	return nil
+}
+
+// ScopesForRoles returns the union of the scopes granted by each role.
+func ScopesForRoles(roles []string) []string {
+	seen := make(map[string]bool)
+	scopes := []string{}
+	for _,role := range roles {
+		for _,scope := range roleScopes[role] {
+			if !seen[scope] {
+				seen[scope] = true
+				scopes = append(scopes,scope)
+			}
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TestDriveBooked = "booked"
+	TestDriveCancelled = "cancelled"
+)
+
+var (
+	ErrBookingConflict = errors.New("the car is already booked for this time")
+	ErrSlotUnavailable = errors.New("requested time is not an available test drive slot")
+	ErrInvalidTestDriveDate = errors.New("date must be written as YYYY-MM-DD")
+)
+
+type TestDrive struct {
+	ID uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	CarID uuid.UUID `json:"car_id"`
+	CustomerID string `json:"customer_id"`
+	CustomerName string `json:"customer_name"`
+	CustomerPhone string `json:"customer_phone"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt time.Time `json:"ends_at"`
+	Status string `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+}
+
+type TestDriveRequest struct {
+	StartsAt time.Time `json:"starts_at"`
+	CustomerName string `json:"customer_name"`
+	CustomerPhone string `json:"customer_phone"`
+}
+
+// TestDriveSlot is a weekly window in which test drives of SlotMinutes each can be booked.
+type TestDriveSlot struct {
+	Weekday time.Weekday `json:"weekday"`
+	// local times written as "15:04"
+	Opens string `json:"opens"`
+	Closes string `json:"closes"`
+	SlotMinutes int `json:"slot_minutes"`
+}
+
+// TestDriveSchedule is a dealership's weekly test drive availability.
+type TestDriveSchedule struct {
+	Timezone string `json:"timezone"`
+	Slots []TestDriveSlot `json:"slots"`
+}
+
+type TestDriveAvailability struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt time.Time `json:"ends_at"`
+}
+
+func ValidateTestDriveRequest(testDriveReq *TestDriveRequest) error {
+	if testDriveReq.StartsAt.IsZero() {
+		return errors.New("starts_at is required")
+	}
+	if testDriveReq.CustomerName == "" {
+		return errors.New("customer_name is required")
+	}
+	if testDriveReq.CustomerPhone == "" {
+		return errors.New("customer_phone is required")
+	}
+	return nil
+}
+
+func ValidateTestDriveSchedule(schedule *TestDriveSchedule) error {
+	if _,err := time.LoadLocation(schedule.Timezone); err != nil || schedule.Timezone == "" {
+		return errors.New("timezone must be an IANA time zone such as Africa/Nairobi")
+	}
+	for _,slot := range schedule.Slots {
+		if slot.Weekday < time.Sunday || slot.Weekday > time.Saturday {
+			return errors.New("weekday must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		opens,err := time.Parse("15:04",slot.Opens)
+		if err != nil {
+			return errors.New("opens must be written as HH:MM")
+		}
+		closes,err := time.Parse("15:04",slot.Closes)
+		if err != nil {
+			return errors.New("closes must be written as HH:MM")
+		}
+		if slot.SlotMinutes < 15 || slot.SlotMinutes > 240 {
+			return errors.New("slot_minutes must be between 15 and 240")
+		}
+		if closes.Sub(opens) < time.Duration(slot.SlotMinutes) * time.Minute {
+			return errors.New("slot window must fit at least one test drive")
+		}
+	}
+	return nil
+}
+
+// SlotsOn lists the test drive slots the schedule offers on the given local date.
+func (s *TestDriveSchedule) SlotsOn(date time.Time) ([]TestDriveAvailability,error) {
+	loc,err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil,err
+	}
+	year,month,day := date.Date()
+	weekday := time.Date(year,month,day,0,0,0,0,loc).Weekday()
+	slots := []TestDriveAvailability{}
+	for _,slot := range s.Slots {
+		if slot.Weekday != weekday {
+			continue
+		}
+		opens,_ := time.Parse("15:04",slot.Opens)
+		closes,_ := time.Parse("15:04",slot.Closes)
+		start := time.Date(year,month,day,opens.Hour(),opens.Minute(),0,0,loc)
+		end := time.Date(year,month,day,closes.Hour(),closes.Minute(),0,0,loc)
+		length := time.Duration(slot.SlotMinutes) * time.Minute
+		for t := start; !t.Add(length).After(end); t = t.Add(length) {
+			slots = append(slots,TestDriveAvailability{StartsAt: t,EndsAt: t.Add(length)})
+		}
+	}
+	return slots,nil
+}
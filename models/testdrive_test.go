@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlotsOn(t *testing.T) {
+	if _,err := time.LoadLocation("Africa/Nairobi"); err != nil {
+		t.Skip("no time zone database: ",err)
+	}
+	schedule := TestDriveSchedule{
+		Timezone: "Africa/Nairobi",
+		Slots: []TestDriveSlot{
+			{Weekday: time.Monday,Opens: "09:00",Closes: "11:00",SlotMinutes: 60},
+			{Weekday: time.Monday,Opens: "14:00",Closes: "15:15",SlotMinutes: 30},
+			{Weekday: time.Saturday,Opens: "10:00",Closes: "10:45",SlotMinutes: 45},
+		},
+	}
+	tests := []struct {
+		name string
+		date time.Time
+		want []string
+	}{
+		{name: "two windows",date: time.Date(2024,3,4,0,0,0,0,time.UTC),want: []string{"09:00","10:00","14:00","14:30"}},
+		{name: "exact fit",date: time.Date(2024,3,9,0,0,0,0,time.UTC),want: []string{"10:00"}},
+		{name: "closed",date: time.Date(2024,3,5,0,0,0,0,time.UTC),want: []string{}},
+		// the date is read as written, not converted to the dealership's zone
+		{name: "date in another zone",date: time.Date(2024,3,4,23,0,0,0,time.FixedZone("UTC-5",-5 * 60 * 60)),want: []string{"09:00","10:00","14:00","14:30"}},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			slots,err := schedule.SlotsOn(tt.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(slots) != len(tt.want) {
+				t.Fatalf("got %d slots, want %d",len(slots),len(tt.want))
+			}
+			for i,slot := range slots {
+				if slot.StartsAt.Location().String() != "Africa/Nairobi" || slot.StartsAt.Format("15:04") != tt.want[i] {
+					t.Errorf("slot %d starts %v, want %s Nairobi time",i,slot.StartsAt,tt.want[i])
+				}
+				if slot.EndsAt.Sub(slot.StartsAt) <= 0 {
+					t.Errorf("slot %d ends %v, before it starts",i,slot.EndsAt)
+				}
+			}
+		})
+	}
+}
+
+func TestSlotsOnUnknownZone(t *testing.T) {
+	schedule := TestDriveSchedule{Timezone: "Mars/Olympus_Mons"}
+	if _,err := schedule.SlotsOn(time.Now()); err == nil {
+		t.Fatal("SlotsOn accepted an unknown time zone")
+	}
+}
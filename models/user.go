@@ -0,0 +1,49 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const minPasswordLength = 8
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUsernameTaken = errors.New("username is already taken")
+)
+
+// User logs in through /login, users without a tenant are platform admins.
+type User struct {
+	ID uuid.UUID `json:"id"`
+	Username string `json:"username"`
+	Roles []string `json:"roles"`
+	TenantID uuid.NullUUID `json:"tenant_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Roles []string `json:"roles"`
+	TenantID uuid.NullUUID `json:"tenant_id"`
+}
+
+func ValidateUserRequest(userReq *UserRequest) error {
+	if userReq.Username == "" {
+		return errors.New("username is required")
+	}
+	if len(userReq.Password) < minPasswordLength {
+		return errors.New("password must be at least 8 characters")
+	}
+	if len(userReq.Roles) == 0 {
+		return errors.New("at least one role is required")
+	}
+	for _,role := range userReq.Roles {
+		if err := ValidateRole(role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
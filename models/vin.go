@@ -0,0 +1,21 @@
+package models
+
+// VINInfo is what a VIN says about a vehicle. The WMI tables only know the
+// manufacturer, an external provider can add the model and the plant's name.
+type VINInfo struct {
+	VIN string `json:"vin"`
+	// world manufacturer identifier, the first three characters
+	WMI string `json:"wmi"`
+	Region string `json:"region"`
+	Country string `json:"country,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	Brand string `json:"brand,omitempty"`
+	Model string `json:"model,omitempty"`
+	// zero when the tenth character is not a year code
+	ModelYear int `json:"model_year,omitempty"`
+	PlantCode string `json:"plant_code"`
+	Plant string `json:"plant,omitempty"`
+	SerialNumber string `json:"serial_number"`
+	// wmi when only the built in tables were used, otherwise the provider's name
+	Source string `json:"source"`
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DeliveryPending = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed = "failed"
+)
+
+var ErrInvalidWebhookRequest = errors.New("invalid webhook request")
+
+// Webhook subscribes a URL to a dealership's events, every delivery is signed with Secret.
+type Webhook struct {
+	ID uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	URL string `json:"url"`
+	Events []string `json:"events"`
+	Secret string `json:"-"`
+	Active bool `json:"active"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IssuedWebhook is returned once on creation, the signing secret is not shown again.
+type IssuedWebhook struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+type WebhookRequest struct {
+	URL string `json:"url"`
+	Events []string `json:"events"`
+}
+
+// WebhookDelivery is one attempt chain at sending an event to a webhook.
+type WebhookDelivery struct {
+	ID uuid.UUID `json:"id"`
+	WebhookID uuid.UUID `json:"webhook_id"`
+	EventID uuid.UUID `json:"event_id"`
+	EventType string `json:"event_type"`
+	Payload json.RawMessage `json:"payload"`
+	Status string `json:"status"`
+	Attempts int `json:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	LastStatusCode int `json:"last_status_code,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+	ReplayOf *uuid.UUID `json:"replay_of,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+	// set when the dispatcher claims the delivery
+	URL string `json:"-"`
+	Secret string `json:"-"`
+}
+
+func ValidateWebhookRequest(webhookReq *WebhookRequest) error {
+	// deliveries carry signed dealership data, so plain http is refused
+	u,err := url.Parse(webhookReq.URL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("%w: url must be an absolute https url",ErrInvalidWebhookRequest)
+	}
+	if len(webhookReq.Events) == 0 {
+		return fmt.Errorf("%w: at least one event is required",ErrInvalidWebhookRequest)
+	}
+	for _,filter := range webhookReq.Events {
+		if !validateEventFilter(filter) {
+			return fmt.Errorf("%w: invalid event %s",ErrInvalidWebhookRequest,filter)
+		}
+	}
+	return nil
+}
+
+func (w *Webhook) Wants(eventType string) bool {
+	for _,filter := range w.Events {
+		if MatchEvent(filter,eventType) {
+			return true
+		}
+	}
+	return false
+}
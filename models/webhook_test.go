@@ -0,0 +1,31 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateWebhookRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req WebhookRequest
+		valid bool
+	}{
+		{name: "valid",req: WebhookRequest{URL: "https://example.com/hooks",Events: []string{"car.*"}},valid: true},
+		{name: "plain http",req: WebhookRequest{URL: "http://example.com/hooks",Events: []string{"car.*"}}},
+		{name: "relative",req: WebhookRequest{URL: "/hooks",Events: []string{"car.*"}}},
+		{name: "no host",req: WebhookRequest{URL: "https://:443/hooks",Events: []string{"car.*"}}},
+		{name: "no events",req: WebhookRequest{URL: "https://example.com/hooks"}},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			err := ValidateWebhookRequest(&tt.req)
+			if tt.valid && err != nil {
+				t.Fatalf("ValidateWebhookRequest = %v, want nil",err)
+			}
+			if !tt.valid && !errors.Is(err,ErrInvalidWebhookRequest) {
+				t.Fatalf("ValidateWebhookRequest = %v, want %v",err,ErrInvalidWebhookRequest)
+			}
+		})
+	}
+}
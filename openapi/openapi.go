@@ -0,0 +1,97 @@
+// Package openapi carries the OpenAPI 3 contracts for the /login, /cars and
+// /engines routes, one for /v1 and one for /v2, and serves them along with a
+// Swagger UI page.
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+//go:embed openapi_v2.yaml
+var specV2 []byte
+
+func init() {
+	// keep validation errors to the offending value, not the whole schema
+	openapi3.SchemaErrorDetailsDisabled = true
+	openapi3.DefineStringFormatCallback("uuid",func(s string) error {
+		_,err := uuid.Parse(s)
+		return err
+	})
+}
+
+// Load parses and validates the embedded /v1 document, which also covers
+// the unversioned routes.
+func Load() (*openapi3.T,error) {
+	return load(spec)
+}
+
+// LoadV2 parses and validates the embedded /v2 document.
+func LoadV2() (*openapi3.T,error) {
+	return load(specV2)
+}
+
+func load(data []byte) (*openapi3.T,error) {
+	loader := openapi3.NewLoader()
+	doc,err := loader.LoadFromData(data)
+	if err != nil {
+		return nil,err
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil,fmt.Errorf("invalid openapi document: %w",err)
+	}
+	return doc,nil
+}
+
+// Handler serves doc as JSON.
+func Handler(doc *openapi3.T) (http.Handler,error) {
+	body,err := json.Marshal(doc)
+	if err != nil {
+		return nil,err
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		w.Header().Set("Content-Type","application/json")
+		w.WriteHeader(http.StatusOK)
+		if _,err := w.Write(body); err != nil {
+			log.Println("Error writing messages ",err)
+		}
+	}),nil
+}
+
+var swaggerUI = template.Must(template.New("swagger").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>CarZone API</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+<script>
+window.onload = () => { window.ui = SwaggerUIBundle({url: {{.}},dom_id: "#swagger-ui"}); };
+</script>
+</body>
+</html>
+`))
+
+// SwaggerUI renders Swagger UI against the document served at specURL.
+func SwaggerUI(specURL string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		w.Header().Set("Content-Type","text/html; charset=utf-8")
+		if err := swaggerUI.Execute(w,specURL); err != nil {
+			log.Println("Error: ",err)
+		}
+	})
+}
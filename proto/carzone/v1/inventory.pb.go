@@ -0,0 +1,1496 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: carzone/v1/inventory.proto
+
+package carzonev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Engine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Displacement  int64                  `protobuf:"varint,3,opt,name=displacement,proto3" json:"displacement,omitempty"`
+	NoOfCylinders int64                  `protobuf:"varint,4,opt,name=no_of_cylinders,json=noOfCylinders,proto3" json:"no_of_cylinders,omitempty"`
+	CarRange      int64                  `protobuf:"varint,5,opt,name=car_range,json=carRange,proto3" json:"car_range,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// empty for engines recorded before powertrains were tracked
+	FuelType           string  `protobuf:"bytes,7,opt,name=fuel_type,json=fuelType,proto3" json:"fuel_type,omitempty"`
+	BatteryCapacityKwh float64 `protobuf:"fixed64,8,opt,name=battery_capacity_kwh,json=batteryCapacityKwh,proto3" json:"battery_capacity_kwh,omitempty"`
+	MotorPowerKw       int64   `protobuf:"varint,9,opt,name=motor_power_kw,json=motorPowerKw,proto3" json:"motor_power_kw,omitempty"`
+	ChargingStandard   string  `protobuf:"bytes,10,opt,name=charging_standard,json=chargingStandard,proto3" json:"charging_standard,omitempty"`
+	Horsepower         int64   `protobuf:"varint,11,opt,name=horsepower,proto3" json:"horsepower,omitempty"`
+	TorqueNm           int64   `protobuf:"varint,12,opt,name=torque_nm,json=torqueNm,proto3" json:"torque_nm,omitempty"`
+	Co2GPerKm          int64   `protobuf:"varint,13,opt,name=co2_g_per_km,json=co2GPerKm,proto3" json:"co2_g_per_km,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Engine) Reset() {
+	*x = Engine{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Engine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Engine) ProtoMessage() {}
+
+func (x *Engine) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Engine.ProtoReflect.Descriptor instead.
+func (*Engine) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Engine) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Engine) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Engine) GetDisplacement() int64 {
+	if x != nil {
+		return x.Displacement
+	}
+	return 0
+}
+
+func (x *Engine) GetNoOfCylinders() int64 {
+	if x != nil {
+		return x.NoOfCylinders
+	}
+	return 0
+}
+
+func (x *Engine) GetCarRange() int64 {
+	if x != nil {
+		return x.CarRange
+	}
+	return 0
+}
+
+func (x *Engine) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Engine) GetFuelType() string {
+	if x != nil {
+		return x.FuelType
+	}
+	return ""
+}
+
+func (x *Engine) GetBatteryCapacityKwh() float64 {
+	if x != nil {
+		return x.BatteryCapacityKwh
+	}
+	return 0
+}
+
+func (x *Engine) GetMotorPowerKw() int64 {
+	if x != nil {
+		return x.MotorPowerKw
+	}
+	return 0
+}
+
+func (x *Engine) GetChargingStandard() string {
+	if x != nil {
+		return x.ChargingStandard
+	}
+	return ""
+}
+
+func (x *Engine) GetHorsepower() int64 {
+	if x != nil {
+		return x.Horsepower
+	}
+	return 0
+}
+
+func (x *Engine) GetTorqueNm() int64 {
+	if x != nil {
+		return x.TorqueNm
+	}
+	return 0
+}
+
+func (x *Engine) GetCo2GPerKm() int64 {
+	if x != nil {
+		return x.Co2GPerKm
+	}
+	return 0
+}
+
+type Car struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TenantId      string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Year          string                 `protobuf:"bytes,5,opt,name=year,proto3" json:"year,omitempty"`
+	Brand         string                 `protobuf:"bytes,6,opt,name=brand,proto3" json:"brand,omitempty"`
+	FuelType      string                 `protobuf:"bytes,7,opt,name=fuel_type,json=fuelType,proto3" json:"fuel_type,omitempty"`
+	Engine        *Engine                `protobuf:"bytes,8,opt,name=engine,proto3" json:"engine,omitempty"`
+	Price         float64                `protobuf:"fixed64,9,opt,name=price,proto3" json:"price,omitempty"`
+	Status        string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	ReservedBy    string                 `protobuf:"bytes,11,opt,name=reserved_by,json=reservedBy,proto3" json:"reserved_by,omitempty"`
+	ReservedUntil *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=reserved_until,json=reservedUntil,proto3" json:"reserved_until,omitempty"`
+	SoldAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=sold_at,json=soldAt,proto3" json:"sold_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// listing details, empty when the dealership left them out
+	Vin          string   `protobuf:"bytes,16,opt,name=vin,proto3" json:"vin,omitempty"`
+	OdometerKm   int64    `protobuf:"varint,17,opt,name=odometer_km,json=odometerKm,proto3" json:"odometer_km,omitempty"`
+	Transmission string   `protobuf:"bytes,18,opt,name=transmission,proto3" json:"transmission,omitempty"`
+	Drivetrain   string   `protobuf:"bytes,19,opt,name=drivetrain,proto3" json:"drivetrain,omitempty"`
+	Colour       string   `protobuf:"bytes,20,opt,name=colour,proto3" json:"colour,omitempty"`
+	BodyType     string   `protobuf:"bytes,21,opt,name=body_type,json=bodyType,proto3" json:"body_type,omitempty"`
+	Condition    string   `protobuf:"bytes,22,opt,name=condition,proto3" json:"condition,omitempty"`
+	Features     []string `protobuf:"bytes,23,rep,name=features,proto3" json:"features,omitempty"`
+	// spelled the way the catalogue spells it, empty when left out
+	Model         string `protobuf:"bytes,24,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Car) Reset() {
+	*x = Car{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Car) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Car) ProtoMessage() {}
+
+func (x *Car) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Car.ProtoReflect.Descriptor instead.
+func (*Car) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Car) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Car) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Car) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Car) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Car) GetYear() string {
+	if x != nil {
+		return x.Year
+	}
+	return ""
+}
+
+func (x *Car) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *Car) GetFuelType() string {
+	if x != nil {
+		return x.FuelType
+	}
+	return ""
+}
+
+func (x *Car) GetEngine() *Engine {
+	if x != nil {
+		return x.Engine
+	}
+	return nil
+}
+
+func (x *Car) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Car) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Car) GetReservedBy() string {
+	if x != nil {
+		return x.ReservedBy
+	}
+	return ""
+}
+
+func (x *Car) GetReservedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReservedUntil
+	}
+	return nil
+}
+
+func (x *Car) GetSoldAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SoldAt
+	}
+	return nil
+}
+
+func (x *Car) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Car) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Car) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *Car) GetOdometerKm() int64 {
+	if x != nil {
+		return x.OdometerKm
+	}
+	return 0
+}
+
+func (x *Car) GetTransmission() string {
+	if x != nil {
+		return x.Transmission
+	}
+	return ""
+}
+
+func (x *Car) GetDrivetrain() string {
+	if x != nil {
+		return x.Drivetrain
+	}
+	return ""
+}
+
+func (x *Car) GetColour() string {
+	if x != nil {
+		return x.Colour
+	}
+	return ""
+}
+
+func (x *Car) GetBodyType() string {
+	if x != nil {
+		return x.BodyType
+	}
+	return ""
+}
+
+func (x *Car) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *Car) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *Car) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+type CarInput struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Year     string                 `protobuf:"bytes,2,opt,name=year,proto3" json:"year,omitempty"`
+	Brand    string                 `protobuf:"bytes,3,opt,name=brand,proto3" json:"brand,omitempty"`
+	FuelType string                 `protobuf:"bytes,4,opt,name=fuel_type,json=fuelType,proto3" json:"fuel_type,omitempty"`
+	EngineId string                 `protobuf:"bytes,5,opt,name=engine_id,json=engineId,proto3" json:"engine_id,omitempty"`
+	Price    float64                `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+	// draft or available, defaults to available
+	Status       string   `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Vin          string   `protobuf:"bytes,8,opt,name=vin,proto3" json:"vin,omitempty"`
+	OdometerKm   int64    `protobuf:"varint,9,opt,name=odometer_km,json=odometerKm,proto3" json:"odometer_km,omitempty"`
+	Transmission string   `protobuf:"bytes,10,opt,name=transmission,proto3" json:"transmission,omitempty"`
+	Drivetrain   string   `protobuf:"bytes,11,opt,name=drivetrain,proto3" json:"drivetrain,omitempty"`
+	Colour       string   `protobuf:"bytes,12,opt,name=colour,proto3" json:"colour,omitempty"`
+	BodyType     string   `protobuf:"bytes,13,opt,name=body_type,json=bodyType,proto3" json:"body_type,omitempty"`
+	Condition    string   `protobuf:"bytes,14,opt,name=condition,proto3" json:"condition,omitempty"`
+	Features     []string `protobuf:"bytes,15,rep,name=features,proto3" json:"features,omitempty"`
+	// brand and model may use any spelling the catalogue knows
+	Model         string `protobuf:"bytes,16,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CarInput) Reset() {
+	*x = CarInput{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CarInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CarInput) ProtoMessage() {}
+
+func (x *CarInput) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CarInput.ProtoReflect.Descriptor instead.
+func (*CarInput) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CarInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CarInput) GetYear() string {
+	if x != nil {
+		return x.Year
+	}
+	return ""
+}
+
+func (x *CarInput) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *CarInput) GetFuelType() string {
+	if x != nil {
+		return x.FuelType
+	}
+	return ""
+}
+
+func (x *CarInput) GetEngineId() string {
+	if x != nil {
+		return x.EngineId
+	}
+	return ""
+}
+
+func (x *CarInput) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CarInput) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CarInput) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *CarInput) GetOdometerKm() int64 {
+	if x != nil {
+		return x.OdometerKm
+	}
+	return 0
+}
+
+func (x *CarInput) GetTransmission() string {
+	if x != nil {
+		return x.Transmission
+	}
+	return ""
+}
+
+func (x *CarInput) GetDrivetrain() string {
+	if x != nil {
+		return x.Drivetrain
+	}
+	return ""
+}
+
+func (x *CarInput) GetColour() string {
+	if x != nil {
+		return x.Colour
+	}
+	return ""
+}
+
+func (x *CarInput) GetBodyType() string {
+	if x != nil {
+		return x.BodyType
+	}
+	return ""
+}
+
+func (x *CarInput) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *CarInput) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *CarInput) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+// Electric engines have no displacement or cylinders and need a battery,
+// motor and charging standard, hybrids need both.
+type EngineInput struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Displacement       int64                  `protobuf:"varint,1,opt,name=displacement,proto3" json:"displacement,omitempty"`
+	NoOfCylinders      int64                  `protobuf:"varint,2,opt,name=no_of_cylinders,json=noOfCylinders,proto3" json:"no_of_cylinders,omitempty"`
+	CarRange           int64                  `protobuf:"varint,3,opt,name=car_range,json=carRange,proto3" json:"car_range,omitempty"`
+	FuelType           string                 `protobuf:"bytes,4,opt,name=fuel_type,json=fuelType,proto3" json:"fuel_type,omitempty"`
+	BatteryCapacityKwh float64                `protobuf:"fixed64,5,opt,name=battery_capacity_kwh,json=batteryCapacityKwh,proto3" json:"battery_capacity_kwh,omitempty"`
+	MotorPowerKw       int64                  `protobuf:"varint,6,opt,name=motor_power_kw,json=motorPowerKw,proto3" json:"motor_power_kw,omitempty"`
+	ChargingStandard   string                 `protobuf:"bytes,7,opt,name=charging_standard,json=chargingStandard,proto3" json:"charging_standard,omitempty"`
+	Horsepower         int64                  `protobuf:"varint,8,opt,name=horsepower,proto3" json:"horsepower,omitempty"`
+	TorqueNm           int64                  `protobuf:"varint,9,opt,name=torque_nm,json=torqueNm,proto3" json:"torque_nm,omitempty"`
+	Co2GPerKm          int64                  `protobuf:"varint,10,opt,name=co2_g_per_km,json=co2GPerKm,proto3" json:"co2_g_per_km,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *EngineInput) Reset() {
+	*x = EngineInput{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EngineInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineInput) ProtoMessage() {}
+
+func (x *EngineInput) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineInput.ProtoReflect.Descriptor instead.
+func (*EngineInput) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EngineInput) GetDisplacement() int64 {
+	if x != nil {
+		return x.Displacement
+	}
+	return 0
+}
+
+func (x *EngineInput) GetNoOfCylinders() int64 {
+	if x != nil {
+		return x.NoOfCylinders
+	}
+	return 0
+}
+
+func (x *EngineInput) GetCarRange() int64 {
+	if x != nil {
+		return x.CarRange
+	}
+	return 0
+}
+
+func (x *EngineInput) GetFuelType() string {
+	if x != nil {
+		return x.FuelType
+	}
+	return ""
+}
+
+func (x *EngineInput) GetBatteryCapacityKwh() float64 {
+	if x != nil {
+		return x.BatteryCapacityKwh
+	}
+	return 0
+}
+
+func (x *EngineInput) GetMotorPowerKw() int64 {
+	if x != nil {
+		return x.MotorPowerKw
+	}
+	return 0
+}
+
+func (x *EngineInput) GetChargingStandard() string {
+	if x != nil {
+		return x.ChargingStandard
+	}
+	return ""
+}
+
+func (x *EngineInput) GetHorsepower() int64 {
+	if x != nil {
+		return x.Horsepower
+	}
+	return 0
+}
+
+func (x *EngineInput) GetTorqueNm() int64 {
+	if x != nil {
+		return x.TorqueNm
+	}
+	return 0
+}
+
+func (x *EngineInput) GetCo2GPerKm() int64 {
+	if x != nil {
+		return x.Co2GPerKm
+	}
+	return 0
+}
+
+type GetCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCarRequest) Reset() {
+	*x = GetCarRequest{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCarRequest) ProtoMessage() {}
+
+func (x *GetCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCarRequest.ProtoReflect.Descriptor instead.
+func (*GetCarRequest) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListCarsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// empty lists every brand
+	Brand string `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+	// buyers only get available cars whatever is asked for
+	Statuses []string `protobuf:"bytes,2,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	// fill in the engine specs of each car
+	IncludeEngine bool `protobuf:"varint,3,opt,name=include_engine,json=includeEngine,proto3" json:"include_engine,omitempty"`
+	// the listing details below are ignored when empty
+	Vin          string `protobuf:"bytes,4,opt,name=vin,proto3" json:"vin,omitempty"`
+	Transmission string `protobuf:"bytes,5,opt,name=transmission,proto3" json:"transmission,omitempty"`
+	Drivetrain   string `protobuf:"bytes,6,opt,name=drivetrain,proto3" json:"drivetrain,omitempty"`
+	BodyType     string `protobuf:"bytes,7,opt,name=body_type,json=bodyType,proto3" json:"body_type,omitempty"`
+	Condition    string `protobuf:"bytes,8,opt,name=condition,proto3" json:"condition,omitempty"`
+	// matched ignoring case
+	Colour        string `protobuf:"bytes,9,opt,name=colour,proto3" json:"colour,omitempty"`
+	MaxOdometerKm int64  `protobuf:"varint,10,opt,name=max_odometer_km,json=maxOdometerKm,proto3" json:"max_odometer_km,omitempty"`
+	// cars having every one of these
+	Features      []string `protobuf:"bytes,11,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCarsRequest) Reset() {
+	*x = ListCarsRequest{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCarsRequest) ProtoMessage() {}
+
+func (x *ListCarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCarsRequest.ProtoReflect.Descriptor instead.
+func (*ListCarsRequest) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListCarsRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *ListCarsRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListCarsRequest) GetIncludeEngine() bool {
+	if x != nil {
+		return x.IncludeEngine
+	}
+	return false
+}
+
+func (x *ListCarsRequest) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *ListCarsRequest) GetTransmission() string {
+	if x != nil {
+		return x.Transmission
+	}
+	return ""
+}
+
+func (x *ListCarsRequest) GetDrivetrain() string {
+	if x != nil {
+		return x.Drivetrain
+	}
+	return ""
+}
+
+func (x *ListCarsRequest) GetBodyType() string {
+	if x != nil {
+		return x.BodyType
+	}
+	return ""
+}
+
+func (x *ListCarsRequest) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *ListCarsRequest) GetColour() string {
+	if x != nil {
+		return x.Colour
+	}
+	return ""
+}
+
+func (x *ListCarsRequest) GetMaxOdometerKm() int64 {
+	if x != nil {
+		return x.MaxOdometerKm
+	}
+	return 0
+}
+
+func (x *ListCarsRequest) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type ListMyCarsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyCarsRequest) Reset() {
+	*x = ListMyCarsRequest{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyCarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyCarsRequest) ProtoMessage() {}
+
+func (x *ListMyCarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyCarsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyCarsRequest) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{6}
+}
+
+type CreateCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Car           *CarInput              `protobuf:"bytes,1,opt,name=car,proto3" json:"car,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCarRequest) Reset() {
+	*x = CreateCarRequest{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCarRequest) ProtoMessage() {}
+
+func (x *CreateCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCarRequest.ProtoReflect.Descriptor instead.
+func (*CreateCarRequest) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateCarRequest) GetCar() *CarInput {
+	if x != nil {
+		return x.Car
+	}
+	return nil
+}
+
+type UpdateCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Car           *CarInput              `protobuf:"bytes,2,opt,name=car,proto3" json:"car,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCarRequest) Reset() {
+	*x = UpdateCarRequest{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCarRequest) ProtoMessage() {}
+
+func (x *UpdateCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCarRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCarRequest) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateCarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCarRequest) GetCar() *CarInput {
+	if x != nil {
+		return x.Car
+	}
+	return nil
+}
+
+type DeleteCarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCarRequest) Reset() {
+	*x = DeleteCarRequest{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCarRequest) ProtoMessage() {}
+
+func (x *DeleteCarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCarRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCarRequest) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteCarRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetEngineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEngineRequest) Reset() {
+	*x = GetEngineRequest{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEngineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEngineRequest) ProtoMessage() {}
+
+func (x *GetEngineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEngineRequest.ProtoReflect.Descriptor instead.
+func (*GetEngineRequest) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetEngineRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListEnginesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// engines that do not exist are left out
+	Ids           []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEnginesRequest) Reset() {
+	*x = ListEnginesRequest{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEnginesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEnginesRequest) ProtoMessage() {}
+
+func (x *ListEnginesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEnginesRequest.ProtoReflect.Descriptor instead.
+func (*ListEnginesRequest) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListEnginesRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type CreateEngineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Engine        *EngineInput           `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEngineRequest) Reset() {
+	*x = CreateEngineRequest{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEngineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEngineRequest) ProtoMessage() {}
+
+func (x *CreateEngineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEngineRequest.ProtoReflect.Descriptor instead.
+func (*CreateEngineRequest) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateEngineRequest) GetEngine() *EngineInput {
+	if x != nil {
+		return x.Engine
+	}
+	return nil
+}
+
+type UpdateEngineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Engine        *EngineInput           `protobuf:"bytes,2,opt,name=engine,proto3" json:"engine,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEngineRequest) Reset() {
+	*x = UpdateEngineRequest{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEngineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEngineRequest) ProtoMessage() {}
+
+func (x *UpdateEngineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEngineRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEngineRequest) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateEngineRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateEngineRequest) GetEngine() *EngineInput {
+	if x != nil {
+		return x.Engine
+	}
+	return nil
+}
+
+type DeleteEngineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEngineRequest) Reset() {
+	*x = DeleteEngineRequest{}
+	mi := &file_carzone_v1_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEngineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEngineRequest) ProtoMessage() {}
+
+func (x *DeleteEngineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_carzone_v1_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEngineRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEngineRequest) Descriptor() ([]byte, []int) {
+	return file_carzone_v1_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteEngineRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_carzone_v1_inventory_proto protoreflect.FileDescriptor
+
+const file_carzone_v1_inventory_proto_rawDesc = "" +
+	"\n" +
+	"\x1acarzone/v1/inventory.proto\x12\n" +
+	"carzone.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd9\x03\n" +
+	"\x06Engine\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\"\n" +
+	"\fdisplacement\x18\x03 \x01(\x03R\fdisplacement\x12&\n" +
+	"\x0fno_of_cylinders\x18\x04 \x01(\x03R\rnoOfCylinders\x12\x1b\n" +
+	"\tcar_range\x18\x05 \x01(\x03R\bcarRange\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
+	"\tfuel_type\x18\a \x01(\tR\bfuelType\x120\n" +
+	"\x14battery_capacity_kwh\x18\b \x01(\x01R\x12batteryCapacityKwh\x12$\n" +
+	"\x0emotor_power_kw\x18\t \x01(\x03R\fmotorPowerKw\x12+\n" +
+	"\x11charging_standard\x18\n" +
+	" \x01(\tR\x10chargingStandard\x12\x1e\n" +
+	"\n" +
+	"horsepower\x18\v \x01(\x03R\n" +
+	"horsepower\x12\x1b\n" +
+	"\ttorque_nm\x18\f \x01(\x03R\btorqueNm\x12\x1f\n" +
+	"\fco2_g_per_km\x18\r \x01(\x03R\tco2GPerKm\"\x91\x06\n" +
+	"\x03Car\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x12\n" +
+	"\x04year\x18\x05 \x01(\tR\x04year\x12\x14\n" +
+	"\x05brand\x18\x06 \x01(\tR\x05brand\x12\x1b\n" +
+	"\tfuel_type\x18\a \x01(\tR\bfuelType\x12*\n" +
+	"\x06engine\x18\b \x01(\v2\x12.carzone.v1.EngineR\x06engine\x12\x14\n" +
+	"\x05price\x18\t \x01(\x01R\x05price\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12\x1f\n" +
+	"\vreserved_by\x18\v \x01(\tR\n" +
+	"reservedBy\x12A\n" +
+	"\x0ereserved_until\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\rreservedUntil\x123\n" +
+	"\asold_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x06soldAt\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x10\n" +
+	"\x03vin\x18\x10 \x01(\tR\x03vin\x12\x1f\n" +
+	"\vodometer_km\x18\x11 \x01(\x03R\n" +
+	"odometerKm\x12\"\n" +
+	"\ftransmission\x18\x12 \x01(\tR\ftransmission\x12\x1e\n" +
+	"\n" +
+	"drivetrain\x18\x13 \x01(\tR\n" +
+	"drivetrain\x12\x16\n" +
+	"\x06colour\x18\x14 \x01(\tR\x06colour\x12\x1b\n" +
+	"\tbody_type\x18\x15 \x01(\tR\bbodyType\x12\x1c\n" +
+	"\tcondition\x18\x16 \x01(\tR\tcondition\x12\x1a\n" +
+	"\bfeatures\x18\x17 \x03(\tR\bfeatures\x12\x14\n" +
+	"\x05model\x18\x18 \x01(\tR\x05model\"\xac\x03\n" +
+	"\bCarInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04year\x18\x02 \x01(\tR\x04year\x12\x14\n" +
+	"\x05brand\x18\x03 \x01(\tR\x05brand\x12\x1b\n" +
+	"\tfuel_type\x18\x04 \x01(\tR\bfuelType\x12\x1b\n" +
+	"\tengine_id\x18\x05 \x01(\tR\bengineId\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\x01R\x05price\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x10\n" +
+	"\x03vin\x18\b \x01(\tR\x03vin\x12\x1f\n" +
+	"\vodometer_km\x18\t \x01(\x03R\n" +
+	"odometerKm\x12\"\n" +
+	"\ftransmission\x18\n" +
+	" \x01(\tR\ftransmission\x12\x1e\n" +
+	"\n" +
+	"drivetrain\x18\v \x01(\tR\n" +
+	"drivetrain\x12\x16\n" +
+	"\x06colour\x18\f \x01(\tR\x06colour\x12\x1b\n" +
+	"\tbody_type\x18\r \x01(\tR\bbodyType\x12\x1c\n" +
+	"\tcondition\x18\x0e \x01(\tR\tcondition\x12\x1a\n" +
+	"\bfeatures\x18\x0f \x03(\tR\bfeatures\x12\x14\n" +
+	"\x05model\x18\x10 \x01(\tR\x05model\"\xf6\x02\n" +
+	"\vEngineInput\x12\"\n" +
+	"\fdisplacement\x18\x01 \x01(\x03R\fdisplacement\x12&\n" +
+	"\x0fno_of_cylinders\x18\x02 \x01(\x03R\rnoOfCylinders\x12\x1b\n" +
+	"\tcar_range\x18\x03 \x01(\x03R\bcarRange\x12\x1b\n" +
+	"\tfuel_type\x18\x04 \x01(\tR\bfuelType\x120\n" +
+	"\x14battery_capacity_kwh\x18\x05 \x01(\x01R\x12batteryCapacityKwh\x12$\n" +
+	"\x0emotor_power_kw\x18\x06 \x01(\x03R\fmotorPowerKw\x12+\n" +
+	"\x11charging_standard\x18\a \x01(\tR\x10chargingStandard\x12\x1e\n" +
+	"\n" +
+	"horsepower\x18\b \x01(\x03R\n" +
+	"horsepower\x12\x1b\n" +
+	"\ttorque_nm\x18\t \x01(\x03R\btorqueNm\x12\x1f\n" +
+	"\fco2_g_per_km\x18\n" +
+	" \x01(\x03R\tco2GPerKm\"\x1f\n" +
+	"\rGetCarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xd7\x02\n" +
+	"\x0fListCarsRequest\x12\x14\n" +
+	"\x05brand\x18\x01 \x01(\tR\x05brand\x12\x1a\n" +
+	"\bstatuses\x18\x02 \x03(\tR\bstatuses\x12%\n" +
+	"\x0einclude_engine\x18\x03 \x01(\bR\rincludeEngine\x12\x10\n" +
+	"\x03vin\x18\x04 \x01(\tR\x03vin\x12\"\n" +
+	"\ftransmission\x18\x05 \x01(\tR\ftransmission\x12\x1e\n" +
+	"\n" +
+	"drivetrain\x18\x06 \x01(\tR\n" +
+	"drivetrain\x12\x1b\n" +
+	"\tbody_type\x18\a \x01(\tR\bbodyType\x12\x1c\n" +
+	"\tcondition\x18\b \x01(\tR\tcondition\x12\x16\n" +
+	"\x06colour\x18\t \x01(\tR\x06colour\x12&\n" +
+	"\x0fmax_odometer_km\x18\n" +
+	" \x01(\x03R\rmaxOdometerKm\x12\x1a\n" +
+	"\bfeatures\x18\v \x03(\tR\bfeatures\"\x13\n" +
+	"\x11ListMyCarsRequest\":\n" +
+	"\x10CreateCarRequest\x12&\n" +
+	"\x03car\x18\x01 \x01(\v2\x14.carzone.v1.CarInputR\x03car\"J\n" +
+	"\x10UpdateCarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x03car\x18\x02 \x01(\v2\x14.carzone.v1.CarInputR\x03car\"\"\n" +
+	"\x10DeleteCarRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\"\n" +
+	"\x10GetEngineRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
+	"\x12ListEnginesRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"F\n" +
+	"\x13CreateEngineRequest\x12/\n" +
+	"\x06engine\x18\x01 \x01(\v2\x17.carzone.v1.EngineInputR\x06engine\"V\n" +
+	"\x13UpdateEngineRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12/\n" +
+	"\x06engine\x18\x02 \x01(\v2\x17.carzone.v1.EngineInputR\x06engine\"%\n" +
+	"\x13DeleteEngineRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id2\xf2\x02\n" +
+	"\n" +
+	"CarService\x124\n" +
+	"\x06GetCar\x12\x19.carzone.v1.GetCarRequest\x1a\x0f.carzone.v1.Car\x12:\n" +
+	"\bListCars\x12\x1b.carzone.v1.ListCarsRequest\x1a\x0f.carzone.v1.Car0\x01\x12>\n" +
+	"\n" +
+	"ListMyCars\x12\x1d.carzone.v1.ListMyCarsRequest\x1a\x0f.carzone.v1.Car0\x01\x12:\n" +
+	"\tCreateCar\x12\x1c.carzone.v1.CreateCarRequest\x1a\x0f.carzone.v1.Car\x12:\n" +
+	"\tUpdateCar\x12\x1c.carzone.v1.UpdateCarRequest\x1a\x0f.carzone.v1.Car\x12:\n" +
+	"\tDeleteCar\x12\x1c.carzone.v1.DeleteCarRequest\x1a\x0f.carzone.v1.Car2\xe2\x02\n" +
+	"\rEngineService\x12=\n" +
+	"\tGetEngine\x12\x1c.carzone.v1.GetEngineRequest\x1a\x12.carzone.v1.Engine\x12C\n" +
+	"\vListEngines\x12\x1e.carzone.v1.ListEnginesRequest\x1a\x12.carzone.v1.Engine0\x01\x12C\n" +
+	"\fCreateEngine\x12\x1f.carzone.v1.CreateEngineRequest\x1a\x12.carzone.v1.Engine\x12C\n" +
+	"\fUpdateEngine\x12\x1f.carzone.v1.UpdateEngineRequest\x1a\x12.carzone.v1.Engine\x12C\n" +
+	"\fDeleteEngine\x12\x1f.carzone.v1.DeleteEngineRequest\x1a\x12.carzone.v1.EngineB=Z;github.com/iangechuki/go_carzone/proto/carzone/v1;carzonev1b\x06proto3"
+
+var (
+	file_carzone_v1_inventory_proto_rawDescOnce sync.Once
+	file_carzone_v1_inventory_proto_rawDescData []byte
+)
+
+func file_carzone_v1_inventory_proto_rawDescGZIP() []byte {
+	file_carzone_v1_inventory_proto_rawDescOnce.Do(func() {
+		file_carzone_v1_inventory_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_carzone_v1_inventory_proto_rawDesc), len(file_carzone_v1_inventory_proto_rawDesc)))
+	})
+	return file_carzone_v1_inventory_proto_rawDescData
+}
+
+var file_carzone_v1_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_carzone_v1_inventory_proto_goTypes = []any{
+	(*Engine)(nil),                // 0: carzone.v1.Engine
+	(*Car)(nil),                   // 1: carzone.v1.Car
+	(*CarInput)(nil),              // 2: carzone.v1.CarInput
+	(*EngineInput)(nil),           // 3: carzone.v1.EngineInput
+	(*GetCarRequest)(nil),         // 4: carzone.v1.GetCarRequest
+	(*ListCarsRequest)(nil),       // 5: carzone.v1.ListCarsRequest
+	(*ListMyCarsRequest)(nil),     // 6: carzone.v1.ListMyCarsRequest
+	(*CreateCarRequest)(nil),      // 7: carzone.v1.CreateCarRequest
+	(*UpdateCarRequest)(nil),      // 8: carzone.v1.UpdateCarRequest
+	(*DeleteCarRequest)(nil),      // 9: carzone.v1.DeleteCarRequest
+	(*GetEngineRequest)(nil),      // 10: carzone.v1.GetEngineRequest
+	(*ListEnginesRequest)(nil),    // 11: carzone.v1.ListEnginesRequest
+	(*CreateEngineRequest)(nil),   // 12: carzone.v1.CreateEngineRequest
+	(*UpdateEngineRequest)(nil),   // 13: carzone.v1.UpdateEngineRequest
+	(*DeleteEngineRequest)(nil),   // 14: carzone.v1.DeleteEngineRequest
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+}
+var file_carzone_v1_inventory_proto_depIdxs = []int32{
+	15, // 0: carzone.v1.Engine.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: carzone.v1.Car.engine:type_name -> carzone.v1.Engine
+	15, // 2: carzone.v1.Car.reserved_until:type_name -> google.protobuf.Timestamp
+	15, // 3: carzone.v1.Car.sold_at:type_name -> google.protobuf.Timestamp
+	15, // 4: carzone.v1.Car.created_at:type_name -> google.protobuf.Timestamp
+	15, // 5: carzone.v1.Car.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 6: carzone.v1.CreateCarRequest.car:type_name -> carzone.v1.CarInput
+	2,  // 7: carzone.v1.UpdateCarRequest.car:type_name -> carzone.v1.CarInput
+	3,  // 8: carzone.v1.CreateEngineRequest.engine:type_name -> carzone.v1.EngineInput
+	3,  // 9: carzone.v1.UpdateEngineRequest.engine:type_name -> carzone.v1.EngineInput
+	4,  // 10: carzone.v1.CarService.GetCar:input_type -> carzone.v1.GetCarRequest
+	5,  // 11: carzone.v1.CarService.ListCars:input_type -> carzone.v1.ListCarsRequest
+	6,  // 12: carzone.v1.CarService.ListMyCars:input_type -> carzone.v1.ListMyCarsRequest
+	7,  // 13: carzone.v1.CarService.CreateCar:input_type -> carzone.v1.CreateCarRequest
+	8,  // 14: carzone.v1.CarService.UpdateCar:input_type -> carzone.v1.UpdateCarRequest
+	9,  // 15: carzone.v1.CarService.DeleteCar:input_type -> carzone.v1.DeleteCarRequest
+	10, // 16: carzone.v1.EngineService.GetEngine:input_type -> carzone.v1.GetEngineRequest
+	11, // 17: carzone.v1.EngineService.ListEngines:input_type -> carzone.v1.ListEnginesRequest
+	12, // 18: carzone.v1.EngineService.CreateEngine:input_type -> carzone.v1.CreateEngineRequest
+	13, // 19: carzone.v1.EngineService.UpdateEngine:input_type -> carzone.v1.UpdateEngineRequest
+	14, // 20: carzone.v1.EngineService.DeleteEngine:input_type -> carzone.v1.DeleteEngineRequest
+	1,  // 21: carzone.v1.CarService.GetCar:output_type -> carzone.v1.Car
+	1,  // 22: carzone.v1.CarService.ListCars:output_type -> carzone.v1.Car
+	1,  // 23: carzone.v1.CarService.ListMyCars:output_type -> carzone.v1.Car
+	1,  // 24: carzone.v1.CarService.CreateCar:output_type -> carzone.v1.Car
+	1,  // 25: carzone.v1.CarService.UpdateCar:output_type -> carzone.v1.Car
+	1,  // 26: carzone.v1.CarService.DeleteCar:output_type -> carzone.v1.Car
+	0,  // 27: carzone.v1.EngineService.GetEngine:output_type -> carzone.v1.Engine
+	0,  // 28: carzone.v1.EngineService.ListEngines:output_type -> carzone.v1.Engine
+	0,  // 29: carzone.v1.EngineService.CreateEngine:output_type -> carzone.v1.Engine
+	0,  // 30: carzone.v1.EngineService.UpdateEngine:output_type -> carzone.v1.Engine
+	0,  // 31: carzone.v1.EngineService.DeleteEngine:output_type -> carzone.v1.Engine
+	21, // [21:32] is the sub-list for method output_type
+	10, // [10:21] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_carzone_v1_inventory_proto_init() }
+func file_carzone_v1_inventory_proto_init() {
+	if File_carzone_v1_inventory_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_carzone_v1_inventory_proto_rawDesc), len(file_carzone_v1_inventory_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_carzone_v1_inventory_proto_goTypes,
+		DependencyIndexes: file_carzone_v1_inventory_proto_depIdxs,
+		MessageInfos:      file_carzone_v1_inventory_proto_msgTypes,
+	}.Build()
+	File_carzone_v1_inventory_proto = out.File
+	file_carzone_v1_inventory_proto_goTypes = nil
+	file_carzone_v1_inventory_proto_depIdxs = nil
+}
@@ -0,0 +1,136 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// keys look like cz_<prefix>_<secret>, the prefix is kept in clear so keys can be told apart in listings
+const keyPrefix = "cz_"
+
+type APIKeyService struct {
+	store store.APIKeyStoreInterface
+}
+
+func NewAPIKeyService(store store.APIKeyStoreInterface) *APIKeyService {
+	return &APIKeyService{
+		store: store,
+	}
+}
+
+func (s *APIKeyService)CreateAPIKey(ctx context.Context,keyReq *models.APIKeyRequest) (*models.IssuedAPIKey,error) {
+	tracer := otel.Tracer("APIKeyService")
+	ctx,span := tracer.Start(ctx, "CreateAPIKey-Service")
+	defer span.End()
+
+	if err := models.ValidateAPIKeyRequest(keyReq); err != nil {
+		return nil,err
+	}
+	// keys carry no roles, so one without a dealership could never pass
+	// TenantMiddleware. Platform admins issue them for the dealership they
+	// name in X-Tenant-ID.
+	if !auth.TenantID(ctx).Valid {
+		return nil,fmt.Errorf("%w: api keys belong to one dealership",models.ErrTenantRequired)
+	}
+	prefix,err := randomString(6)
+	if err != nil {
+		return nil,err
+	}
+	secret,err := randomString(32)
+	if err != nil {
+		return nil,err
+	}
+	rawKey := keyPrefix + prefix + "_" + secret
+	key := models.APIKey{
+		ID: uuid.New(),
+		Name: keyReq.Name,
+		Prefix: keyPrefix + prefix,
+		Scopes: keyReq.Scopes,
+		CreatedBy: auth.UserID(ctx),
+		TenantID: auth.TenantID(ctx),
+		CreatedAt: time.Now(),
+	}
+	createdKey,err := s.store.CreateAPIKey(ctx,key,hashKey(rawKey))
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s issued api key %s",auth.Actor(ctx),createdKey.ID)
+	return &models.IssuedAPIKey{APIKey: createdKey,Key: rawKey},nil
+}
+
+func (s *APIKeyService)ListAPIKeys(ctx context.Context) ([]models.APIKey,error) {
+	tracer := otel.Tracer("APIKeyService")
+	ctx,span := tracer.Start(ctx, "ListAPIKeys-Service")
+	defer span.End()
+
+	return s.store.ListAPIKeys(ctx)
+}
+
+func (s *APIKeyService)RevokeAPIKey(ctx context.Context,id string) (*models.APIKey,error) {
+	tracer := otel.Tracer("APIKeyService")
+	ctx,span := tracer.Start(ctx, "RevokeAPIKey-Service")
+	defer span.End()
+
+	// ids are uuids, anything else can't name a key
+	if _,err := uuid.Parse(id); err != nil {
+		return nil,models.ErrRecordNotFound
+	}
+	key,err := s.store.RevokeAPIKey(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s revoked api key %s",auth.Actor(ctx),key.ID)
+	return &key,nil
+}
+
+func (s *APIKeyService)AuthenticateAPIKey(ctx context.Context,rawKey string) (*models.APIKey,error) {
+	tracer := otel.Tracer("APIKeyService")
+	ctx,span := tracer.Start(ctx, "AuthenticateAPIKey-Service")
+	defer span.End()
+
+	if !strings.HasPrefix(rawKey,keyPrefix) {
+		return nil,models.ErrInvalidAPIKey
+	}
+	key,err := s.store.GetAPIKeyByHash(ctx,hashKey(rawKey))
+	if err != nil {
+		if errors.Is(err,models.ErrRecordNotFound) {
+			return nil,models.ErrInvalidAPIKey
+		}
+		return nil,err
+	}
+	if key.RevokedAt != nil {
+		return nil,models.ErrInvalidAPIKey
+	}
+	if err := s.store.TouchAPIKey(ctx,key.ID.String(),time.Now()); err != nil {
+		log.Println("Error recording api key usage: ",err)
+	}
+	return &key,nil
+}
+
+// keys carry 256 bits of randomness so a plain sha256 is enough, no need for a slow password hash
+func hashKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int) (string,error) {
+	b := make([]byte,n)
+	if _,err := rand.Read(b); err != nil {
+		return "",err
+	}
+	return strings.ReplaceAll(base64.RawURLEncoding.EncodeToString(b),"_","-"),nil
+}
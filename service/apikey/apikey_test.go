@@ -0,0 +1,166 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+)
+
+// memoryStore keeps keys by hash the way the postgres store does.
+type memoryStore struct {
+	keys map[string]models.APIKey
+	touched []string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{keys: make(map[string]models.APIKey)}
+}
+
+func (s *memoryStore)CreateAPIKey(ctx context.Context,key models.APIKey,keyHash string) (models.APIKey,error) {
+	s.keys[keyHash] = key
+	return key,nil
+}
+
+func (s *memoryStore)GetAPIKeyByHash(ctx context.Context,keyHash string) (models.APIKey,error) {
+	key,ok := s.keys[keyHash]
+	if !ok {
+		return models.APIKey{},models.ErrRecordNotFound
+	}
+	return key,nil
+}
+
+func (s *memoryStore)ListAPIKeys(ctx context.Context) ([]models.APIKey,error) {
+	keys := []models.APIKey{}
+	for _,key := range s.keys {
+		keys = append(keys,key)
+	}
+	return keys,nil
+}
+
+func (s *memoryStore)RevokeAPIKey(ctx context.Context,id string) (models.APIKey,error) {
+	for hash,key := range s.keys {
+		if key.ID.String() == id && key.RevokedAt == nil {
+			now := time.Now()
+			key.RevokedAt = &now
+			s.keys[hash] = key
+			return key,nil
+		}
+	}
+	return models.APIKey{},models.ErrRecordNotFound
+}
+
+func (s *memoryStore)TouchAPIKey(ctx context.Context,id string,usedAt time.Time) error {
+	s.touched = append(s.touched,id)
+	return nil
+}
+
+func staffContext(tenantID uuid.NullUUID) context.Context {
+	return auth.WithPrincipal(context.Background(),&auth.Principal{
+		UserID: "admin",
+		Roles: []string{models.RoleAdmin},
+		TenantID: tenantID,
+	})
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	store := newMemoryStore()
+	service := NewAPIKeyService(store)
+	tenantID := uuid.NullUUID{UUID: uuid.New(),Valid: true}
+
+	issued,err := service.CreateAPIKey(staffContext(tenantID),&models.APIKeyRequest{Name: "feed",Scopes: []string{models.ScopeCarsRead}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(issued.Key,issued.Prefix + "_") || !strings.HasPrefix(issued.Prefix,keyPrefix) {
+		t.Errorf("key %q doesn't start with its prefix %q",issued.Key,issued.Prefix)
+	}
+	if issued.TenantID != tenantID || issued.CreatedBy != "admin" {
+		t.Errorf("issued key = %+v",issued.APIKey)
+	}
+	if _,ok := store.keys[issued.Key]; ok {
+		t.Error("the plain key was stored")
+	}
+	if _,ok := store.keys[hashKey(issued.Key)]; !ok {
+		t.Error("the key's hash was not stored")
+	}
+
+	other,err := service.CreateAPIKey(staffContext(tenantID),&models.APIKeyRequest{Name: "feed",Scopes: []string{models.ScopeCarsRead}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Key == issued.Key || other.Prefix == issued.Prefix {
+		t.Error("two keys came out the same")
+	}
+}
+
+func TestCreateAPIKeyRejects(t *testing.T) {
+	service := NewAPIKeyService(newMemoryStore())
+	tenantID := uuid.NullUUID{UUID: uuid.New(),Valid: true}
+	tests := []struct {
+		name string
+		ctx context.Context
+		keyReq models.APIKeyRequest
+		want error
+	}{
+		{name: "platform admin without X-Tenant-ID",ctx: staffContext(uuid.NullUUID{}),keyReq: models.APIKeyRequest{Name: "feed",Scopes: []string{models.ScopeCarsRead}},want: models.ErrTenantRequired},
+		{name: "no name",ctx: staffContext(tenantID),keyReq: models.APIKeyRequest{Scopes: []string{models.ScopeCarsRead}},want: models.ErrInvalidAPIKeyRequest},
+		{name: "no scopes",ctx: staffContext(tenantID),keyReq: models.APIKeyRequest{Name: "feed"},want: models.ErrInvalidAPIKeyRequest},
+		{name: "unknown scope",ctx: staffContext(tenantID),keyReq: models.APIKeyRequest{Name: "feed",Scopes: []string{"cars:everything"}},want: models.ErrInvalidAPIKeyRequest},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			if _,err := service.CreateAPIKey(tt.ctx,&tt.keyReq); !errors.Is(err,tt.want) {
+				t.Errorf("CreateAPIKey = %v, want %v",err,tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	store := newMemoryStore()
+	service := NewAPIKeyService(store)
+	ctx := staffContext(uuid.NullUUID{UUID: uuid.New(),Valid: true})
+	issued,err := service.CreateAPIKey(ctx,&models.APIKeyRequest{Name: "feed",Scopes: []string{models.ScopeCarsRead}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key,err := service.AuthenticateAPIKey(context.Background(),issued.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.ID != issued.ID {
+		t.Errorf("authenticated key %s, want %s",key.ID,issued.ID)
+	}
+	if len(store.touched) != 1 || store.touched[0] != issued.ID.String() {
+		t.Errorf("touched %v, want [%s]",store.touched,issued.ID)
+	}
+	for _,rawKey := range []string{"",issued.Prefix,issued.Key + "x",strings.TrimPrefix(issued.Key,keyPrefix)} {
+		if _,err := service.AuthenticateAPIKey(context.Background(),rawKey); !errors.Is(err,models.ErrInvalidAPIKey) {
+			t.Errorf("AuthenticateAPIKey(%q) = %v, want %v",rawKey,err,models.ErrInvalidAPIKey)
+		}
+	}
+
+	if _,err := service.RevokeAPIKey(ctx,issued.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	if _,err := service.AuthenticateAPIKey(context.Background(),issued.Key); !errors.Is(err,models.ErrInvalidAPIKey) {
+		t.Errorf("revoked key: %v, want %v",err,models.ErrInvalidAPIKey)
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	service := NewAPIKeyService(newMemoryStore())
+	ctx := staffContext(uuid.NullUUID{UUID: uuid.New(),Valid: true})
+	for _,id := range []string{"not-a-uuid","",uuid.NewString()} {
+		if _,err := service.RevokeAPIKey(ctx,id); !errors.Is(err,models.ErrRecordNotFound) {
+			t.Errorf("RevokeAPIKey(%q) = %v, want %v",id,err,models.ErrRecordNotFound)
+		}
+	}
+}
@@ -2,20 +2,38 @@ package car
 
 import (
 	"context"
+	"log"
+	"strconv"
+	"time"
 
+	"github.com/iangechuki/go_carzone/auth"
 	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
 	"github.com/iangechuki/go_carzone/store"
 	"go.opentelemetry.io/otel"
 )
 
 type CarService struct {
 	store store.CarStoreInterface
+	vinDecoder service.VINServiceInterface
+	catalogue service.CatalogueServiceInterface
 } 
 func NewCarService(store store.CarStoreInterface) *CarService {
 	return &CarService{
 		store: store,
 	}
 }
+// WithVINDecoder fills in the brand, model, year and name a new car leaves out from its VIN.
+func (s *CarService)WithVINDecoder(decoder service.VINServiceInterface) *CarService {
+	s.vinDecoder = decoder
+	return s
+}
+// WithCatalogue checks brands and models against the catalogue and lists
+// cars by any spelling of their brand.
+func (s *CarService)WithCatalogue(catalogue service.CatalogueServiceInterface) *CarService {
+	s.catalogue = catalogue
+	return s
+}
 func (s *CarService)GetCarByID(ctx context.Context,id string) (*models.Car,error) {
 	tracer := otel.Tracer("CarService")
 	ctx,span := tracer.Start(ctx, "GetCarByID-Service")
@@ -25,31 +43,73 @@ func (s *CarService)GetCarByID(ctx context.Context,id string) (*models.Car,error
 	if err != nil {
 		return nil,err
 	}
+	if !visibleToBuyer(ctx,&car) {
+		return nil,models.ErrRecordNotFound
+	}
 	return &car,nil
 }
-func (s *CarService)GetCarsByBrand(ctx context.Context,brand string,isEngine bool) ([]models.Car,error) {
+// GetCarsByBrand only lists available cars to buyers, staff may filter on any status.
+func (s *CarService)GetCarsByBrand(ctx context.Context,brand string,isEngine bool,filter models.CarFilter) ([]models.Car,error) {
 	tracer := otel.Tracer("CarService")
 	ctx,span := tracer.Start(ctx, "GetCarsByBrand-Service")
 	defer span.End()
 
-	cars,err := s.store.GetCarsByBrand(ctx,brand,isEngine)
+	if err := models.ValidateCarFilter(&filter); err != nil {
+		return nil,err
+	}
+	for _,status := range filter.Statuses {
+		if err := models.ValidateStatus(status); err != nil {
+			return nil,err
+		}
+	}
+	if !isStaff(ctx) {
+		filter.Statuses = []string{models.CarStatusAvailable}
+	}
+	if brand != "" {
+		catalogue,err := s.loadCatalogue(ctx)
+		if err != nil {
+			return nil,err
+		}
+		if b,ok := catalogue.Brand(brand); ok {
+			brand = b.Name
+		}
+	}
+	cars,err := s.store.GetCarsByBrand(ctx,brand,isEngine,filter)
 	if err != nil {
 		return nil,err
 	}
 	return cars,err
 }
+// GetMyCars lists the listings created by the caller.
+func (s *CarService)GetMyCars(ctx context.Context) ([]models.Car,error) {
+	tracer := otel.Tracer("CarService")
+	ctx,span := tracer.Start(ctx, "GetMyCars-Service")
+	defer span.End()
+
+	userID := auth.UserID(ctx)
+	if userID == "" {
+		return nil,models.ErrForbidden
+	}
+	return s.store.GetCarsByOwner(ctx,userID)
+}
 func (s *CarService)CreateCar(ctx context.Context,car *models.CarRequest) (*models.Car,error) {
 	tracer := otel.Tracer("CarService")
 	ctx,span := tracer.Start(ctx, "CreateCar-Service")
 	defer span.End()
 	
-	if err:= models.ValidateRequest(car); err != nil {
+	catalogue,err := s.loadCatalogue(ctx)
+	if err != nil {
+		return nil,err
+	}
+	s.prefill(ctx,car,catalogue)
+	if err:= models.ValidateRequest(car,catalogue); err != nil {
 		return nil,err
 	}
 	createdCar,err := s.store.CreateCar(ctx,car)
 	if err != nil {
 		return nil,err
 	}
+	log.Printf("audit: %s created car %s",auth.Actor(ctx),createdCar.ID)
 	return &createdCar,err
 }
 func (s *CarService)UpdateCar(ctx context.Context,id string,carReq *models.CarRequest) (*models.Car,error) {
@@ -57,13 +117,22 @@ func (s *CarService)UpdateCar(ctx context.Context,id string,carReq *models.CarRe
 	ctx,span := tracer.Start(ctx, "UpdateCar-Service")
 	defer span.End()
 
-	if err := models.ValidateRequest(carReq);err != nil {
+	catalogue,err := s.loadCatalogue(ctx)
+	if err != nil {
+		return nil,err
+	}
+	if err := models.ValidateRequest(carReq,catalogue);err != nil {
+		return nil,err
+	}
+	ownerID,err := ownerFilter(ctx)
+	if err != nil {
 		return nil,err
 	}
-	updatedCar,err := s.store.UpdateCar(ctx,id,carReq)
+	updatedCar,err := s.store.UpdateCar(ctx,id,ownerID,carReq)
 	if err != nil {
 		return nil,err
 	}
+	log.Printf("audit: %s updated car %s",auth.Actor(ctx),updatedCar.ID)
 	return &updatedCar,err
 }
 func (s *CarService)DeleteCar(ctx context.Context,id string) (*models.Car,error) {
@@ -71,9 +140,178 @@ func (s *CarService)DeleteCar(ctx context.Context,id string) (*models.Car,error)
 	ctx,span := tracer.Start(ctx, "DeleteCar-Service")
 	defer span.End()
 	
-	deletedCar,err := s.store.DeleteCar(ctx,id)
+	ownerID,err := ownerFilter(ctx)
+	if err != nil {
+		return nil,err
+	}
+	deletedCar,err := s.store.DeleteCar(ctx,id,ownerID)
 	if err != nil {
 		return nil,err
 	}
+	log.Printf("audit: %s deleted car %s",auth.Actor(ctx),deletedCar.ID)
 	return &deletedCar,err
-}
\ No newline at end of file
+}
+// prefill only touches fields left empty, a VIN that doesn't decode is
+// reported by validation instead. A decoded model the catalogue doesn't
+// list for the brand is left out rather than failing the car.
+func (s *CarService)prefill(ctx context.Context,car *models.CarRequest,catalogue *models.Catalogue) {
+	if s.vinDecoder == nil || car.VIN == "" {
+		return
+	}
+	info,err := s.vinDecoder.DecodeVIN(ctx,car.VIN)
+	if err != nil {
+		return
+	}
+	if car.Brand == "" {
+		car.Brand = info.Brand
+	}
+	if car.Model == "" && knownModel(catalogue,car.Brand,info.Model) {
+		car.Model = info.Model
+	}
+	if car.Year == "" && info.ModelYear != 0 {
+		car.Year = strconv.Itoa(info.ModelYear)
+	}
+	if car.Name == "" && info.Brand != "" && info.Model != "" {
+		car.Name = info.Brand + " " + info.Model
+	}
+}
+func knownModel(catalogue *models.Catalogue,brand string,model string) bool {
+	if catalogue == nil {
+		return true
+	}
+	b,ok := catalogue.Brand(brand)
+	if !ok || len(b.Models) == 0 {
+		return true
+	}
+	_,ok = catalogue.Model(b,model)
+	return ok
+}
+// loadCatalogue is nil without a catalogue, which skips the checks against it.
+func (s *CarService)loadCatalogue(ctx context.Context) (*models.Catalogue,error) {
+	if s.catalogue == nil {
+		return nil,nil
+	}
+	return s.catalogue.Catalogue(ctx)
+}
+// ownerFilter is the creator a listing has to have for the caller to change
+// it, empty for admins who may change any. The stores check it in the same
+// statement as the change so a listing can't be swapped out in between.
+func ownerFilter(ctx context.Context) (string,error) {
+	principal,ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return "",models.ErrForbidden
+	}
+	if principal.IsAdmin() {
+		return "",nil
+	}
+	return principal.UserID,nil
+}
+
+// ReserveCar holds an available car for the caller until the reservation expires.
+func (s *CarService)ReserveCar(ctx context.Context,id string,req *models.ReservationRequest) (*models.Car,error) {
+	tracer := otel.Tracer("CarService")
+	ctx,span := tracer.Start(ctx, "ReserveCar-Service")
+	defer span.End()
+
+	expiresAt,err := models.ReservationExpiry(req,time.Now())
+	if err != nil {
+		return nil,err
+	}
+	userID := auth.UserID(ctx)
+	if userID == "" {
+		return nil,models.ErrForbidden
+	}
+	return s.transition(ctx,id,"",models.CarStatusChange{
+		Status: models.CarStatusReserved,
+		ReservedBy: userID,
+		ReservedUntil: &expiresAt,
+	})
+}
+
+// CancelReservation releases a reservation, allowed for whoever made it and for staff.
+func (s *CarService)CancelReservation(ctx context.Context,id string) (*models.Car,error) {
+	tracer := otel.Tracer("CarService")
+	ctx,span := tracer.Start(ctx, "CancelReservation-Service")
+	defer span.End()
+
+	car,err := s.store.GetCarByID(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	if car.Status != models.CarStatusReserved {
+		return nil,models.ErrInvalidTransition
+	}
+	if car.ReservedBy != auth.UserID(ctx) && !isStaff(ctx) {
+		return nil,models.ErrForbidden
+	}
+	return s.transitionFrom(ctx,&car,models.CarStatusChange{Status: models.CarStatusAvailable})
+}
+
+func (s *CarService)MarkCarSold(ctx context.Context,id string) (*models.Car,error) {
+	tracer := otel.Tracer("CarService")
+	ctx,span := tracer.Start(ctx, "MarkCarSold-Service")
+	defer span.End()
+
+	ownerID,err := ownerFilter(ctx)
+	if err != nil {
+		return nil,err
+	}
+	return s.transition(ctx,id,ownerID,models.CarStatusChange{Status: models.CarStatusSold})
+}
+
+// ChangeCarStatus publishes, unpublishes and archives listings, reservations
+// and sales go through ReserveCar and MarkCarSold.
+func (s *CarService)ChangeCarStatus(ctx context.Context,id string,status string) (*models.Car,error) {
+	tracer := otel.Tracer("CarService")
+	ctx,span := tracer.Start(ctx, "ChangeCarStatus-Service")
+	defer span.End()
+
+	if err := models.ValidateStatus(status); err != nil {
+		return nil,err
+	}
+	if status == models.CarStatusReserved || status == models.CarStatusSold {
+		return nil,models.ErrInvalidTransition
+	}
+	ownerID,err := ownerFilter(ctx)
+	if err != nil {
+		return nil,err
+	}
+	return s.transition(ctx,id,ownerID,models.CarStatusChange{Status: status})
+}
+
+// transition checks ownerID against the car it read, which is safe because
+// a listing never changes hands and UpdateCarStatus only applies to the
+// status that was read.
+func (s *CarService)transition(ctx context.Context,id string,ownerID string,to models.CarStatusChange) (*models.Car,error) {
+	car,err := s.store.GetCarByID(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	if ownerID != "" && car.CreatedBy != ownerID {
+		return nil,models.ErrForbidden
+	}
+	return s.transitionFrom(ctx,&car,to)
+}
+
+func (s *CarService)transitionFrom(ctx context.Context,car *models.Car,to models.CarStatusChange) (*models.Car,error) {
+	if !models.CanTransition(car.Status,to.Status) {
+		return nil,models.ErrInvalidTransition
+	}
+	updatedCar,err := s.store.UpdateCarStatus(ctx,car.ID.String(),car.Status,to)
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s moved car %s from %s to %s",auth.Actor(ctx),updatedCar.ID,car.Status,updatedCar.Status)
+	return &updatedCar,nil
+}
+
+// isStaff reports whether the caller manages inventory rather than browsing it.
+func isStaff(ctx context.Context) bool {
+	principal,ok := auth.PrincipalFromContext(ctx)
+	return ok && principal.HasScope(models.ScopeCarsWrite)
+}
+
+// visibleToBuyer hides everything but available cars and the buyer's own reservations.
+func visibleToBuyer(ctx context.Context,car *models.Car) bool {
+	return isStaff(ctx) || models.VisibleToBuyer(car,auth.UserID(ctx))
+}
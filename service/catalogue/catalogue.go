@@ -0,0 +1,199 @@
+package catalogue
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// cacheTTL bounds how long other instances validate against a catalogue
+// changed elsewhere, changes made through this one apply at once.
+const cacheTTL = time.Minute
+
+// CatalogueService lets anyone read the catalogue while only platform admins
+// change it, every dealership validates its cars against the same one.
+type CatalogueService struct {
+	store store.CatalogueStoreInterface
+	now func() time.Time
+
+	mu sync.Mutex
+	cached *models.Catalogue
+	loadedAt time.Time
+}
+
+func NewCatalogueService(store store.CatalogueStoreInterface) *CatalogueService {
+	return &CatalogueService{
+		store: store,
+		now: time.Now,
+	}
+}
+
+func (s *CatalogueService)Catalogue(ctx context.Context) (*models.Catalogue,error) {
+	tracer := otel.Tracer("CatalogueService")
+	ctx,span := tracer.Start(ctx, "Catalogue-Service")
+	defer span.End()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cached != nil && s.now().Sub(s.loadedAt) < cacheTTL {
+		return s.cached,nil
+	}
+	brands,err := s.store.ListBrands(ctx)
+	if err != nil {
+		return nil,err
+	}
+	s.cached = models.NewCatalogue(brands)
+	s.loadedAt = s.now()
+	return s.cached,nil
+}
+
+func (s *CatalogueService)ListBrands(ctx context.Context) ([]models.Brand,error) {
+	tracer := otel.Tracer("CatalogueService")
+	ctx,span := tracer.Start(ctx, "ListBrands-Service")
+	defer span.End()
+
+	return s.store.ListBrands(ctx)
+}
+
+func (s *CatalogueService)GetBrandByID(ctx context.Context,id string) (*models.Brand,error) {
+	tracer := otel.Tracer("CatalogueService")
+	ctx,span := tracer.Start(ctx, "GetBrandByID-Service")
+	defer span.End()
+
+	brand,err := s.store.GetBrandByID(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	return &brand,nil
+}
+
+func (s *CatalogueService)CreateBrand(ctx context.Context,brandReq *models.BrandRequest) (*models.Brand,error) {
+	tracer := otel.Tracer("CatalogueService")
+	ctx,span := tracer.Start(ctx, "CreateBrand-Service")
+	defer span.End()
+
+	if !isPlatformAdmin(ctx) {
+		return nil,models.ErrForbidden
+	}
+	if err := models.ValidateBrandRequest(brandReq); err != nil {
+		return nil,err
+	}
+	brand,err := s.store.CreateBrand(ctx,brandReq)
+	if err != nil {
+		return nil,err
+	}
+	s.invalidate()
+	log.Printf("audit: %s created brand %s",auth.Actor(ctx),brand.ID)
+	return &brand,nil
+}
+
+func (s *CatalogueService)UpdateBrand(ctx context.Context,id string,brandReq *models.BrandRequest) (*models.Brand,error) {
+	tracer := otel.Tracer("CatalogueService")
+	ctx,span := tracer.Start(ctx, "UpdateBrand-Service")
+	defer span.End()
+
+	if !isPlatformAdmin(ctx) {
+		return nil,models.ErrForbidden
+	}
+	if err := models.ValidateBrandRequest(brandReq); err != nil {
+		return nil,err
+	}
+	brand,err := s.store.UpdateBrand(ctx,id,brandReq)
+	if err != nil {
+		return nil,err
+	}
+	s.invalidate()
+	log.Printf("audit: %s updated brand %s",auth.Actor(ctx),brand.ID)
+	return &brand,nil
+}
+
+func (s *CatalogueService)DeleteBrand(ctx context.Context,id string) (*models.Brand,error) {
+	tracer := otel.Tracer("CatalogueService")
+	ctx,span := tracer.Start(ctx, "DeleteBrand-Service")
+	defer span.End()
+
+	if !isPlatformAdmin(ctx) {
+		return nil,models.ErrForbidden
+	}
+	brand,err := s.store.DeleteBrand(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	s.invalidate()
+	log.Printf("audit: %s deleted brand %s",auth.Actor(ctx),brand.ID)
+	return &brand,nil
+}
+
+func (s *CatalogueService)CreateModel(ctx context.Context,brandID string,modelReq *models.CarModelRequest) (*models.CarModel,error) {
+	tracer := otel.Tracer("CatalogueService")
+	ctx,span := tracer.Start(ctx, "CreateModel-Service")
+	defer span.End()
+
+	if !isPlatformAdmin(ctx) {
+		return nil,models.ErrForbidden
+	}
+	if err := models.ValidateCarModelRequest(modelReq); err != nil {
+		return nil,err
+	}
+	model,err := s.store.CreateModel(ctx,brandID,modelReq)
+	if err != nil {
+		return nil,err
+	}
+	s.invalidate()
+	log.Printf("audit: %s created model %s",auth.Actor(ctx),model.ID)
+	return &model,nil
+}
+
+func (s *CatalogueService)UpdateModel(ctx context.Context,brandID string,id string,modelReq *models.CarModelRequest) (*models.CarModel,error) {
+	tracer := otel.Tracer("CatalogueService")
+	ctx,span := tracer.Start(ctx, "UpdateModel-Service")
+	defer span.End()
+
+	if !isPlatformAdmin(ctx) {
+		return nil,models.ErrForbidden
+	}
+	if err := models.ValidateCarModelRequest(modelReq); err != nil {
+		return nil,err
+	}
+	model,err := s.store.UpdateModel(ctx,brandID,id,modelReq)
+	if err != nil {
+		return nil,err
+	}
+	s.invalidate()
+	log.Printf("audit: %s updated model %s",auth.Actor(ctx),model.ID)
+	return &model,nil
+}
+
+func (s *CatalogueService)DeleteModel(ctx context.Context,brandID string,id string) (*models.CarModel,error) {
+	tracer := otel.Tracer("CatalogueService")
+	ctx,span := tracer.Start(ctx, "DeleteModel-Service")
+	defer span.End()
+
+	if !isPlatformAdmin(ctx) {
+		return nil,models.ErrForbidden
+	}
+	model,err := s.store.DeleteModel(ctx,brandID,id)
+	if err != nil {
+		return nil,err
+	}
+	s.invalidate()
+	log.Printf("audit: %s deleted model %s",auth.Actor(ctx),model.ID)
+	return &model,nil
+}
+
+func (s *CatalogueService)invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cached = nil
+}
+
+func isPlatformAdmin(ctx context.Context) bool {
+	principal,ok := auth.PrincipalFromContext(ctx)
+	return ok && principal.IsPlatformAdmin()
+}
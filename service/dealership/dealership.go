@@ -0,0 +1,116 @@
+package dealership
+
+import (
+	"context"
+	"log"
+
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// DealershipService lets platform admins manage every dealership while an
+// admin scoped to a dealership can only read and rename their own.
+type DealershipService struct {
+	store store.DealershipStoreInterface
+}
+
+func NewDealershipService(store store.DealershipStoreInterface) *DealershipService {
+	return &DealershipService{
+		store: store,
+	}
+}
+
+func (s *DealershipService)GetDealershipByID(ctx context.Context,id string) (*models.Dealership,error) {
+	tracer := otel.Tracer("DealershipService")
+	ctx,span := tracer.Start(ctx, "GetDealershipByID-Service")
+	defer span.End()
+
+	if !canManage(ctx,id) {
+		return nil,models.ErrForbidden
+	}
+	dealership,err := s.store.GetDealershipByID(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	return &dealership,nil
+}
+
+func (s *DealershipService)ListDealerships(ctx context.Context) ([]models.Dealership,error) {
+	tracer := otel.Tracer("DealershipService")
+	ctx,span := tracer.Start(ctx, "ListDealerships-Service")
+	defer span.End()
+
+	if !isPlatformAdmin(ctx) {
+		return nil,models.ErrForbidden
+	}
+	return s.store.ListDealerships(ctx)
+}
+
+func (s *DealershipService)CreateDealership(ctx context.Context,dealershipReq *models.DealershipRequest) (*models.Dealership,error) {
+	tracer := otel.Tracer("DealershipService")
+	ctx,span := tracer.Start(ctx, "CreateDealership-Service")
+	defer span.End()
+
+	if !isPlatformAdmin(ctx) {
+		return nil,models.ErrForbidden
+	}
+	if err := models.ValidateDealershipRequest(dealershipReq); err != nil {
+		return nil,err
+	}
+	dealership,err := s.store.CreateDealership(ctx,dealershipReq)
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s created dealership %s",auth.Actor(ctx),dealership.ID)
+	return &dealership,nil
+}
+
+func (s *DealershipService)UpdateDealership(ctx context.Context,id string,dealershipReq *models.DealershipRequest) (*models.Dealership,error) {
+	tracer := otel.Tracer("DealershipService")
+	ctx,span := tracer.Start(ctx, "UpdateDealership-Service")
+	defer span.End()
+
+	if !canManage(ctx,id) {
+		return nil,models.ErrForbidden
+	}
+	if err := models.ValidateDealershipRequest(dealershipReq); err != nil {
+		return nil,err
+	}
+	dealership,err := s.store.UpdateDealership(ctx,id,dealershipReq)
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s updated dealership %s",auth.Actor(ctx),dealership.ID)
+	return &dealership,nil
+}
+
+func (s *DealershipService)DeleteDealership(ctx context.Context,id string) (*models.Dealership,error) {
+	tracer := otel.Tracer("DealershipService")
+	ctx,span := tracer.Start(ctx, "DeleteDealership-Service")
+	defer span.End()
+
+	if !isPlatformAdmin(ctx) {
+		return nil,models.ErrForbidden
+	}
+	dealership,err := s.store.DeleteDealership(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s deleted dealership %s",auth.Actor(ctx),dealership.ID)
+	return &dealership,nil
+}
+
+func isPlatformAdmin(ctx context.Context) bool {
+	principal,ok := auth.PrincipalFromContext(ctx)
+	return ok && principal.IsPlatformAdmin()
+}
+
+func canManage(ctx context.Context,id string) bool {
+	if isPlatformAdmin(ctx) {
+		return true
+	}
+	tenantID := auth.TenantID(ctx)
+	return tenantID.Valid && tenantID.UUID.String() == id
+}
@@ -2,7 +2,9 @@ package engine
 
 import (
 	"context"
+	"log"
 
+	"github.com/iangechuki/go_carzone/auth"
 	"github.com/iangechuki/go_carzone/models"
 	"github.com/iangechuki/go_carzone/store"
 	"go.opentelemetry.io/otel"
@@ -30,6 +32,29 @@ func (s *EngineService)GetEngineByID(ctx context.Context,id string) (*models.Eng
 	return &engine,nil
 }
 
+// GetEnginesByIDs loads several engines in one query, engines that do not exist are left out.
+func (s *EngineService)GetEnginesByIDs(ctx context.Context,ids []string) ([]models.Engine,error) {
+	tracer := otel.Tracer("EngineService")
+	ctx,span := tracer.Start(ctx, "GetEnginesByIDs-Service")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return []models.Engine{},nil
+	}
+	return s.store.GetEnginesByIDs(ctx,ids)
+}
+
+func (s *EngineService)GetEngines(ctx context.Context,limit,offset int) ([]models.Engine,error) {
+	tracer := otel.Tracer("EngineService")
+	ctx,span := tracer.Start(ctx, "GetEngines-Service")
+	defer span.End()
+
+	if err := models.ValidatePagination(models.CarFilter{Limit: limit,Offset: offset}); err != nil {
+		return nil,err
+	}
+	return s.store.GetEngines(ctx,limit,offset)
+}
+
 func (s *EngineService)CreateEngine(ctx context.Context,engineReq *models.EngineRequest) (*models.Engine,error) {
 	tracer := otel.Tracer("EngineService")
 	ctx,span := tracer.Start(ctx, "CreateEngine-Service")
@@ -41,6 +66,7 @@ func (s *EngineService)CreateEngine(ctx context.Context,engineReq *models.Engine
 	if err != nil {
 		return nil,err
 	}
+	log.Printf("audit: %s created engine %s",auth.Actor(ctx),engine.EngineID)
 	return &engine,nil
 }
 
@@ -55,6 +81,7 @@ func (s *EngineService)UpdateEngine(ctx context.Context,id string,engineReq *mod
 	if err != nil {
 		return nil,err
 	}
+	log.Printf("audit: %s updated engine %s",auth.Actor(ctx),engine.EngineID)
 	return &engine,nil
 }
 
@@ -67,5 +94,6 @@ func (s *EngineService)DeleteEngine(ctx context.Context,id string) (*models.Engi
 	if err != nil {
 		return nil,err
 	}
+	log.Printf("audit: %s deleted engine %s",auth.Actor(ctx),engine.EngineID)
 	return &engine,nil
-}
\ No newline at end of file
+}
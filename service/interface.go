@@ -2,21 +2,113 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/iangechuki/go_carzone/models"
 )
 
 type CarServiceInterface interface {
 	GetCarByID(ctx context.Context,id string) (*models.Car,error)
-	GetCarsByBrand(ctx context.Context,brand string,isEngine bool) ([]models.Car,error)
+	GetCarsByBrand(ctx context.Context,brand string,isEngine bool,filter models.CarFilter) ([]models.Car,error)
+	GetMyCars(ctx context.Context) ([]models.Car,error)
 	CreateCar(ctx context.Context,car *models.CarRequest) (*models.Car,error)
 	UpdateCar(ctx context.Context,id string,carReq *models.CarRequest) (*models.Car,error)
 	DeleteCar(ctx context.Context,id string) (*models.Car,error)
+	ReserveCar(ctx context.Context,id string,req *models.ReservationRequest) (*models.Car,error)
+	CancelReservation(ctx context.Context,id string) (*models.Car,error)
+	MarkCarSold(ctx context.Context,id string) (*models.Car,error)
+	ChangeCarStatus(ctx context.Context,id string,status string) (*models.Car,error)
 }
 
 type EngineServiceInterface interface {
 	GetEngineByID(ctx context.Context,id string) (*models.Engine,error)
+	GetEnginesByIDs(ctx context.Context,ids []string) ([]models.Engine,error)
+	GetEngines(ctx context.Context,limit,offset int) ([]models.Engine,error)
 	CreateEngine(ctx context.Context,engineReq *models.EngineRequest) (*models.Engine,error)
 	UpdateEngine(ctx context.Context,id string,engineReq *models.EngineRequest) (*models.Engine,error)
 	DeleteEngine(ctx context.Context,id string) (*models.Engine,error)
-}
\ No newline at end of file
+}
+
+type VINServiceInterface interface {
+	DecodeVIN(ctx context.Context,vin string) (*models.VINInfo,error)
+}
+
+type UserServiceInterface interface {
+	CreateUser(ctx context.Context,userReq *models.UserRequest) (*models.User,error)
+	Authenticate(ctx context.Context,username,password string) (*models.User,error)
+}
+
+type APIKeyServiceInterface interface {
+	CreateAPIKey(ctx context.Context,keyReq *models.APIKeyRequest) (*models.IssuedAPIKey,error)
+	ListAPIKeys(ctx context.Context) ([]models.APIKey,error)
+	RevokeAPIKey(ctx context.Context,id string) (*models.APIKey,error)
+	AuthenticateAPIKey(ctx context.Context,rawKey string) (*models.APIKey,error)
+}
+
+type OIDCServiceInterface interface {
+	AuthCodeURL(state,nonce,verifier string) string
+	Exchange(ctx context.Context,code,verifier,nonce string) (*models.OIDCLogin,error)
+	VerifyToken(ctx context.Context,rawToken string) (*models.Identity,error)
+}
+
+type CatalogueServiceInterface interface {
+	// Catalogue is a recent snapshot for validating cars against
+	Catalogue(ctx context.Context) (*models.Catalogue,error)
+	ListBrands(ctx context.Context) ([]models.Brand,error)
+	GetBrandByID(ctx context.Context,id string) (*models.Brand,error)
+	CreateBrand(ctx context.Context,brandReq *models.BrandRequest) (*models.Brand,error)
+	UpdateBrand(ctx context.Context,id string,brandReq *models.BrandRequest) (*models.Brand,error)
+	DeleteBrand(ctx context.Context,id string) (*models.Brand,error)
+	CreateModel(ctx context.Context,brandID string,modelReq *models.CarModelRequest) (*models.CarModel,error)
+	UpdateModel(ctx context.Context,brandID string,id string,modelReq *models.CarModelRequest) (*models.CarModel,error)
+	DeleteModel(ctx context.Context,brandID string,id string) (*models.CarModel,error)
+}
+
+type DealershipServiceInterface interface {
+	GetDealershipByID(ctx context.Context,id string) (*models.Dealership,error)
+	ListDealerships(ctx context.Context) ([]models.Dealership,error)
+	CreateDealership(ctx context.Context,dealershipReq *models.DealershipRequest) (*models.Dealership,error)
+	UpdateDealership(ctx context.Context,id string,dealershipReq *models.DealershipRequest) (*models.Dealership,error)
+	DeleteDealership(ctx context.Context,id string) (*models.Dealership,error)
+}
+type TestDriveServiceInterface interface {
+	GetSchedule(ctx context.Context,dealershipID string) (*models.TestDriveSchedule,error)
+	ReplaceSchedule(ctx context.Context,dealershipID string,schedule *models.TestDriveSchedule) (*models.TestDriveSchedule,error)
+	GetAvailability(ctx context.Context,carID string,date string) ([]models.TestDriveAvailability,error)
+	ListTestDrives(ctx context.Context,carID string,from time.Time,to time.Time) ([]models.TestDrive,error)
+	BookTestDrive(ctx context.Context,carID string,testDriveReq *models.TestDriveRequest) (*models.TestDrive,error)
+	CancelTestDrive(ctx context.Context,carID string,id string) (*models.TestDrive,error)
+}
+
+type OrderServiceInterface interface {
+	GetOrderByID(ctx context.Context,id string) (*models.Order,error)
+	ListOrders(ctx context.Context) ([]models.Order,error)
+	CreateOrder(ctx context.Context,orderReq *models.OrderRequest) (*models.Order,error)
+	RecordPayment(ctx context.Context,id string,paymentReq *models.PaymentRequest) (*models.Order,error)
+	CancelOrder(ctx context.Context,id string) (*models.Order,error)
+	RefundOrder(ctx context.Context,id string) (*models.Order,error)
+	GetInvoice(ctx context.Context,id string) (*models.Invoice,error)
+}
+
+// CarStreamInterface pushes car changes to live subscribers.
+type CarStreamInterface interface {
+	// Subscribe delivers matching events until cancel is called. The channel is
+	// closed early when the subscriber falls too far behind.
+	Subscribe(filter models.CarEventFilter) (events <-chan models.CarEvent,cancel func())
+	// Replay returns what happened after lastEventID, or models.ErrResumeExpired.
+	Replay(ctx context.Context,lastEventID string,filter models.CarEventFilter) ([]models.CarEvent,error)
+}
+
+// EventPublisher receives the events the outbox relay publishes. An error
+// leaves the event in the outbox to be published again, to every publisher.
+type EventPublisher interface {
+	Publish(ctx context.Context,event models.Event) error
+}
+
+type WebhookServiceInterface interface {
+	CreateWebhook(ctx context.Context,webhookReq *models.WebhookRequest) (*models.IssuedWebhook,error)
+	ListWebhooks(ctx context.Context) ([]models.Webhook,error)
+	DeleteWebhook(ctx context.Context,id string) (*models.Webhook,error)
+	ListDeliveries(ctx context.Context,webhookID string) ([]models.WebhookDelivery,error)
+	ReplayDelivery(ctx context.Context,webhookID string,id string) (*models.WebhookDelivery,error)
+}
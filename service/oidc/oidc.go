@@ -0,0 +1,224 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/iangechuki/go_carzone/models"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrNoRoles = errors.New("user is not a member of any carzone group")
+)
+
+type Config struct {
+	IssuerURL string
+	ClientID string
+	ClientSecret string
+	RedirectURL string
+	// audience expected on bearer tokens, defaults to the client id
+	Audience string
+	GroupsClaim string
+	// claim holding the dealership id of the user, only admins may sign in without one
+	TenantClaim string
+	// maps identity provider groups to carzone roles
+	RoleMapping map[string]string
+}
+
+// ConfigFromEnv reads OIDC_* variables, ok is false when OIDC_ISSUER_URL is unset.
+func ConfigFromEnv() (config Config,ok bool,err error) {
+	config = Config{
+		IssuerURL: os.Getenv("OIDC_ISSUER_URL"),
+		ClientID: os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL: os.Getenv("OIDC_REDIRECT_URL"),
+		Audience: os.Getenv("OIDC_AUDIENCE"),
+		GroupsClaim: os.Getenv("OIDC_GROUPS_CLAIM"),
+		TenantClaim: os.Getenv("OIDC_TENANT_CLAIM"),
+	}
+	if config.IssuerURL == "" {
+		return Config{},false,nil
+	}
+	if config.ClientID == "" || config.RedirectURL == "" {
+		return Config{},false,errors.New("OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required")
+	}
+	if config.Audience == "" {
+		config.Audience = config.ClientID
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = "groups"
+	}
+	if config.TenantClaim == "" {
+		config.TenantClaim = "tenant_id"
+	}
+	config.RoleMapping,err = ParseRoleMapping(os.Getenv("OIDC_ROLE_MAPPING"))
+	if err != nil {
+		return Config{},false,err
+	}
+	return config,true,nil
+}
+
+// ParseRoleMapping reads group to role pairs written as "carzone-admins=admin;carzone-staff=staff".
+func ParseRoleMapping(s string) (map[string]string,error) {
+	mapping := make(map[string]string)
+	for _,entry := range strings.Split(s,";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		group,role,ok := strings.Cut(entry,"=")
+		if !ok {
+			return nil,fmt.Errorf("invalid role mapping %q",entry)
+		}
+		role = strings.TrimSpace(role)
+		if err := models.ValidateRole(role); err != nil {
+			return nil,err
+		}
+		mapping[strings.TrimSpace(group)] = role
+	}
+	return mapping,nil
+}
+
+type OIDCService struct {
+	config Config
+	oauth2Config oauth2.Config
+	idTokenVerifier *gooidc.IDTokenVerifier
+	bearerVerifier *gooidc.IDTokenVerifier
+}
+
+// NewOIDCService runs discovery once, the provider's key set is cached and only
+// refetched when a token is signed with an unknown key id.
+func NewOIDCService(ctx context.Context,config Config) (*OIDCService,error) {
+	provider,err := gooidc.NewProvider(ctx,config.IssuerURL)
+	if err != nil {
+		return nil,fmt.Errorf("oidc discovery: %w",err)
+	}
+	return &OIDCService{
+		config: config,
+		oauth2Config: oauth2.Config{
+			ClientID: config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL: config.RedirectURL,
+			Endpoint: provider.Endpoint(),
+			Scopes: []string{gooidc.ScopeOpenID,"profile","email"},
+		},
+		idTokenVerifier: provider.Verifier(&gooidc.Config{ClientID: config.ClientID}),
+		bearerVerifier: provider.Verifier(&gooidc.Config{ClientID: config.Audience}),
+	},nil
+}
+
+// AuthCodeURL builds the authorization request, verifier is the PKCE code verifier kept by the caller.
+func (s *OIDCService)AuthCodeURL(state,nonce,verifier string) string {
+	return s.oauth2Config.AuthCodeURL(state,gooidc.Nonce(nonce),oauth2.S256ChallengeOption(verifier))
+}
+
+func (s *OIDCService)Exchange(ctx context.Context,code,verifier,nonce string) (*models.OIDCLogin,error) {
+	tracer := otel.Tracer("OIDCService")
+	ctx,span := tracer.Start(ctx, "Exchange-Service")
+	defer span.End()
+
+	token,err := s.oauth2Config.Exchange(ctx,code,oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil,fmt.Errorf("exchanging code: %w",err)
+	}
+	rawIDToken,ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil,errors.New("token response has no id_token")
+	}
+	idToken,err := s.idTokenVerifier.Verify(ctx,rawIDToken)
+	if err != nil {
+		return nil,err
+	}
+	if idToken.Nonce != nonce {
+		return nil,errors.New("id_token nonce mismatch")
+	}
+	identity,err := s.identity(idToken)
+	if err != nil {
+		return nil,err
+	}
+	return &models.OIDCLogin{
+		Identity: *identity,
+		Token: rawIDToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresAt: idToken.Expiry.Unix(),
+	},nil
+}
+
+// VerifyToken checks a bearer token issued by the identity provider.
+func (s *OIDCService)VerifyToken(ctx context.Context,rawToken string) (*models.Identity,error) {
+	tracer := otel.Tracer("OIDCService")
+	ctx,span := tracer.Start(ctx, "VerifyToken-Service")
+	defer span.End()
+
+	token,err := s.bearerVerifier.Verify(ctx,rawToken)
+	if err != nil {
+		return nil,err
+	}
+	return s.identity(token)
+}
+
+func (s *OIDCService)identity(token *gooidc.IDToken) (*models.Identity,error) {
+	var claims map[string]interface{}
+	if err := token.Claims(&claims); err != nil {
+		return nil,err
+	}
+	identity := &models.Identity{
+		Issuer: token.Issuer,
+		Subject: token.Subject,
+		Username: token.Subject,
+		Groups: stringSlice(claims[s.config.GroupsClaim]),
+	}
+	if jti,ok := claims["jti"].(string); ok {
+		identity.TokenID = jti
+	}
+	if tenantID,ok := claims[s.config.TenantClaim].(string); ok {
+		identity.TenantID = tenantID
+	}
+	for _,claim := range []string{"preferred_username","email"} {
+		if v,ok := claims[claim].(string); ok && v != "" {
+			identity.Username = v
+			break
+		}
+	}
+	identity.Roles = s.rolesFor(identity.Groups)
+	if len(identity.Roles) == 0 {
+		return nil,ErrNoRoles
+	}
+	return identity,nil
+}
+
+func (s *OIDCService)rolesFor(groups []string) []string {
+	seen := make(map[string]bool)
+	roles := []string{}
+	for _,group := range groups {
+		role,ok := s.config.RoleMapping[group]
+		if ok && !seen[role] {
+			seen[role] = true
+			roles = append(roles,role)
+		}
+	}
+	return roles
+}
+
+// stringSlice accepts both a JSON array and a single string, providers differ on how groups are sent.
+func stringSlice(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string,0,len(v))
+		for _,item := range v {
+			if s,ok := item.(string); ok {
+				values = append(values,s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/iangechuki/go_carzone/mockoidc"
+)
+
+const (
+	testClientID = "carzone"
+	testClientSecret = "carzone-secret"
+	testRedirectURL = "http://localhost/oidc/callback"
+	testVerifier = "a-pkce-verifier-that-is-long-enough-to-be-valid-0123456789"
+)
+
+var testUser = mockoidc.User{
+	Subject: "user-1",
+	Username: "jdoe",
+	Email: "jdoe@example.com",
+	Groups: []string{"carzone-staff"},
+}
+
+func newTestProvider(t *testing.T,clientID string,user mockoidc.User) *mockoidc.Server {
+	t.Helper()
+	provider,err := mockoidc.New(clientID,testClientSecret,user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(provider.Start().Close)
+	return provider
+}
+
+func newTestService(t *testing.T,provider *mockoidc.Server) *OIDCService {
+	t.Helper()
+	service,err := NewOIDCService(context.Background(),Config{
+		IssuerURL: provider.Issuer,
+		ClientID: testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL: testRedirectURL,
+		Audience: testClientID,
+		GroupsClaim: "groups",
+		TenantClaim: "tenant_id",
+		RoleMapping: map[string]string{"carzone-staff": "staff","carzone-admins": "admin"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+// authorize follows the authorization request as the browser would and
+// returns the code the provider redirected back with.
+func authorize(t *testing.T,authCodeURL string) string {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request,[]*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp,err := client.Get(authCodeURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize status = %d, want %d",resp.StatusCode,http.StatusFound)
+	}
+	location,err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return location.Query().Get("code")
+}
+
+func TestExchange(t *testing.T) {
+	provider := newTestProvider(t,testClientID,testUser)
+	service := newTestService(t,provider)
+
+	tests := []struct {
+		name string
+		verifier string
+		nonce string
+		wantErr bool
+	}{
+		{name: "valid",verifier: testVerifier,nonce: "n-1"},
+		{name: "wrong verifier",verifier: "another-verifier-0123456789-0123456789-0123",nonce: "n-1",wantErr: true},
+		{name: "nonce mismatch",verifier: testVerifier,nonce: "other-nonce",wantErr: true},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			code := authorize(t,service.AuthCodeURL("state","n-1",testVerifier))
+			login,err := service.Exchange(context.Background(),code,tt.verifier,tt.nonce)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Exchange succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if login.Identity.Subject != testUser.Subject || login.Identity.Username != testUser.Username {
+				t.Errorf("identity = %+v",login.Identity)
+			}
+			if len(login.Identity.Roles) != 1 || login.Identity.Roles[0] != "staff" {
+				t.Errorf("roles = %v, want [staff]",login.Identity.Roles)
+			}
+			if login.Token == "" {
+				t.Error("login has no token")
+			}
+		})
+	}
+}
+
+func TestExchangeCodeIsSingleUse(t *testing.T) {
+	provider := newTestProvider(t,testClientID,testUser)
+	service := newTestService(t,provider)
+	code := authorize(t,service.AuthCodeURL("state","n-1",testVerifier))
+	if _,err := service.Exchange(context.Background(),code,testVerifier,"n-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _,err := service.Exchange(context.Background(),code,testVerifier,"n-1"); err == nil {
+		t.Fatal("a code was exchanged twice")
+	}
+}
+
+func TestVerifyToken(t *testing.T) {
+	provider := newTestProvider(t,testClientID,testUser)
+	service := newTestService(t,provider)
+	// same issuer, different signing key
+	impostor := newTestProvider(t,testClientID,testUser)
+	impostor.Issuer = provider.Issuer
+
+	admin := testUser
+	admin.Groups = []string{"carzone-admins","unmapped"}
+	outsider := testUser
+	outsider.Groups = []string{"unmapped"}
+
+	tests := []struct {
+		name string
+		issuer interface{ IssueToken(mockoidc.User,string) (string,error) }
+		user mockoidc.User
+		wantErr error
+		wantRole string
+	}{
+		{name: "staff",issuer: provider,user: testUser,wantRole: "staff"},
+		{name: "admin",issuer: provider,user: admin,wantRole: "admin"},
+		{name: "no mapped group",issuer: provider,user: outsider,wantErr: ErrNoRoles},
+		{name: "unknown key",issuer: impostor,user: testUser,wantErr: errAny},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			token,err := tt.issuer.IssueToken(tt.user,"")
+			if err != nil {
+				t.Fatal(err)
+			}
+			identity,err := service.VerifyToken(context.Background(),token)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatalf("VerifyToken = %+v, want an error",identity)
+				}
+				if tt.wantErr != errAny && !errors.Is(err,tt.wantErr) {
+					t.Fatalf("VerifyToken error = %v, want %v",err,tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(identity.Roles) != 1 || identity.Roles[0] != tt.wantRole {
+				t.Errorf("roles = %v, want [%s]",identity.Roles,tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestVerifyTokenAudience(t *testing.T) {
+	provider := newTestProvider(t,testClientID,testUser)
+	service,err := NewOIDCService(context.Background(),Config{
+		IssuerURL: provider.Issuer,
+		ClientID: testClientID,
+		RedirectURL: testRedirectURL,
+		Audience: "carzone-api",
+		RoleMapping: map[string]string{"carzone-staff": "staff"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token,err := provider.IssueToken(testUser,"")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _,err := service.VerifyToken(context.Background(),token); err == nil {
+		t.Fatal("VerifyToken accepted a token issued for another audience")
+	}
+}
+
+// errAny marks cases where only the failure matters, not its kind.
+var errAny = errors.New("any error")
+
+func TestParseRoleMapping(t *testing.T) {
+	mapping,err := ParseRoleMapping(" carzone-admins=admin ; carzone-staff=staff;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping["carzone-admins"] != "admin" || mapping["carzone-staff"] != "staff" || len(mapping) != 2 {
+		t.Errorf("mapping = %v",mapping)
+	}
+	for _,bad := range []string{"carzone-admins","carzone-admins=root"} {
+		if _,err := ParseRoleMapping(bad); err == nil {
+			t.Errorf("ParseRoleMapping(%q) succeeded, want an error",bad)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// PricingFromEnv reads ORDER_TAX_RATE (default 0.16) and ORDER_FEES, written
+// as "Documentation=5000;Registration=2500".
+func PricingFromEnv() (models.Pricing,error) {
+	pricing := models.Pricing{TaxRate: 0.16,DefaultFees: []models.OrderFee{}}
+	if v := os.Getenv("ORDER_TAX_RATE"); v != "" {
+		rate,err := strconv.ParseFloat(v,64)
+		if err != nil || rate < 0 || rate >= 1 {
+			return models.Pricing{},fmt.Errorf("invalid ORDER_TAX_RATE %q, expected a fraction such as 0.16",v)
+		}
+		pricing.TaxRate = rate
+	}
+	for _,entry := range strings.Split(os.Getenv("ORDER_FEES"),";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name,amountStr,ok := strings.Cut(entry,"=")
+		if !ok {
+			return models.Pricing{},fmt.Errorf("invalid order fee %q",entry)
+		}
+		amount,err := strconv.ParseFloat(strings.TrimSpace(amountStr),64)
+		if err != nil || amount < 0 {
+			return models.Pricing{},fmt.Errorf("invalid order fee amount %q",amountStr)
+		}
+		pricing.DefaultFees = append(pricing.DefaultFees,models.OrderFee{Name: strings.TrimSpace(name),Amount: amount})
+	}
+	return pricing,nil
+}
+
+// OrderService sells cars through orders. Staff place and settle orders,
+// buyers can only read their own orders and invoices.
+type OrderService struct {
+	store store.OrderStoreInterface
+	carStore store.CarStoreInterface
+	dealershipStore store.DealershipStoreInterface
+	pricing models.Pricing
+}
+
+func NewOrderService(store store.OrderStoreInterface,carStore store.CarStoreInterface,dealershipStore store.DealershipStoreInterface,pricing models.Pricing) *OrderService {
+	return &OrderService{
+		store: store,
+		carStore: carStore,
+		dealershipStore: dealershipStore,
+		pricing: pricing,
+	}
+}
+
+func (s *OrderService)GetOrderByID(ctx context.Context,id string) (*models.Order,error) {
+	tracer := otel.Tracer("OrderService")
+	ctx,span := tracer.Start(ctx, "GetOrderByID-Service")
+	defer span.End()
+
+	order,err := s.store.GetOrderByID(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	if !canManageOrders(ctx) && order.BuyerID != auth.UserID(ctx) {
+		return nil,models.ErrRecordNotFound
+	}
+	return &order,nil
+}
+
+func (s *OrderService)ListOrders(ctx context.Context) ([]models.Order,error) {
+	tracer := otel.Tracer("OrderService")
+	ctx,span := tracer.Start(ctx, "ListOrders-Service")
+	defer span.End()
+
+	buyerID := ""
+	if !canManageOrders(ctx) {
+		buyerID = auth.UserID(ctx)
+		if buyerID == "" {
+			return nil,models.ErrForbidden
+		}
+	}
+	return s.store.ListOrders(ctx,buyerID)
+}
+
+func (s *OrderService)CreateOrder(ctx context.Context,orderReq *models.OrderRequest) (*models.Order,error) {
+	tracer := otel.Tracer("OrderService")
+	ctx,span := tracer.Start(ctx, "CreateOrder-Service")
+	defer span.End()
+
+	if err := models.ValidateOrderRequest(orderReq); err != nil {
+		return nil,err
+	}
+	tenantID := auth.TenantID(ctx)
+	if !tenantID.Valid {
+		return nil,models.ErrTenantRequired
+	}
+	order := models.Order{
+		ID: uuid.New(),
+		TenantID: tenantID.UUID,
+		CarID: orderReq.CarID,
+		BuyerID: orderReq.BuyerID,
+		BuyerName: orderReq.BuyerName,
+		BuyerEmail: orderReq.BuyerEmail,
+		AgreedPrice: orderReq.AgreedPrice,
+		CreatedBy: auth.UserID(ctx),
+	}
+	s.pricing.PriceOrder(&order,orderReq.Fees)
+	createdOrder,err := s.store.CreateOrder(ctx,order)
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s placed order %s for car %s at %.2f",auth.Actor(ctx),createdOrder.ID,createdOrder.CarID,createdOrder.Total)
+	return &createdOrder,nil
+}
+
+func (s *OrderService)RecordPayment(ctx context.Context,id string,paymentReq *models.PaymentRequest) (*models.Order,error) {
+	tracer := otel.Tracer("OrderService")
+	ctx,span := tracer.Start(ctx, "RecordPayment-Service")
+	defer span.End()
+
+	if err := models.ValidatePaymentRequest(paymentReq); err != nil {
+		return nil,err
+	}
+	order,err := s.store.AddPayment(ctx,id,models.Payment{
+		ID: uuid.New(),
+		Amount: paymentReq.Amount,
+		Method: paymentReq.Method,
+		Reference: paymentReq.Reference,
+		RecordedBy: auth.UserID(ctx),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s recorded a payment of %.2f on order %s",auth.Actor(ctx),paymentReq.Amount,order.ID)
+	return &order,nil
+}
+
+// CancelOrder drops an unpaid order and puts the car back on sale.
+func (s *OrderService)CancelOrder(ctx context.Context,id string) (*models.Order,error) {
+	return s.closeOrder(ctx,id,models.PaymentCancelled)
+}
+
+// RefundOrder marks a paid or partially paid order refunded, the car is back on sale.
+func (s *OrderService)RefundOrder(ctx context.Context,id string) (*models.Order,error) {
+	return s.closeOrder(ctx,id,models.PaymentRefunded)
+}
+
+func (s *OrderService)closeOrder(ctx context.Context,id string,status string) (*models.Order,error) {
+	tracer := otel.Tracer("OrderService")
+	ctx,span := tracer.Start(ctx, "CloseOrder-Service")
+	defer span.End()
+
+	order,err := s.store.CloseOrder(ctx,id,status)
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s set order %s to %s",auth.Actor(ctx),order.ID,status)
+	return &order,nil
+}
+
+func (s *OrderService)GetInvoice(ctx context.Context,id string) (*models.Invoice,error) {
+	tracer := otel.Tracer("OrderService")
+	ctx,span := tracer.Start(ctx, "GetInvoice-Service")
+	defer span.End()
+
+	order,err := s.GetOrderByID(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	car,err := s.carStore.GetCarByID(ctx,order.CarID.String())
+	if err != nil {
+		return nil,err
+	}
+	dealership,err := s.dealershipStore.GetDealershipByID(ctx,order.TenantID.String())
+	if err != nil {
+		return nil,err
+	}
+	return &models.Invoice{
+		Order: *order,
+		Car: car,
+		Dealership: dealership,
+		IssuedAt: order.CreatedAt,
+	},nil
+}
+
+func canManageOrders(ctx context.Context) bool {
+	principal,ok := auth.PrincipalFromContext(ctx)
+	return ok && principal.HasScope(models.ScopeOrdersWrite)
+}
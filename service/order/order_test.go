@@ -0,0 +1,218 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+)
+
+// memoryStore sells cars by the same rules as the postgres store.
+type memoryStore struct {
+	cars map[uuid.UUID]*models.Car
+	orders map[string]models.Order
+}
+
+func newMemoryStore(cars ...models.Car) *memoryStore {
+	s := &memoryStore{cars: make(map[uuid.UUID]*models.Car),orders: make(map[string]models.Order)}
+	for i := range cars {
+		s.cars[cars[i].ID] = &cars[i]
+	}
+	return s
+}
+
+func (s *memoryStore)GetOrderByID(ctx context.Context,id string) (models.Order,error) {
+	order,ok := s.orders[id]
+	if !ok {
+		return models.Order{},models.ErrRecordNotFound
+	}
+	return order,nil
+}
+
+func (s *memoryStore)ListOrders(ctx context.Context,buyerID string) ([]models.Order,error) {
+	orders := []models.Order{}
+	for _,order := range s.orders {
+		if buyerID == "" || order.BuyerID == buyerID {
+			orders = append(orders,order)
+		}
+	}
+	return orders,nil
+}
+
+func (s *memoryStore)CreateOrder(ctx context.Context,order models.Order) (models.Order,error) {
+	car,ok := s.cars[order.CarID]
+	if !ok || car.TenantID != order.TenantID {
+		return models.Order{},models.ErrRecordNotFound
+	}
+	if !models.ForSaleTo(car,order.BuyerID,time.Now()) {
+		return models.Order{},models.ErrCarNotForSale
+	}
+	order.PaymentStatus = models.PaymentPending
+	s.orders[order.ID.String()] = order
+	car.Status = models.CarStatusSold
+	return order,nil
+}
+
+func (s *memoryStore)AddPayment(ctx context.Context,id string,payment models.Payment) (models.Order,error) {
+	order,ok := s.orders[id]
+	if !ok {
+		return models.Order{},models.ErrRecordNotFound
+	}
+	if err := order.CheckPayment(payment.Amount); err != nil {
+		return models.Order{},err
+	}
+	order.Payments = append(order.Payments,payment)
+	order.AmountPaid += payment.Amount
+	order.PaymentStatus = models.PaymentStatusFor(order.Total,order.AmountPaid)
+	s.orders[id] = order
+	return order,nil
+}
+
+func (s *memoryStore)CloseOrder(ctx context.Context,id string,status string) (models.Order,error) {
+	order,ok := s.orders[id]
+	if !ok {
+		return models.Order{},models.ErrRecordNotFound
+	}
+	if err := order.CheckClose(status); err != nil {
+		return models.Order{},err
+	}
+	order.PaymentStatus = status
+	s.orders[id] = order
+	if car := s.cars[order.CarID]; car.Status == models.CarStatusSold {
+		car.Status = models.CarStatusAvailable
+	}
+	return order,nil
+}
+
+func salesContext(tenantID uuid.UUID) context.Context {
+	return auth.WithPrincipal(context.Background(),&auth.Principal{
+		UserID: "sales",
+		Roles: []string{models.RoleStaff},
+		Scopes: []string{models.ScopeOrdersWrite},
+		TenantID: uuid.NullUUID{UUID: tenantID,Valid: true},
+	})
+}
+
+func orderFor(carID uuid.UUID,buyerID string) *models.OrderRequest {
+	return &models.OrderRequest{CarID: carID,BuyerID: buyerID,BuyerName: "Wanjiru",BuyerEmail: "wanjiru@example.com",AgreedPrice: 1000}
+}
+
+func TestCreateOrder(t *testing.T) {
+	tenantID := uuid.New()
+	later := time.Now().Add(time.Hour)
+	earlier := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name string
+		car models.Car
+		buyerID string
+		want error
+	}{
+		{name: "available",car: models.Car{Status: models.CarStatusAvailable},buyerID: "buyer"},
+		{name: "sold",car: models.Car{Status: models.CarStatusSold},buyerID: "buyer",want: models.ErrCarNotForSale},
+		{name: "reserved by someone else",car: models.Car{Status: models.CarStatusReserved,ReservedBy: "other",ReservedUntil: &later},buyerID: "buyer",want: models.ErrCarNotForSale},
+		{name: "reserved by the buyer",car: models.Car{Status: models.CarStatusReserved,ReservedBy: "buyer",ReservedUntil: &later},buyerID: "buyer"},
+		{name: "reservation expired",car: models.Car{Status: models.CarStatusReserved,ReservedBy: "other",ReservedUntil: &earlier},buyerID: "buyer"},
+		{name: "another dealership's car",car: models.Car{TenantID: uuid.New(),Status: models.CarStatusAvailable},buyerID: "buyer",want: models.ErrRecordNotFound},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			car := tt.car
+			car.ID = uuid.New()
+			if car.TenantID == uuid.Nil {
+				car.TenantID = tenantID
+			}
+			service := NewOrderService(newMemoryStore(car),nil,nil,models.Pricing{TaxRate: 0.16})
+			_,err := service.CreateOrder(salesContext(tenantID),orderFor(car.ID,tt.buyerID))
+			if !errors.Is(err,tt.want) {
+				t.Errorf("CreateOrder = %v, want %v",err,tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateOrderTwice(t *testing.T) {
+	tenantID := uuid.New()
+	car := models.Car{ID: uuid.New(),TenantID: tenantID,Status: models.CarStatusAvailable}
+	store := newMemoryStore(car)
+	service := NewOrderService(store,nil,nil,models.Pricing{TaxRate: 0.16,DefaultFees: []models.OrderFee{{Name: "Documentation",Amount: 50}}})
+	ctx := salesContext(tenantID)
+
+	placed,err := service.CreateOrder(ctx,orderFor(car.ID,"buyer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if placed.Total != 1218 || placed.PaymentStatus != models.PaymentPending || placed.CreatedBy != "sales" {
+		t.Errorf("placed order = %+v",placed)
+	}
+	if store.cars[car.ID].Status != models.CarStatusSold {
+		t.Errorf("car is %s after the sale, want sold",store.cars[car.ID].Status)
+	}
+	for _,buyerID := range []string{"buyer","other"} {
+		if _,err := service.CreateOrder(ctx,orderFor(car.ID,buyerID)); !errors.Is(err,models.ErrCarNotForSale) {
+			t.Errorf("second order for %s: %v, want %v",buyerID,err,models.ErrCarNotForSale)
+		}
+	}
+
+	// cancelling puts the car back on sale
+	if _,err := service.CancelOrder(ctx,placed.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	if _,err := service.CreateOrder(ctx,orderFor(car.ID,"other")); err != nil {
+		t.Errorf("order after cancelling: %v",err)
+	}
+}
+
+func TestCreateOrderRequiresTenant(t *testing.T) {
+	service := NewOrderService(newMemoryStore(),nil,nil,models.Pricing{})
+	ctx := auth.WithPrincipal(context.Background(),&auth.Principal{UserID: "root",Roles: []string{models.RoleAdmin},Scopes: []string{models.ScopeOrdersWrite}})
+	if _,err := service.CreateOrder(ctx,orderFor(uuid.New(),"buyer")); !errors.Is(err,models.ErrTenantRequired) {
+		t.Errorf("CreateOrder = %v, want %v",err,models.ErrTenantRequired)
+	}
+}
+
+func TestPaymentTransitions(t *testing.T) {
+	tenantID := uuid.New()
+	car := models.Car{ID: uuid.New(),TenantID: tenantID,Status: models.CarStatusAvailable}
+	service := NewOrderService(newMemoryStore(car),nil,nil,models.Pricing{})
+	ctx := salesContext(tenantID)
+	placed,err := service.CreateOrder(ctx,orderFor(car.ID,"buyer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := placed.ID.String()
+	pay := func(amount float64) (*models.Order,error) {
+		return service.RecordPayment(ctx,id,&models.PaymentRequest{Amount: amount,Method: "mobile_money"})
+	}
+
+	order,err := pay(400)
+	if err != nil || order.PaymentStatus != models.PaymentPartiallyPaid {
+		t.Fatalf("after 400: %+v, %v",order,err)
+	}
+	if _,err := service.CancelOrder(ctx,id); !errors.Is(err,models.ErrOrderHasPayments) {
+		t.Errorf("cancelling a part paid order: %v, want %v",err,models.ErrOrderHasPayments)
+	}
+	if _,err := pay(600.01); !errors.Is(err,models.ErrOverpayment) {
+		t.Errorf("overpaying: %v, want %v",err,models.ErrOverpayment)
+	}
+	order,err = pay(600)
+	if err != nil || order.PaymentStatus != models.PaymentPaid || order.AmountDue() != 0 {
+		t.Fatalf("after 1000: %+v, %v",order,err)
+	}
+	order,err = service.RefundOrder(ctx,id)
+	if err != nil || order.PaymentStatus != models.PaymentRefunded {
+		t.Fatalf("refund: %+v, %v",order,err)
+	}
+	if _,err := pay(1); !errors.Is(err,models.ErrOrderClosed) {
+		t.Errorf("paying a refunded order: %v, want %v",err,models.ErrOrderClosed)
+	}
+	if _,err := service.RefundOrder(ctx,id); !errors.Is(err,models.ErrOrderClosed) {
+		t.Errorf("refunding twice: %v, want %v",err,models.ErrOrderClosed)
+	}
+	if _,err := service.RecordPayment(ctx,id,&models.PaymentRequest{Amount: 1,Method: "cheque"}); err == nil {
+		t.Error("a payment by cheque was taken")
+	}
+}
@@ -0,0 +1,150 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/broker"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/service"
+	"github.com/iangechuki/go_carzone/store"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	batchSize = 100
+	// how long a claimed message is held before another relay may publish it
+	claimLease = time.Minute
+	maxBackoff = 5 * time.Minute
+	// published messages are kept this long for inspection
+	retention = 7 * 24 * time.Hour
+)
+
+// Relay moves events from the outbox to the broker and to local subscribers
+// such as webhooks. Anything it publishes may be published again after a
+// crash or a lost acknowledgement, consumers deduplicate on the event id.
+type Relay struct {
+	store store.OutboxStoreInterface
+	broker broker.Broker
+	local []service.EventPublisher
+	interval time.Duration
+}
+
+func NewRelay(store store.OutboxStoreInterface,broker broker.Broker,interval time.Duration,local ...service.EventPublisher) *Relay {
+	return &Relay{
+		store: store,
+		broker: broker,
+		local: local,
+		interval: interval,
+	}
+}
+
+// Run relays events until ctx is done.
+func (r *Relay)Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	lastPurge := time.Time{}
+	for {
+		// keep draining while full batches come back
+		for r.relayDue(ctx) == batchSize {
+		}
+		if time.Since(lastPurge) > time.Hour {
+			lastPurge = time.Now()
+			if _,err := r.store.PurgePublished(ctx,lastPurge.Add(-retention)); err != nil {
+				log.Println("Error purging outbox: ",err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay)relayDue(ctx context.Context) int {
+	tracer := otel.Tracer("OutboxRelay")
+	ctx,span := tracer.Start(ctx, "Relay-Outbox")
+	defer span.End()
+
+	messages,err := r.store.ClaimOutbox(ctx,batchSize,claimLease)
+	if err != nil {
+		log.Println("Error claiming outbox messages: ",err)
+		return 0
+	}
+	published := []uuid.UUID{}
+	for _,msg := range messages {
+		if err := r.publish(ctx,msg); err != nil {
+			log.Printf("Error publishing outbox message %s: %v",msg.ID,err)
+			if err := r.store.MarkFailed(ctx,msg.ID,err.Error(),time.Now().Add(backoff(msg.Attempts))); err != nil {
+				log.Println("Error recording outbox failure: ",err)
+			}
+			continue
+		}
+		published = append(published,msg.ID)
+	}
+	if len(published) > 0 {
+		if err := r.store.MarkPublished(ctx,published); err != nil {
+			log.Println("Error marking outbox messages published: ",err)
+		}
+	}
+	return len(messages)
+}
+
+func (r *Relay)publish(ctx context.Context,msg models.OutboxMessage) error {
+	err := r.broker.Publish(ctx,broker.Message{
+		ID: msg.ID.String(),
+		Subject: msg.EventType,
+		Key: msg.TenantID.String(),
+		Payload: msg.Payload,
+	})
+	if err != nil {
+		return err
+	}
+	if len(r.local) == 0 {
+		return nil
+	}
+	event,err := decodeEvent(msg.Payload)
+	if err != nil {
+		return err
+	}
+	// every publisher gets its go, a failure publishes the event to all of them again
+	errs := []error{}
+	for _,publisher := range r.local {
+		if err := publisher.Publish(ctx,event); err != nil {
+			errs = append(errs,err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// decodeEvent keeps Data as raw JSON so it is re-encoded exactly as recorded.
+func decodeEvent(payload []byte) (models.Event,error) {
+	var event models.Event
+	var raw struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(payload,&event); err != nil {
+		return models.Event{},err
+	}
+	if err := json.Unmarshal(payload,&raw); err != nil {
+		return models.Event{},err
+	}
+	event.Data = raw.Data
+	return event,nil
+}
+
+func backoff(attempts int) time.Duration {
+	d := time.Second
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
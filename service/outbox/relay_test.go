@@ -0,0 +1,87 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/broker"
+	"github.com/iangechuki/go_carzone/models"
+)
+
+// memoryOutbox hands out its messages once and records what became of them.
+type memoryOutbox struct {
+	messages []models.OutboxMessage
+	published []uuid.UUID
+	failed []uuid.UUID
+}
+
+func (s *memoryOutbox)ClaimOutbox(ctx context.Context,limit int,lease time.Duration) ([]models.OutboxMessage,error) {
+	claimed := s.messages
+	s.messages = nil
+	return claimed,nil
+}
+
+func (s *memoryOutbox)MarkPublished(ctx context.Context,ids []uuid.UUID) error {
+	s.published = append(s.published,ids...)
+	return nil
+}
+
+func (s *memoryOutbox)MarkFailed(ctx context.Context,id uuid.UUID,cause string,nextAttemptAt time.Time) error {
+	s.failed = append(s.failed,id)
+	return nil
+}
+
+func (s *memoryOutbox)PurgePublished(ctx context.Context,before time.Time) (int64,error) {
+	return 0,nil
+}
+
+// publisher fails the events it is told to.
+type publisher struct {
+	fail map[uuid.UUID]bool
+	got []uuid.UUID
+}
+
+func (p *publisher)Publish(ctx context.Context,event models.Event) error {
+	p.got = append(p.got,event.ID)
+	if p.fail[event.ID] {
+		return errors.New("webhook store is down")
+	}
+	return nil
+}
+
+func outboxMessage(t *testing.T) models.OutboxMessage {
+	t.Helper()
+	event := models.NewEvent(models.EventCarUpdated,uuid.New(),"staff",map[string]string{"id": uuid.NewString()})
+	payload,err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return models.OutboxMessage{ID: event.ID,EventType: event.Type,TenantID: event.TenantID,Payload: payload}
+}
+
+func TestRelayRetriesFailedPublishers(t *testing.T) {
+	ok := outboxMessage(t)
+	failing := outboxMessage(t)
+	store := &memoryOutbox{messages: []models.OutboxMessage{ok,failing}}
+	webhooks := &publisher{fail: map[uuid.UUID]bool{failing.ID: true}}
+	cache := &publisher{}
+	relay := NewRelay(store,broker.NewMemoryBroker(),time.Second,webhooks,cache)
+
+	if n := relay.relayDue(context.Background()); n != 2 {
+		t.Fatalf("relayed %d messages, want 2",n)
+	}
+	if len(store.published) != 1 || store.published[0] != ok.ID {
+		t.Errorf("published %v, want [%s]",store.published,ok.ID)
+	}
+	if len(store.failed) != 1 || store.failed[0] != failing.ID {
+		t.Errorf("failed %v, want [%s]",store.failed,failing.ID)
+	}
+	// one publisher failing doesn't keep the event from the others
+	if len(cache.got) != 2 {
+		t.Errorf("cache got %v, want both events",cache.got)
+	}
+}
@@ -0,0 +1,180 @@
+// Package stream fans car changes out to the clients of /cars/stream. Every
+// instance listens to the outbox itself, so a client sees changes made
+// through any instance no matter which one it is connected to.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// events a subscriber may fall behind by before it is dropped
+	subscriberBuffer = 64
+	// the most events a reconnecting client is replayed before being told to reload
+	maxReplay = 500
+	retryListen = 5 * time.Second
+)
+
+var carEventTypes = []string{models.EventCarCreated,models.EventCarUpdated,models.EventCarDeleted}
+
+var subscriberGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "car_stream_subscribers",
+	Help: "Clients connected to the car change stream",
+})
+
+func init() {
+	prometheus.MustRegister(subscriberGauge)
+}
+
+type Hub struct {
+	events store.EventLogInterface
+	mu sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	filter models.CarEventFilter
+	events chan models.CarEvent
+}
+
+func NewHub(events store.EventLogInterface) *Hub {
+	return &Hub{
+		events: events,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Run feeds subscribers from the outbox until ctx is done.
+func (h *Hub)Run(ctx context.Context) {
+	for {
+		err := h.events.Listen(ctx,h.dispatch)
+		if err != nil {
+			log.Println("Error listening for car events: ",err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryListen):
+		}
+	}
+}
+
+func (h *Hub)Subscribe(filter models.CarEventFilter) (<-chan models.CarEvent,func()) {
+	sub := &subscriber{filter: filter,events: make(chan models.CarEvent,subscriberBuffer)}
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+	subscriberGauge.Inc()
+	return sub.events,func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.drop(sub)
+	}
+}
+
+// drop is called with mu held, closing under the lock keeps dispatch from
+// sending on a closed channel.
+func (h *Hub)drop(sub *subscriber) {
+	if _,ok := h.subscribers[sub]; !ok {
+		return
+	}
+	delete(h.subscribers,sub)
+	close(sub.events)
+	subscriberGauge.Dec()
+}
+
+func (h *Hub)Replay(ctx context.Context,lastEventID string,filter models.CarEventFilter) ([]models.CarEvent,error) {
+	tracer := otel.Tracer("StreamService")
+	ctx,span := tracer.Start(ctx, "Replay-Service")
+	defer span.End()
+
+	afterID,err := uuid.Parse(lastEventID)
+	if err != nil {
+		return nil,models.ErrResumeExpired
+	}
+	messages,err := h.events.EventsAfter(ctx,afterID,carEventTypes,filter.TenantID,maxReplay + 1)
+	if errors.Is(err,models.ErrRecordNotFound) {
+		return nil,models.ErrResumeExpired
+	}
+	if err != nil {
+		return nil,err
+	}
+	if len(messages) > maxReplay {
+		return nil,models.ErrResumeExpired
+	}
+	events := []models.CarEvent{}
+	for _,msg := range messages {
+		event,err := decodeCarEvent(msg.Payload)
+		if err != nil {
+			return nil,err
+		}
+		if filter.Match(event) {
+			events = append(events,filter.Redact(event))
+		}
+	}
+	return events,nil
+}
+
+func (h *Hub)dispatch(msg models.OutboxMessage) {
+	if !isCarEvent(msg.EventType) {
+		return
+	}
+	event,err := decodeCarEvent(msg.Payload)
+	if err != nil {
+		log.Printf("Error decoding outbox event %s: %v",msg.ID,err)
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers {
+		if !sub.filter.Match(event) {
+			continue
+		}
+		select {
+		case sub.events <- sub.filter.Redact(event):
+		default:
+			// too slow, it reconnects and catches up through Replay
+			h.drop(sub)
+		}
+	}
+}
+
+func isCarEvent(eventType string) bool {
+	for _,carEventType := range carEventTypes {
+		if eventType == carEventType {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeCarEvent(payload []byte) (models.CarEvent,error) {
+	var recorded struct {
+		ID uuid.UUID `json:"id"`
+		Type string `json:"type"`
+		TenantID uuid.UUID `json:"tenant_id"`
+		OccurredAt time.Time `json:"occurred_at"`
+		Data models.Car `json:"data"`
+	}
+	if err := json.Unmarshal(payload,&recorded); err != nil {
+		return models.CarEvent{},err
+	}
+	return models.CarEvent{
+		ID: recorded.ID,
+		Type: recorded.Type,
+		TenantID: recorded.TenantID,
+		OccurredAt: recorded.OccurredAt,
+		Car: recorded.Data,
+	},nil
+}
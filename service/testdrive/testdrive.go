@@ -0,0 +1,257 @@
+package testdrive
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// TestDriveService books test drives into the slots a dealership publishes.
+// Customers see and cancel their own bookings, staff see every booking.
+type TestDriveService struct {
+	store store.TestDriveStoreInterface
+	carStore store.CarStoreInterface
+}
+
+func NewTestDriveService(store store.TestDriveStoreInterface,carStore store.CarStoreInterface) *TestDriveService {
+	return &TestDriveService{
+		store: store,
+		carStore: carStore,
+	}
+}
+
+func (s *TestDriveService)GetSchedule(ctx context.Context,dealershipID string) (*models.TestDriveSchedule,error) {
+	tracer := otel.Tracer("TestDriveService")
+	ctx,span := tracer.Start(ctx, "GetSchedule-Service")
+	defer span.End()
+
+	tenantID,err := authorizeDealership(ctx,dealershipID)
+	if err != nil {
+		return nil,err
+	}
+	schedule,err := s.store.GetSchedule(ctx,tenantID)
+	if err != nil {
+		return nil,err
+	}
+	return &schedule,nil
+}
+
+func (s *TestDriveService)ReplaceSchedule(ctx context.Context,dealershipID string,schedule *models.TestDriveSchedule) (*models.TestDriveSchedule,error) {
+	tracer := otel.Tracer("TestDriveService")
+	ctx,span := tracer.Start(ctx, "ReplaceSchedule-Service")
+	defer span.End()
+
+	tenantID,err := authorizeDealership(ctx,dealershipID)
+	if err != nil {
+		return nil,err
+	}
+	if err := models.ValidateTestDriveSchedule(schedule); err != nil {
+		return nil,err
+	}
+	replaced,err := s.store.ReplaceSchedule(ctx,tenantID,schedule)
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s replaced the test drive schedule of dealership %s",auth.Actor(ctx),tenantID)
+	return &replaced,nil
+}
+
+// GetAvailability lists the free slots for the car on date, a YYYY-MM-DD day in the dealership's time zone.
+func (s *TestDriveService)GetAvailability(ctx context.Context,carID string,date string) ([]models.TestDriveAvailability,error) {
+	tracer := otel.Tracer("TestDriveService")
+	ctx,span := tracer.Start(ctx, "GetAvailability-Service")
+	defer span.End()
+
+	car,err := s.bookableCar(ctx,carID)
+	if err != nil {
+		return nil,err
+	}
+	schedule,err := s.store.GetSchedule(ctx,car.TenantID)
+	if err != nil {
+		return nil,err
+	}
+	day,err := time.Parse("2006-01-02",date)
+	if err != nil {
+		return nil,models.ErrInvalidTestDriveDate
+	}
+	slots,err := schedule.SlotsOn(day)
+	if err != nil {
+		return nil,err
+	}
+	if len(slots) == 0 {
+		return slots,nil
+	}
+	booked,err := s.store.GetTestDrivesByCar(ctx,carID,"",slots[0].StartsAt,slots[len(slots)-1].EndsAt)
+	if err != nil {
+		return nil,err
+	}
+	now := time.Now()
+	free := []models.TestDriveAvailability{}
+	for _,slot := range slots {
+		if slot.StartsAt.Before(now) || overlapsBooking(slot,booked) {
+			continue
+		}
+		free = append(free,slot)
+	}
+	return free,nil
+}
+
+// ListTestDrives lists the car's bookings in [from, to), customers only get their own.
+func (s *TestDriveService)ListTestDrives(ctx context.Context,carID string,from time.Time,to time.Time) ([]models.TestDrive,error) {
+	tracer := otel.Tracer("TestDriveService")
+	ctx,span := tracer.Start(ctx, "ListTestDrives-Service")
+	defer span.End()
+
+	if _,err := s.carStore.GetCarByID(ctx,carID); err != nil {
+		return nil,err
+	}
+	customerID := ""
+	if !isStaff(ctx) {
+		customerID = auth.UserID(ctx)
+		if customerID == "" {
+			return nil,models.ErrForbidden
+		}
+	}
+	return s.store.GetTestDrivesByCar(ctx,carID,customerID,from,to)
+}
+
+func (s *TestDriveService)BookTestDrive(ctx context.Context,carID string,testDriveReq *models.TestDriveRequest) (*models.TestDrive,error) {
+	tracer := otel.Tracer("TestDriveService")
+	ctx,span := tracer.Start(ctx, "BookTestDrive-Service")
+	defer span.End()
+
+	if err := models.ValidateTestDriveRequest(testDriveReq); err != nil {
+		return nil,err
+	}
+	customerID := auth.UserID(ctx)
+	if customerID == "" {
+		return nil,models.ErrForbidden
+	}
+	car,err := s.bookableCar(ctx,carID)
+	if err != nil {
+		return nil,err
+	}
+	schedule,err := s.store.GetSchedule(ctx,car.TenantID)
+	if err != nil {
+		return nil,err
+	}
+	slot,err := matchSlot(&schedule,testDriveReq.StartsAt)
+	if err != nil {
+		return nil,err
+	}
+	testDrive,err := s.store.CreateTestDrive(ctx,models.TestDrive{
+		ID: uuid.New(),
+		TenantID: car.TenantID,
+		CarID: car.ID,
+		CustomerID: customerID,
+		CustomerName: testDriveReq.CustomerName,
+		CustomerPhone: testDriveReq.CustomerPhone,
+		StartsAt: slot.StartsAt,
+		EndsAt: slot.EndsAt,
+	})
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s booked test drive %s of car %s at %s",auth.Actor(ctx),testDrive.ID,car.ID,testDrive.StartsAt.Format(time.RFC3339))
+	return &testDrive,nil
+}
+
+// CancelTestDrive cancels a booking, only its customer or staff may do so.
+func (s *TestDriveService)CancelTestDrive(ctx context.Context,carID string,id string) (*models.TestDrive,error) {
+	tracer := otel.Tracer("TestDriveService")
+	ctx,span := tracer.Start(ctx, "CancelTestDrive-Service")
+	defer span.End()
+
+	testDrive,err := s.store.GetTestDriveByID(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	if testDrive.CarID.String() != carID {
+		return nil,models.ErrRecordNotFound
+	}
+	if !isStaff(ctx) && testDrive.CustomerID != auth.UserID(ctx) {
+		return nil,models.ErrForbidden
+	}
+	cancelled,err := s.store.CancelTestDrive(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s cancelled test drive %s",auth.Actor(ctx),cancelled.ID)
+	return &cancelled,nil
+}
+
+// bookableCar only lets customers test drive cars that are for sale, a car
+// they reserved themselves still counts.
+func (s *TestDriveService)bookableCar(ctx context.Context,carID string) (models.Car,error) {
+	car,err := s.carStore.GetCarByID(ctx,carID)
+	if err != nil {
+		return models.Car{},err
+	}
+	if car.Status == models.CarStatusAvailable {
+		return car,nil
+	}
+	if car.Status == models.CarStatusReserved && (isStaff(ctx) || car.ReservedBy == auth.UserID(ctx)) {
+		return car,nil
+	}
+	// buyers cannot see cars that are not for sale at all
+	if !isStaff(ctx) {
+		return models.Car{},models.ErrRecordNotFound
+	}
+	return models.Car{},models.ErrSlotUnavailable
+}
+
+func matchSlot(schedule *models.TestDriveSchedule,startsAt time.Time) (models.TestDriveAvailability,error) {
+	if !startsAt.After(time.Now()) {
+		return models.TestDriveAvailability{},models.ErrSlotUnavailable
+	}
+	loc,err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return models.TestDriveAvailability{},err
+	}
+	slots,err := schedule.SlotsOn(startsAt.In(loc))
+	if err != nil {
+		return models.TestDriveAvailability{},err
+	}
+	for _,slot := range slots {
+		if slot.StartsAt.Equal(startsAt) {
+			return slot,nil
+		}
+	}
+	return models.TestDriveAvailability{},models.ErrSlotUnavailable
+}
+
+func overlapsBooking(slot models.TestDriveAvailability,testDrives []models.TestDrive) bool {
+	for _,testDrive := range testDrives {
+		if testDrive.Status == models.TestDriveBooked && testDrive.StartsAt.Before(slot.EndsAt) && testDrive.EndsAt.After(slot.StartsAt) {
+			return true
+		}
+	}
+	return false
+}
+
+func isStaff(ctx context.Context) bool {
+	principal,ok := auth.PrincipalFromContext(ctx)
+	return ok && principal.HasScope(models.ScopeCarsWrite)
+}
+
+// authorizeDealership lets platform admins and the dealership's own admins manage its schedule.
+func authorizeDealership(ctx context.Context,dealershipID string) (uuid.UUID,error) {
+	id,err := uuid.Parse(dealershipID)
+	if err != nil {
+		return uuid.Nil,models.ErrRecordNotFound
+	}
+	principal,ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return uuid.Nil,models.ErrForbidden
+	}
+	if principal.IsPlatformAdmin() || (principal.TenantID.Valid && principal.TenantID.UUID == id) {
+		return id,nil
+	}
+	return uuid.Nil,models.ErrForbidden
+}
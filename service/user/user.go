@@ -0,0 +1,74 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// compared against when the username doesn't exist, so unknown users take as
+// long to turn away as wrong passwords
+var dummyHash,_ = bcrypt.GenerateFromPassword([]byte("carzone-dummy-password"),bcrypt.DefaultCost)
+
+type UserService struct {
+	store store.UserStoreInterface
+}
+
+func NewUserService(store store.UserStoreInterface) *UserService {
+	return &UserService{
+		store: store,
+	}
+}
+
+func (s *UserService)CreateUser(ctx context.Context,userReq *models.UserRequest) (*models.User,error) {
+	tracer := otel.Tracer("UserService")
+	ctx,span := tracer.Start(ctx, "CreateUser-Service")
+	defer span.End()
+
+	if err := models.ValidateUserRequest(userReq); err != nil {
+		return nil,err
+	}
+	passwordHash,err := bcrypt.GenerateFromPassword([]byte(userReq.Password),bcrypt.DefaultCost)
+	if err != nil {
+		return nil,err
+	}
+	user := models.User{
+		ID: uuid.New(),
+		Username: userReq.Username,
+		Roles: userReq.Roles,
+		TenantID: userReq.TenantID,
+		CreatedAt: time.Now(),
+	}
+	createdUser,err := s.store.CreateUser(ctx,user,string(passwordHash))
+	if err != nil {
+		return nil,err
+	}
+	return &createdUser,nil
+}
+
+// Authenticate fails with models.ErrInvalidCredentials without saying whether
+// the username or the password was wrong.
+func (s *UserService)Authenticate(ctx context.Context,username,password string) (*models.User,error) {
+	tracer := otel.Tracer("UserService")
+	ctx,span := tracer.Start(ctx, "Authenticate-Service")
+	defer span.End()
+
+	user,passwordHash,err := s.store.GetUserByUsername(ctx,username)
+	if errors.Is(err,models.ErrRecordNotFound) {
+		bcrypt.CompareHashAndPassword(dummyHash,[]byte(password))
+		return nil,models.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil,err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(passwordHash),[]byte(password)) != nil {
+		return nil,models.ErrInvalidCredentials
+	}
+	return &user,nil
+}
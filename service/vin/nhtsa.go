@@ -0,0 +1,115 @@
+package vin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iangechuki/go_carzone/models"
+	"go.opentelemetry.io/otel"
+)
+
+const defaultNHTSAURL = "https://vpic.nhtsa.dot.gov/api/vehicles"
+
+// NHTSA looks VINs up in the US National Highway Traffic Safety
+// Administration's vPIC database, which is free and needs no key. It knows
+// vehicles sold in the United States best.
+type NHTSA struct {
+	baseURL string
+	client *http.Client
+}
+
+func NewNHTSA(baseURL string,timeout time.Duration) *NHTSA {
+	return &NHTSA{
+		baseURL: strings.TrimRight(baseURL,"/"),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *NHTSA)Name() string {
+	return "nhtsa"
+}
+
+type nhtsaResponse struct {
+	Results []struct {
+		Make string
+		Manufacturer string
+		Model string
+		ModelYear string
+		PlantCity string
+		PlantState string
+		PlantCountry string
+	}
+}
+
+func (p *NHTSA)Lookup(ctx context.Context,vin string) (models.VINInfo,error) {
+	tracer := otel.Tracer("NHTSA")
+	ctx,span := tracer.Start(ctx, "Lookup-NHTSA")
+	defer span.End()
+
+	req,err := http.NewRequestWithContext(ctx,http.MethodGet,p.baseURL+"/DecodeVinValues/"+url.PathEscape(vin)+"?format=json",nil)
+	if err != nil {
+		return models.VINInfo{},err
+	}
+	resp,err := p.client.Do(req)
+	if err != nil {
+		return models.VINInfo{},err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return models.VINInfo{},fmt.Errorf("nhtsa answered %s",resp.Status)
+	}
+	var body nhtsaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return models.VINInfo{},err
+	}
+	if len(body.Results) == 0 {
+		return models.VINInfo{},errors.New("nhtsa returned no results")
+	}
+	result := body.Results[0]
+	info := models.VINInfo{
+		Manufacturer: result.Manufacturer,
+		Brand: result.Make,
+		Model: result.Model,
+		Country: result.PlantCountry,
+	}
+	info.ModelYear,_ = strconv.Atoi(result.ModelYear)
+	plant := []string{}
+	for _,part := range []string{result.PlantCity,result.PlantState,result.PlantCountry} {
+		if part != "" {
+			plant = append(plant,part)
+		}
+	}
+	info.Plant = strings.Join(plant,", ")
+	return info,nil
+}
+
+// ProviderFromEnv returns the provider VIN_DECODER_PROVIDER names, ok is
+// false when it isn't set and VINs are decoded from the tables alone.
+func ProviderFromEnv() (provider Provider,ok bool,err error) {
+	timeout := 5 * time.Second
+	if v := os.Getenv("VIN_DECODER_TIMEOUT"); v != "" {
+		if timeout,err = time.ParseDuration(v); err != nil {
+			return nil,false,fmt.Errorf("invalid VIN_DECODER_TIMEOUT %q",v)
+		}
+	}
+	switch name := os.Getenv("VIN_DECODER_PROVIDER"); name {
+	case "":
+		return nil,false,nil
+	case "nhtsa":
+		baseURL := os.Getenv("VIN_DECODER_URL")
+		if baseURL == "" {
+			baseURL = defaultNHTSAURL
+		}
+		return NewNHTSA(baseURL,timeout),true,nil
+	default:
+		return nil,false,fmt.Errorf("unknown VIN_DECODER_PROVIDER %q, expected nhtsa",name)
+	}
+}
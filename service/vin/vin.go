@@ -0,0 +1,103 @@
+// Package vin decodes vehicle identification numbers. The built in WMI tables
+// name the manufacturer and brand, the tenth character gives the model year
+// and the eleventh the plant. An optional Provider, such as NHTSA's vPIC,
+// fills in the model and corrects what the tables can't know.
+package vin
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/iangechuki/go_carzone/models"
+	"go.opentelemetry.io/otel"
+)
+
+// Provider looks a VIN up in an external database.
+type Provider interface {
+	// Name is recorded as the source of what the provider decoded.
+	Name() string
+	Lookup(ctx context.Context,vin string) (models.VINInfo,error)
+}
+
+type VINService struct {
+	provider Provider
+	now func() time.Time
+}
+
+// NewVINService decodes from the tables alone when provider is nil.
+func NewVINService(provider Provider) *VINService {
+	return &VINService{
+		provider: provider,
+		now: time.Now,
+	}
+}
+
+// DecodeVIN fails with models.ErrInvalidVIN for malformed VINs. A failing
+// provider only costs the details it would have added.
+func (s *VINService)DecodeVIN(ctx context.Context,vin string) (*models.VINInfo,error) {
+	tracer := otel.Tracer("VINService")
+	ctx,span := tracer.Start(ctx, "DecodeVIN-Service")
+	defer span.End()
+
+	vin = models.NormalizeVIN(vin)
+	if err := models.ValidateVIN(vin); err != nil {
+		return nil,err
+	}
+	info := Decode(vin,s.now().Year())
+	if s.provider == nil {
+		return &info,nil
+	}
+	found,err := s.provider.Lookup(ctx,vin)
+	if err != nil {
+		log.Println("Error: ",err)
+		return &info,nil
+	}
+	merge(&info,found)
+	info.Source = s.provider.Name()
+	return &info,nil
+}
+
+// Decode reads a valid, normalized VIN with the built in tables.
+func Decode(vin string,currentYear int) models.VINInfo {
+	info := models.VINInfo{
+		VIN: vin,
+		WMI: vin[:3],
+		Region: region(vin[0]),
+		Country: country(vin),
+		ModelYear: modelYear(vin,currentYear),
+		PlantCode: vin[10:11],
+		SerialNumber: vin[11:],
+		Source: "wmi",
+	}
+	if m,ok := manufacturers[info.WMI]; ok {
+		info.Manufacturer = m.name
+		info.Brand = m.brand
+		info.Plant = plants[m.brand][vin[10]]
+	}
+	return info
+}
+
+// merge lets the provider fill the gaps and settle the model year, it has
+// the manufacturer's own data.
+func merge(info *models.VINInfo,found models.VINInfo) {
+	if found.Manufacturer != "" {
+		info.Manufacturer = found.Manufacturer
+	}
+	// the tables spell brands the way dealers list them
+	if info.Brand == "" {
+		info.Brand = found.Brand
+	}
+	if found.Model != "" {
+		info.Model = found.Model
+	}
+	if found.ModelYear != 0 {
+		info.ModelYear = found.ModelYear
+	}
+	if found.Plant != "" {
+		info.Plant = found.Plant
+	}
+	if info.Country == "" {
+		info.Country = found.Country
+	}
+}
@@ -0,0 +1,171 @@
+package vin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iangechuki/go_carzone/models"
+)
+
+// withCheckDigit puts the right check digit in vin's ninth place.
+func withCheckDigit(t *testing.T,vin string) string {
+	t.Helper()
+	for _,c := range "0123456789X" {
+		candidate := vin[:8] + string(c) + vin[9:]
+		if models.ValidateVIN(candidate) == nil {
+			return candidate
+		}
+	}
+	t.Fatalf("no check digit fits %s",vin)
+	return ""
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		vin string
+		region string
+		country string
+		manufacturer string
+		brand string
+		plant string
+	}{
+		{vin: "1HGCM82633A004352",region: "North America",country: "United States",manufacturer: "Honda of America Manufacturing",brand: "Honda"},
+		{vin: "5YJ3E1EA0KF317000",region: "North America",country: "United States",manufacturer: "Tesla",brand: "Tesla",plant: "Fremont, California"},
+		{vin: "LRW3E7FA0MC000001",region: "Asia",country: "China",manufacturer: "Tesla Shanghai",brand: "Tesla",plant: "Shanghai, China"},
+		{vin: "JTHBA1D20G5000001",region: "Asia",country: "Japan",manufacturer: "Toyota Motor Corporation",brand: "Lexus"},
+		{vin: "WBA8E9G50GNU00001",region: "Europe",country: "Germany",manufacturer: "BMW",brand: "BMW"},
+		{vin: "SALGA2BE5KA000001",region: "Europe",country: "United Kingdom",manufacturer: "Jaguar Land Rover",brand: "Land Rover"},
+		{vin: "9BWAA05U0BP000001",region: "South America",country: "Brazil",manufacturer: "Volkswagen do Brasil",brand: "Volkswagen"},
+		// a WMI the tables don't list still has its region and country
+		{vin: "1M8GDM9AXKP042788",region: "North America",country: "United States"},
+		{vin: "XTA21099041000001",region: "Europe"},
+	}
+	for _,tt := range tests {
+		t.Run(tt.vin,func(t *testing.T) {
+			info := Decode(tt.vin,2026)
+			if info.WMI != tt.vin[:3] || info.Region != tt.region || info.Country != tt.country {
+				t.Errorf("decoded %s/%s/%s, want %s/%s/%s",info.WMI,info.Region,info.Country,tt.vin[:3],tt.region,tt.country)
+			}
+			if info.Manufacturer != tt.manufacturer || info.Brand != tt.brand || info.Plant != tt.plant {
+				t.Errorf("decoded %q %q %q, want %q %q %q",info.Manufacturer,info.Brand,info.Plant,tt.manufacturer,tt.brand,tt.plant)
+			}
+			if info.PlantCode != tt.vin[10:11] || info.SerialNumber != tt.vin[11:] || info.Source != "wmi" {
+				t.Errorf("decoded %+v",info)
+			}
+		})
+	}
+}
+
+func TestCheckDigit(t *testing.T) {
+	service := NewVINService(nil)
+	tests := []struct {
+		name string
+		vin string
+		want byte
+	}{
+		{name: "digit",vin: "1HGCM82633A004352",want: '3'},
+		{name: "X for a remainder of 10",vin: "1M8GDM9AXKP042788",want: 'X'},
+		{name: "tesla",vin: "5YJ3E1EA2KF317000",want: '2'},
+		{name: "chinese",vin: "LVSHCAMB1CE054249",want: '1'},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			for _,c := range "0123456789X" {
+				vin := tt.vin[:8] + string(c) + tt.vin[9:]
+				_,err := service.DecodeVIN(context.Background(),vin)
+				if byte(c) == tt.want {
+					if err != nil {
+						t.Errorf("DecodeVIN(%s) = %v",vin,err)
+					}
+				} else if !errors.Is(err,models.ErrInvalidVIN) {
+					t.Errorf("DecodeVIN(%s) = %v, want %v",vin,err,models.ErrInvalidVIN)
+				}
+			}
+		})
+	}
+
+	// outside North America and China the ninth character is the maker's to use
+	if _,err := service.DecodeVIN(context.Background(),"WVWZZZ1JZXW000001"); err != nil {
+		t.Errorf("european VIN: %v",err)
+	}
+	if _,err := service.DecodeVIN(context.Background()," 1hgcm82633a004352 "); err != nil {
+		t.Errorf("VIN needing normalizing: %v",err)
+	}
+}
+
+func TestModelYear(t *testing.T) {
+	tests := []struct {
+		name string
+		vin string
+		currentYear int
+		want int
+	}{
+		{name: "north american before 2010",vin: "1HGCM82633A004352",currentYear: 2026,want: 2003},
+		{name: "north american from 2010",vin: "5YJ3E1EA7KF317000",currentYear: 2026,want: 2019},
+		{name: "north american 1980s",vin: "1M8GDM9AXKP042788",currentYear: 2026,want: 1989},
+		// A is 1980 and 2010, the seventh character tells a North American VIN's cycle whatever the date
+		{name: "north american A with a digit",vin: "1G1ZT5381AF000001",currentYear: 2026,want: 1980},
+		{name: "north american A with a letter",vin: "1G1ZT5A31AF000001",currentYear: 2026,want: 2010},
+		{name: "north american Y",vin: "1G1ZT5381YF000001",currentYear: 2035,want: 2000},
+		{name: "european latest cycle",vin: "WVWZZZ1JZXW000001",currentYear: 2026,want: 1999},
+		{name: "european this cycle",vin: "WVWZZZ1JZRW000001",currentYear: 2026,want: 2024},
+		{name: "next year's models",vin: "WVWZZZ1JZVW000001",currentYear: 2026,want: 2027},
+		// elsewhere the same code moves to the next cycle once its year is at most a year away
+		{name: "european A in 2026",vin: "WVWZZZ1JZAW000001",currentYear: 2026,want: 2010},
+		{name: "european A in 2039",vin: "WVWZZZ1JZAW000001",currentYear: 2039,want: 2040},
+		{name: "european Y in 2028",vin: "WVWZZZ1JZYW000001",currentYear: 2028,want: 2000},
+		{name: "european Y in 2029",vin: "WVWZZZ1JZYW000001",currentYear: 2029,want: 2030},
+		{name: "european 9 in 2026",vin: "WVWZZZ1JZ9W000001",currentYear: 2026,want: 2009},
+		{name: "unused code",vin: "WVWZZZ1JZUW000001",currentYear: 2026,want: 0},
+		{name: "zero is unused",vin: "WVWZZZ1JZ0W000001",currentYear: 2026,want: 0},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			if got := modelYear(tt.vin,tt.currentYear); got != tt.want {
+				t.Errorf("modelYear(%q) = %d, want %d",tt.vin,got,tt.want)
+			}
+		})
+	}
+}
+
+// provider answers every lookup with info, or fails with err.
+type provider struct {
+	info models.VINInfo
+	err error
+}
+
+func (p provider)Name() string {
+	return "stub"
+}
+
+func (p provider)Lookup(ctx context.Context,vin string) (models.VINInfo,error) {
+	return p.info,p.err
+}
+
+func TestDecodeVINProvider(t *testing.T) {
+	vin := withCheckDigit(t,"5YJ3E1EA0KF317000")
+	now := func() time.Time { return time.Date(2026,time.March,1,0,0,0,0,time.UTC) }
+
+	s := NewVINService(provider{info: models.VINInfo{Manufacturer: "Tesla, Inc.",Brand: "TESLA",Model: "Model 3",ModelYear: 2019,Plant: "Fremont"}})
+	s.now = now
+	info,err := s.DecodeVIN(context.Background(),vin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the provider knows the model and its own names, the tables keep the dealer's spelling of the brand
+	if info.Manufacturer != "Tesla, Inc." || info.Brand != "Tesla" || info.Model != "Model 3" || info.Plant != "Fremont" || info.Source != "stub" {
+		t.Errorf("decoded %+v",info)
+	}
+
+	s = NewVINService(provider{err: errors.New("vPIC is down")})
+	s.now = now
+	info,err = s.DecodeVIN(context.Background(),vin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Source != "wmi" || info.Brand != "Tesla" || info.ModelYear != 2019 {
+		t.Errorf("decoded %+v without the provider",info)
+	}
+}
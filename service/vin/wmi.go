@@ -0,0 +1,190 @@
+package vin
+
+import "strings"
+
+// vinAlphabet orders the characters a VIN may hold the way ISO 3780 assigns
+// country ranges, letters before digits and 0 last.
+const vinAlphabet = "ABCDEFGHJKLMNPRSTUVWXYZ1234567890"
+
+// region is decided by the first character alone.
+func region(c byte) string {
+	switch {
+	case c >= 'A' && c <= 'H':
+		return "Africa"
+	case c >= 'J' && c <= 'R':
+		return "Asia"
+	case c >= 'S' && c <= 'Z':
+		return "Europe"
+	case c >= '1' && c <= '5':
+		return "North America"
+	case c == '6' || c == '7':
+		return "Oceania"
+	case c == '8' || c == '9' || c == '0':
+		return "South America"
+	default:
+		return ""
+	}
+}
+
+// countryRange gives the countries assigned a run of second characters.
+type countryRange struct {
+	first byte
+	from,to byte
+	country string
+}
+
+// countries only lists the ranges of countries that build cars in numbers.
+var countries = []countryRange{
+	{'1','A','0',"United States"},
+	{'4','A','0',"United States"},
+	{'5','A','0',"United States"},
+	{'2','A','0',"Canada"},
+	{'3','A','W',"Mexico"},
+	{'6','A','W',"Australia"},
+	{'7','A','E',"New Zealand"},
+	{'8','A','E',"Argentina"},
+	{'9','A','E',"Brazil"},
+	{'9','3','9',"Brazil"},
+	{'A','A','H',"South Africa"},
+	{'J','A','0',"Japan"},
+	{'K','L','R',"South Korea"},
+	{'L','A','0',"China"},
+	{'M','A','E',"India"},
+	{'M','F','K',"Indonesia"},
+	{'M','L','R',"Thailand"},
+	{'N','L','R',"Turkey"},
+	{'P','L','R',"Malaysia"},
+	{'S','A','M',"United Kingdom"},
+	{'S','N','T',"Germany"},
+	{'S','U','Z',"Poland"},
+	{'T','A','H',"Switzerland"},
+	{'T','J','P',"Czech Republic"},
+	{'T','R','V',"Hungary"},
+	{'T','W','1',"Portugal"},
+	{'U','U','7',"Romania"},
+	{'V','A','E',"Austria"},
+	{'V','F','R',"France"},
+	{'V','S','W',"Spain"},
+	{'W','A','0',"Germany"},
+	{'X','L','R',"Netherlands"},
+	{'X','3','0',"Russia"},
+	{'Y','A','E',"Belgium"},
+	{'Y','F','K',"Finland"},
+	{'Y','S','W',"Sweden"},
+	{'Z','A','R',"Italy"},
+}
+
+func country(vin string) string {
+	second := strings.IndexByte(vinAlphabet,vin[1])
+	for _,r := range countries {
+		if r.first != vin[0] {
+			continue
+		}
+		if second >= strings.IndexByte(vinAlphabet,r.from) && second <= strings.IndexByte(vinAlphabet,r.to) {
+			return r.country
+		}
+	}
+	return ""
+}
+
+type manufacturer struct {
+	name string
+	brand string
+}
+
+// manufacturers is keyed by WMI. Makers building fewer than 1000 vehicles a
+// year share a WMI ending in 9 and aren't listed.
+var manufacturers = map[string]manufacturer{
+	"1FA": {"Ford Motor Company","Ford"},
+	"1FM": {"Ford Motor Company","Ford"},
+	"1FT": {"Ford Motor Company","Ford"},
+	"1G1": {"General Motors","Chevrolet"},
+	"1GC": {"General Motors","Chevrolet"},
+	"1GN": {"General Motors","Chevrolet"},
+	"1GT": {"General Motors","GMC"},
+	"1G6": {"General Motors","Cadillac"},
+	"1C3": {"FCA US","Chrysler"},
+	"1C4": {"FCA US","Chrysler"},
+	"1C6": {"FCA US","Ram"},
+	"1J4": {"FCA US","Jeep"},
+	"1HG": {"Honda of America Manufacturing","Honda"},
+	"1N4": {"Nissan North America","Nissan"},
+	"1VW": {"Volkswagen Group of America","Volkswagen"},
+	"2HG": {"Honda of Canada Manufacturing","Honda"},
+	"2T1": {"Toyota Motor Manufacturing Canada","Toyota"},
+	"2T3": {"Toyota Motor Manufacturing Canada","Toyota"},
+	"3FA": {"Ford Motor Company","Ford"},
+	"3VW": {"Volkswagen de Mexico","Volkswagen"},
+	"3N1": {"Nissan Mexicana","Nissan"},
+	"4S3": {"Subaru of Indiana Automotive","Subaru"},
+	"4S4": {"Subaru of Indiana Automotive","Subaru"},
+	"4T1": {"Toyota Motor Manufacturing Kentucky","Toyota"},
+	"5N1": {"Nissan North America","Nissan"},
+	"5UX": {"BMW Manufacturing","BMW"},
+	"5YJ": {"Tesla","Tesla"},
+	"7SA": {"Tesla","Tesla"},
+	"6FP": {"Ford Motor Company of Australia","Ford"},
+	"9BW": {"Volkswagen do Brasil","Volkswagen"},
+	"JA3": {"Mitsubishi Motors","Mitsubishi"},
+	"JF1": {"Subaru Corporation","Subaru"},
+	"JF2": {"Subaru Corporation","Subaru"},
+	"JHM": {"Honda Motor Company","Honda"},
+	"JM1": {"Mazda Motor Corporation","Mazda"},
+	"JN1": {"Nissan Motor Company","Nissan"},
+	"JT2": {"Toyota Motor Corporation","Toyota"},
+	"JTD": {"Toyota Motor Corporation","Toyota"},
+	"JTH": {"Toyota Motor Corporation","Lexus"},
+	"KMH": {"Hyundai Motor Company","Hyundai"},
+	"KNA": {"Kia Corporation","Kia"},
+	"KND": {"Kia Corporation","Kia"},
+	"LRW": {"Tesla Shanghai","Tesla"},
+	"MA1": {"Mahindra & Mahindra","Mahindra"},
+	"MAT": {"Tata Motors","Tata"},
+	"SAJ": {"Jaguar Land Rover","Jaguar"},
+	"SAL": {"Jaguar Land Rover","Land Rover"},
+	"SCC": {"Lotus Cars","Lotus"},
+	"SCF": {"Aston Martin Lagonda","Aston Martin"},
+	"TMB": {"Skoda Auto","Skoda"},
+	"VF1": {"Renault","Renault"},
+	"VF3": {"Stellantis","Peugeot"},
+	"VF7": {"Stellantis","Citroen"},
+	"VSS": {"SEAT","SEAT"},
+	"WAU": {"Audi","Audi"},
+	"WBA": {"BMW","BMW"},
+	"WBS": {"BMW M","BMW"},
+	"WDB": {"Mercedes-Benz","Mercedes-Benz"},
+	"WDD": {"Mercedes-Benz","Mercedes-Benz"},
+	"W1K": {"Mercedes-Benz","Mercedes-Benz"},
+	"WME": {"smart","smart"},
+	"WP0": {"Porsche","Porsche"},
+	"WP1": {"Porsche","Porsche"},
+	"WVW": {"Volkswagen","Volkswagen"},
+	"WV1": {"Volkswagen Commercial Vehicles","Volkswagen"},
+	"WV2": {"Volkswagen Commercial Vehicles","Volkswagen"},
+	"YS3": {"Saab","Saab"},
+	"YV1": {"Volvo Cars","Volvo"},
+	"ZAR": {"Alfa Romeo","Alfa Romeo"},
+	"ZFA": {"Fiat","Fiat"},
+	"ZFF": {"Ferrari","Ferrari"},
+	"ZHW": {"Lamborghini","Lamborghini"},
+}
+
+// plants maps the eleventh character to an assembly plant for the brands
+// whose codes are published, everyone else only gets the code.
+var plants = map[string]map[byte]string{
+	"Tesla": {
+		'A': "Austin, Texas",
+		'B': "Berlin, Germany",
+		'C': "Shanghai, China",
+		'F': "Fremont, California",
+		'P': "Palo Alto, California",
+	},
+	"Ford": {
+		'E': "Kentucky Truck, Louisville",
+		'F': "Dearborn, Michigan",
+		'K': "Kansas City, Missouri",
+		'L': "Michigan Assembly, Wayne",
+		'R': "Hermosillo, Mexico",
+		'U': "Louisville Assembly, Kentucky",
+	},
+}
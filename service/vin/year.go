@@ -0,0 +1,29 @@
+package vin
+
+import "strings"
+
+// yearCodes are the tenth character's values from 1980 on, repeating every
+// 30 years. I, O, Q, U, Z and 0 are never used.
+const yearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+// modelYear decodes the tenth character. The code repeats every 30 years,
+// North American passenger vehicles tell the cycles apart with the seventh
+// character, a digit up to 2009 and a letter from 2010. Other VINs get the
+// latest year that isn't past next year's models.
+func modelYear(vin string,currentYear int) int {
+	i := strings.IndexByte(yearCodes,vin[9])
+	if i < 0 {
+		return 0
+	}
+	year := 1980 + i
+	if region(vin[0]) == "North America" {
+		if vin[6] >= 'A' && vin[6] <= 'Z' {
+			year += 30
+		}
+		return year
+	}
+	for year+30 <= currentYear+1 {
+		year += 30
+	}
+	return year
+}
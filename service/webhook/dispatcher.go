@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// a delivery is given up after this many attempts, about fourteen hours of retrying
+	maxAttempts = 12
+	baseBackoff = 30 * time.Second
+	maxBackoff = 6 * time.Hour
+	batchSize = 50
+	// how long a claimed delivery is held before another dispatcher may retry it
+	claimLease = 2 * time.Minute
+)
+
+// Dispatcher sends due webhook deliveries, retrying failures with exponential backoff.
+type Dispatcher struct {
+	store store.WebhookStoreInterface
+	client *http.Client
+	interval time.Duration
+}
+
+func NewDispatcher(store store.WebhookStoreInterface,interval time.Duration) *Dispatcher {
+	return &Dispatcher{
+		store: store,
+		client: newClient(),
+		interval: interval,
+	}
+}
+
+var errPrivateAddress = errors.New("webhook address is not public")
+
+// carrierNAT is the shared address space carriers use behind NAT, 100.64.0.0/10.
+var carrierNAT = &net.IPNet{IP: net.IPv4(100,64,0,0),Mask: net.CIDRMask(10,32)}
+
+// newClient refuses to connect webhooks to internal addresses. The check runs
+// on the address actually dialled, after DNS, so a host name that resolves or
+// rebinds to an internal address is caught as well. Proxies would dial on our
+// behalf and redirects could point anywhere, so neither is followed.
+func newClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second,Control: refusePrivate}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: transport,
+		CheckRedirect: func(*http.Request,[]*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func refusePrivate(network,address string,conn syscall.RawConn) error {
+	host,_,err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !publicIP(ip) {
+		return fmt.Errorf("%w: %s",errPrivateAddress,host)
+	}
+	return nil
+}
+
+// publicIP reports whether ip is routable on the internet, rather than
+// private, loopback, link-local (cloud metadata lives there) or unspecified.
+func publicIP(ip net.IP) bool {
+	return !(ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || carrierNAT.Contains(ip))
+}
+
+// Run polls for due deliveries until ctx is done.
+func (d *Dispatcher)Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		// keep draining while full batches come back
+		for d.dispatchDue(ctx) == batchSize {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher)dispatchDue(ctx context.Context) int {
+	deliveries,err := d.store.ClaimDueDeliveries(ctx,batchSize,claimLease)
+	if err != nil {
+		log.Println("Error claiming webhook deliveries: ",err)
+		return 0
+	}
+	for _,delivery := range deliveries {
+		d.deliver(ctx,delivery)
+	}
+	return len(deliveries)
+}
+
+func (d *Dispatcher)deliver(ctx context.Context,delivery models.WebhookDelivery) {
+	tracer := otel.Tracer("WebhookDispatcher")
+	ctx,span := tracer.Start(ctx, "Deliver-Webhook")
+	defer span.End()
+
+	delivery.Attempts++
+	statusCode,err := d.send(ctx,delivery)
+	delivery.LastStatusCode = statusCode
+	now := time.Now()
+	switch {
+	case err == nil:
+		delivery.Status = models.DeliverySucceeded
+		delivery.LastError = ""
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= maxAttempts:
+		delivery.Status = models.DeliveryFailed
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = nil
+	default:
+		next := now.Add(Backoff(delivery.Attempts))
+		delivery.Status = models.DeliveryPending
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = &next
+	}
+	if err := d.store.RecordAttempt(ctx,delivery); err != nil {
+		log.Println("Error recording webhook delivery: ",err)
+	}
+}
+
+func (d *Dispatcher)send(ctx context.Context,delivery models.WebhookDelivery) (int,error) {
+	req,err := http.NewRequestWithContext(ctx,http.MethodPost,delivery.URL,bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0,err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type","application/json")
+	req.Header.Set("User-Agent","CarZone-Webhooks/1.0")
+	req.Header.Set("X-CarZone-Event",delivery.EventType)
+	req.Header.Set("X-CarZone-Delivery",delivery.ID.String())
+	req.Header.Set("X-CarZone-Signature",Sign(delivery.Secret,timestamp,delivery.Payload))
+	resp,err := d.client.Do(req)
+	if err != nil {
+		return 0,err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard,io.LimitReader(resp.Body,64 << 10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode,fmt.Errorf("endpoint responded %s",resp.Status)
+	}
+	return resp.StatusCode,nil
+}
+
+// Sign returns the X-CarZone-Signature header for body, "t=<unix time>,v1=<hex HMAC-SHA256>".
+// Receivers recompute the HMAC over "<t>.<body>" with their secret and should
+// reject old timestamps to stop replays.
+func Sign(secret string,timestamp int64,body []byte) string {
+	mac := hmac.New(sha256.New,[]byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp,10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + strconv.FormatInt(timestamp,10) + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff is how long to wait after the given number of failed attempts, doubling from 30s up to 6h.
+func Backoff(attempts int) time.Duration {
+	backoff := baseBackoff
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
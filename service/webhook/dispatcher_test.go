@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/models"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	mac := hmac.New(sha256.New,[]byte("secret"))
+	mac.Write([]byte("1700000000." + string(body)))
+	want := "t=1700000000,v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := Sign("secret",1700000000,body); got != want {
+		t.Errorf("Sign = %s, want %s",got,want)
+	}
+	if Sign("other",1700000000,body) == want {
+		t.Error("signature does not depend on the secret")
+	}
+	if Sign("secret",1700000001,body) == want {
+		t.Error("signature does not depend on the timestamp")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want time.Duration
+	}{
+		{attempts: 0,want: 30 * time.Second},
+		{attempts: 1,want: 30 * time.Second},
+		{attempts: 2,want: time.Minute},
+		{attempts: 3,want: 2 * time.Minute},
+		{attempts: 10,want: 256 * time.Minute},
+		{attempts: 11,want: 6 * time.Hour},
+		{attempts: maxAttempts,want: 6 * time.Hour},
+		{attempts: 100,want: 6 * time.Hour},
+	}
+	for _,tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v",tt.attempts,got,tt.want)
+		}
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	tests := []struct {
+		ip string
+		want bool
+	}{
+		{ip: "93.184.216.34",want: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946",want: true},
+		{ip: "127.0.0.1",want: false},
+		{ip: "::1",want: false},
+		{ip: "10.1.2.3",want: false},
+		{ip: "172.16.0.1",want: false},
+		{ip: "192.168.1.1",want: false},
+		{ip: "169.254.169.254",want: false},
+		{ip: "fe80::1",want: false},
+		{ip: "fd00::1",want: false},
+		{ip: "100.64.0.1",want: false},
+		{ip: "0.0.0.0",want: false},
+		{ip: "::ffff:127.0.0.1",want: false},
+	}
+	for _,tt := range tests {
+		if got := publicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("publicIP(%s) = %v, want %v",tt.ip,got,tt.want)
+		}
+	}
+}
+
+func TestSendRefusesLoopback(t *testing.T) {
+	called := false
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter,r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(nil,time.Minute)
+	_,err := d.send(context.Background(),models.WebhookDelivery{ID: uuid.New(),URL: server.URL,Payload: []byte(`{}`)})
+	if !errors.Is(err,errPrivateAddress) {
+		t.Fatalf("send = %v, want %v",err,errPrivateAddress)
+	}
+	if called {
+		t.Error("the loopback endpoint was called")
+	}
+}
@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// the delivery log endpoint returns at most this many deliveries
+const deliveryLogLimit = 100
+
+// WebhookService manages a dealership's webhooks and turns published events
+// into deliveries, which the Dispatcher sends.
+type WebhookService struct {
+	store store.WebhookStoreInterface
+}
+
+func NewWebhookService(store store.WebhookStoreInterface) *WebhookService {
+	return &WebhookService{
+		store: store,
+	}
+}
+
+func (s *WebhookService)CreateWebhook(ctx context.Context,webhookReq *models.WebhookRequest) (*models.IssuedWebhook,error) {
+	tracer := otel.Tracer("WebhookService")
+	ctx,span := tracer.Start(ctx, "CreateWebhook-Service")
+	defer span.End()
+
+	if err := models.ValidateWebhookRequest(webhookReq); err != nil {
+		return nil,err
+	}
+	tenantID := auth.TenantID(ctx)
+	if !tenantID.Valid {
+		return nil,models.ErrTenantRequired
+	}
+	secret,err := newSecret()
+	if err != nil {
+		return nil,err
+	}
+	webhook,err := s.store.CreateWebhook(ctx,models.Webhook{
+		ID: uuid.New(),
+		TenantID: tenantID.UUID,
+		URL: webhookReq.URL,
+		Events: webhookReq.Events,
+		Secret: secret,
+		Active: true,
+		CreatedBy: auth.UserID(ctx),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s created webhook %s for %s",auth.Actor(ctx),webhook.ID,webhook.URL)
+	return &models.IssuedWebhook{Webhook: webhook,Secret: secret},nil
+}
+
+func (s *WebhookService)ListWebhooks(ctx context.Context) ([]models.Webhook,error) {
+	tracer := otel.Tracer("WebhookService")
+	ctx,span := tracer.Start(ctx, "ListWebhooks-Service")
+	defer span.End()
+
+	return s.store.ListWebhooks(ctx)
+}
+
+func (s *WebhookService)DeleteWebhook(ctx context.Context,id string) (*models.Webhook,error) {
+	tracer := otel.Tracer("WebhookService")
+	ctx,span := tracer.Start(ctx, "DeleteWebhook-Service")
+	defer span.End()
+
+	webhook,err := s.store.DeleteWebhook(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s deleted webhook %s",auth.Actor(ctx),webhook.ID)
+	return &webhook,nil
+}
+
+func (s *WebhookService)ListDeliveries(ctx context.Context,webhookID string) ([]models.WebhookDelivery,error) {
+	tracer := otel.Tracer("WebhookService")
+	ctx,span := tracer.Start(ctx, "ListDeliveries-Service")
+	defer span.End()
+
+	if _,err := s.store.GetWebhookByID(ctx,webhookID); err != nil {
+		return nil,err
+	}
+	return s.store.ListDeliveries(ctx,webhookID,deliveryLogLimit)
+}
+
+// ReplayDelivery sends a delivery's payload again as a new delivery, whatever became of the original.
+func (s *WebhookService)ReplayDelivery(ctx context.Context,webhookID string,id string) (*models.WebhookDelivery,error) {
+	tracer := otel.Tracer("WebhookService")
+	ctx,span := tracer.Start(ctx, "ReplayDelivery-Service")
+	defer span.End()
+
+	original,err := s.store.GetDeliveryByID(ctx,id)
+	if err != nil {
+		return nil,err
+	}
+	if original.WebhookID.String() != webhookID {
+		return nil,models.ErrRecordNotFound
+	}
+	now := time.Now()
+	replay := models.WebhookDelivery{
+		ID: uuid.New(),
+		WebhookID: original.WebhookID,
+		EventID: original.EventID,
+		EventType: original.EventType,
+		Payload: original.Payload,
+		Status: models.DeliveryPending,
+		NextAttemptAt: &now,
+		ReplayOf: &original.ID,
+		CreatedAt: now,
+	}
+	if err := s.store.CreateDeliveries(ctx,[]models.WebhookDelivery{replay}); err != nil {
+		return nil,err
+	}
+	log.Printf("audit: %s replayed webhook delivery %s as %s",auth.Actor(ctx),original.ID,replay.ID)
+	return &replay,nil
+}
+
+// Publish queues a delivery of event to every webhook of its dealership that
+// wants it. The relay may hand over the same event twice, it is only queued once.
+func (s *WebhookService)Publish(ctx context.Context,event models.Event) error {
+	tracer := otel.Tracer("WebhookService")
+	ctx,span := tracer.Start(ctx, "Publish-Service")
+	defer span.End()
+
+	webhooks,err := s.store.ListActiveWebhooks(ctx,event.TenantID)
+	if err != nil {
+		return fmt.Errorf("listing webhooks: %w",err)
+	}
+	payload,err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding event: %w",err)
+	}
+	now := time.Now()
+	deliveries := []models.WebhookDelivery{}
+	for _,webhook := range webhooks {
+		if !webhook.Wants(event.Type) {
+			continue
+		}
+		deliveries = append(deliveries,models.WebhookDelivery{
+			ID: uuid.New(),
+			WebhookID: webhook.ID,
+			EventID: event.ID,
+			EventType: event.Type,
+			Payload: payload,
+			Status: models.DeliveryPending,
+			NextAttemptAt: &now,
+			CreatedAt: now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := s.store.CreateDeliveries(ctx,deliveries); err != nil {
+		return fmt.Errorf("queueing webhook deliveries: %w",err)
+	}
+	return nil
+}
+
+func newSecret() (string,error) {
+	b := make([]byte,32)
+	if _,err := rand.Read(b); err != nil {
+		return "",err
+	}
+	return "whsec_" + hex.EncodeToString(b),nil
+}
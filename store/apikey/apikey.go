@@ -0,0 +1,133 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+const apiKeyColumns = `id, name, prefix, scopes, created_by, tenant_id, created_at, last_used_at, revoked_at`
+
+func scanAPIKey(row interface{ Scan(dest ...any) error }) (models.APIKey,error) {
+	var key models.APIKey
+	var lastUsedAt,revokedAt sql.NullTime
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		pq.Array(&key.Scopes),
+		&key.CreatedBy,
+		&key.TenantID,
+		&key.CreatedAt,
+		&lastUsedAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return models.APIKey{},err
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return key,nil
+}
+
+func (s *Store)CreateAPIKey(ctx context.Context,key models.APIKey,keyHash string) (models.APIKey,error) {
+	tracer := otel.Tracer("APIKeyStore")
+	ctx,span := tracer.Start(ctx, "CreateAPIKey-Store")
+	defer span.End()
+
+	query := `INSERT INTO api_key (id, name, prefix, key_hash, scopes, created_by, tenant_id, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_,err := s.db.ExecContext(ctx,query,key.ID,key.Name,key.Prefix,keyHash,pq.Array(key.Scopes),key.CreatedBy,key.TenantID,key.CreatedAt)
+	if err != nil {
+		return models.APIKey{},err
+	}
+	return key,nil
+}
+
+func (s *Store)GetAPIKeyByHash(ctx context.Context,keyHash string) (models.APIKey,error) {
+	tracer := otel.Tracer("APIKeyStore")
+	ctx,span := tracer.Start(ctx, "GetAPIKeyByHash-Store")
+	defer span.End()
+
+	row := s.db.QueryRowContext(ctx,`SELECT `+apiKeyColumns+` FROM api_key WHERE key_hash = $1`,keyHash)
+	key,err := scanAPIKey(row)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.APIKey{},models.ErrRecordNotFound
+		}
+		return models.APIKey{},err
+	}
+	return key,nil
+}
+
+func (s *Store)ListAPIKeys(ctx context.Context) ([]models.APIKey,error) {
+	tracer := otel.Tracer("APIKeyStore")
+	ctx,span := tracer.Start(ctx, "ListAPIKeys-Store")
+	defer span.End()
+
+	rows,err := s.db.QueryContext(ctx,`SELECT `+apiKeyColumns+` FROM api_key WHERE ($1::uuid IS NULL OR tenant_id = $1) ORDER BY created_at`,auth.TenantID(ctx))
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key,err := scanAPIKey(rows)
+		if err != nil {
+			return nil,err
+		}
+		keys = append(keys,key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	return keys,nil
+}
+
+func (s *Store)RevokeAPIKey(ctx context.Context,id string) (models.APIKey,error) {
+	tracer := otel.Tracer("APIKeyStore")
+	ctx,span := tracer.Start(ctx, "RevokeAPIKey-Store")
+	defer span.End()
+
+	query := `UPDATE api_key SET revoked_at = COALESCE(revoked_at, $2) WHERE id = $1 AND ($3::uuid IS NULL OR tenant_id = $3) RETURNING `+apiKeyColumns
+	key,err := scanAPIKey(s.db.QueryRowContext(ctx,query,id,time.Now(),auth.TenantID(ctx)))
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.APIKey{},models.ErrRecordNotFound
+		}
+		return models.APIKey{},err
+	}
+	return key,nil
+}
+
+// TouchAPIKey records usage at most once a minute so busy integrations don't write on every request.
+func (s *Store)TouchAPIKey(ctx context.Context,id string,usedAt time.Time) error {
+	tracer := otel.Tracer("APIKeyStore")
+	ctx,span := tracer.Start(ctx, "TouchAPIKey-Store")
+	defer span.End()
+
+	_,err := s.db.ExecContext(ctx,
+		`UPDATE api_key SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $2 - INTERVAL '1 minute')`,
+		id,usedAt)
+	return err
+}
@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Backend holds encoded cache entries.
+type Backend interface {
+	Get(ctx context.Context,key string) ([]byte,bool,error)
+	Set(ctx context.Context,key string,value []byte,ttl time.Duration) error
+	Delete(ctx context.Context,keys ...string) error
+}
+
+type memoryEntry struct {
+	key string
+	value []byte
+	expiresAt time.Time
+}
+
+// MemoryBackend is a process local LRU, entries are not shared between replicas.
+type MemoryBackend struct {
+	mu sync.Mutex
+	size int
+	entries map[string]*list.Element
+	// most recently used at the front
+	order *list.List
+}
+
+func NewMemoryBackend(size int) *MemoryBackend {
+	return &MemoryBackend{
+		size: size,
+		entries: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (b *MemoryBackend)Get(ctx context.Context,key string) ([]byte,bool,error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	elem,ok := b.entries[key]
+	if !ok {
+		return nil,false,nil
+	}
+	entry := elem.Value.(*memoryEntry)
+	if time.Now().After(entry.expiresAt) {
+		b.remove(elem)
+		return nil,false,nil
+	}
+	b.order.MoveToFront(elem)
+	return entry.value,true,nil
+}
+
+func (b *MemoryBackend)Set(ctx context.Context,key string,value []byte,ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if elem,ok := b.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		b.order.MoveToFront(elem)
+		return nil
+	}
+	b.entries[key] = b.order.PushFront(&memoryEntry{key: key,value: value,expiresAt: expiresAt})
+	for b.order.Len() > b.size {
+		b.remove(b.order.Back())
+		cacheEvictions.Inc()
+	}
+	return nil
+}
+
+func (b *MemoryBackend)Delete(ctx context.Context,keys ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _,key := range keys {
+		if elem,ok := b.entries[key]; ok {
+			b.remove(elem)
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBackend)remove(elem *list.Element) {
+	b.order.Remove(elem)
+	delete(b.entries,elem.Value.(*memoryEntry).key)
+}
+
+// RedisBackend shares entries between replicas through any Redis compatible server.
+type RedisBackend struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func NewRedisBackend(client redis.UniversalClient) *RedisBackend {
+	return &RedisBackend{
+		client: client,
+		prefix: "carzone:cache:",
+	}
+}
+
+func (b *RedisBackend)Get(ctx context.Context,key string) ([]byte,bool,error) {
+	value,err := b.client.Get(ctx,b.prefix + key).Bytes()
+	if errors.Is(err,redis.Nil) {
+		return nil,false,nil
+	}
+	if err != nil {
+		return nil,false,err
+	}
+	return value,true,nil
+}
+
+func (b *RedisBackend)Set(ctx context.Context,key string,value []byte,ttl time.Duration) error {
+	return b.client.Set(ctx,b.prefix + key,value,ttl).Err()
+}
+
+func (b *RedisBackend)Delete(ctx context.Context,keys ...string) error {
+	prefixed := make([]string,len(keys))
+	for i,key := range keys {
+		prefixed[i] = b.prefix + key
+	}
+	return b.client.Del(ctx,prefixed...).Err()
+}
@@ -0,0 +1,131 @@
+// Package cache puts read-through caches in front of the car and engine stores.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	cacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "store_cache_requests_total",
+			Help: "Count of store cache lookups by result",
+		},
+		[]string{"cache", "result"},
+	)
+	cacheEvictions = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "store_cache_evictions_total",
+			Help: "Count of entries evicted from the in-memory store cache",
+		},
+	)
+)
+
+func init(){
+	prometheus.MustRegister(cacheRequests, cacheEvictions)
+}
+
+// Cache is shared by the store decorators, it collapses concurrent misses for
+// the same key into one store call.
+type Cache struct {
+	backend Backend
+	group singleflight.Group
+}
+
+func New(backend Backend) *Cache {
+	return &Cache{
+		backend: backend,
+	}
+}
+
+func carKey(id uuid.UUID) string {
+	return "car:" + id.String()
+}
+
+func engineKey(id uuid.UUID) string {
+	return "engine:" + id.String()
+}
+
+// load returns the entry under key, calling fetch on a miss and caching what it
+// returns for ttl(value). hit reports whether the value came from the cache.
+func load[T any](ctx context.Context,c *Cache,name string,key string,ttl func(T) time.Duration,fetch func(context.Context) (T,error)) (value T,hit bool,err error) {
+	data,ok,err := c.backend.Get(ctx,key)
+	if err != nil {
+		log.Println("Error reading cache: ",err)
+	}
+	if ok && json.Unmarshal(data,&value) == nil {
+		cacheRequests.WithLabelValues(name,"hit").Inc()
+		return value,true,nil
+	}
+	cacheRequests.WithLabelValues(name,"miss").Inc()
+
+	// stores filter on the caller's tenant, so only callers of the same tenant share a fetch
+	flight := key + "@" + auth.TenantID(ctx).UUID.String()
+	shared,err,_ := c.group.Do(flight,func() (interface{},error) {
+		// one caller giving up must not fail the others waiting on it
+		value,err := fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return value,err
+		}
+		if d := ttl(value); d > 0 {
+			c.set(ctx,key,value,d)
+		}
+		return value,nil
+	})
+	if err != nil {
+		var zero T
+		return zero,false,err
+	}
+	return shared.(T),false,nil
+}
+
+func (c *Cache)set(ctx context.Context,key string,value interface{},ttl time.Duration) {
+	data,err := json.Marshal(value)
+	if err != nil {
+		log.Println("Error encoding cache entry: ",err)
+		return
+	}
+	if err := c.backend.Set(context.WithoutCancel(ctx),key,data,ttl); err != nil {
+		log.Println("Error writing cache: ",err)
+	}
+}
+
+func (c *Cache)invalidate(ctx context.Context,keys ...string) {
+	if err := c.backend.Delete(context.WithoutCancel(ctx),keys...); err != nil {
+		log.Println("Error invalidating cache: ",err)
+	}
+}
+
+// Publish drops the entries named by car and engine events. Registered with the
+// outbox relay it also catches changes made outside the decorators, such as a car
+// sold through an order, and with a shared backend changes made on other replicas.
+// Events without ids name nothing cached and are skipped.
+func (c *Cache)Publish(ctx context.Context,event models.Event) error {
+	data,err := json.Marshal(event.Data)
+	if err != nil {
+		return nil
+	}
+	var ids struct {
+		ID uuid.UUID `json:"id"`
+		EngineID uuid.UUID `json:"engine_id"`
+	}
+	if err := json.Unmarshal(data,&ids); err != nil {
+		return nil
+	}
+	switch {
+	case models.MatchEvent("car.*",event.Type) && ids.ID != uuid.Nil:
+		return c.backend.Delete(ctx,carKey(ids.ID))
+	case models.MatchEvent("engine.*",event.Type) && ids.EngineID != uuid.Nil:
+		return c.backend.Delete(ctx,engineKey(ids.EngineID))
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+)
+
+// carStore filters on the caller's tenant the way the postgres store does and
+// counts the reads that reach it. With release set, reads wait for it.
+type carStore struct {
+	store.CarStoreInterface
+	cars map[string]models.Car
+	reads atomic.Int32
+	release chan struct{}
+}
+
+func (s *carStore)GetCarByID(ctx context.Context,id string) (models.Car,error) {
+	s.reads.Add(1)
+	if s.release != nil {
+		<-s.release
+	}
+	car,ok := s.cars[id]
+	if tenantID := auth.TenantID(ctx); !ok || tenantID.Valid && car.TenantID != tenantID.UUID {
+		return models.Car{},models.ErrRecordNotFound
+	}
+	return car,nil
+}
+
+func (s *carStore)UpdateCar(ctx context.Context,id string,ownerID string,carReq *models.CarRequest) (models.Car,error) {
+	car := s.cars[id]
+	car.Name = carReq.Name
+	s.cars[id] = car
+	return car,nil
+}
+
+func (s *carStore)DeleteCar(ctx context.Context,id string,ownerID string) (models.Car,error) {
+	car := s.cars[id]
+	delete(s.cars,id)
+	return car,nil
+}
+
+func (s *carStore)UpdateCarStatus(ctx context.Context,id string,from string,to models.CarStatusChange) (models.Car,error) {
+	car := s.cars[id]
+	car.Status = to.Status
+	s.cars[id] = car
+	return car,nil
+}
+
+type engineStore struct {
+	store.EngineStoreInterface
+}
+
+func (s *engineStore)GetEngineByID(ctx context.Context,id string) (models.Engine,error) {
+	return models.Engine{EngineID: uuid.MustParse(id)},nil
+}
+
+func tenantContext(tenantID uuid.UUID) context.Context {
+	return auth.WithPrincipal(context.Background(),&auth.Principal{UserID: "staff",TenantID: uuid.NullUUID{UUID: tenantID,Valid: true}})
+}
+
+func newCarStore(cars ...models.Car) (*CarStore,*carStore) {
+	next := &carStore{cars: make(map[string]models.Car)}
+	for _,car := range cars {
+		next.cars[car.ID.String()] = car
+	}
+	return NewCarStore(next,&engineStore{},New(NewMemoryBackend(100)),time.Minute),next
+}
+
+func TestCarCacheTenants(t *testing.T) {
+	home := uuid.New()
+	car := models.Car{ID: uuid.New(),TenantID: home,Name: "Demio",Status: models.CarStatusAvailable}
+	cached,next := newCarStore(car)
+	id := car.ID.String()
+
+	for i := 0; i < 2; i++ {
+		if _,err := cached.GetCarByID(tenantContext(home),id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := next.reads.Load(); n != 1 {
+		t.Errorf("store read %d times, want 1",n)
+	}
+	// the car is cached now, another dealership still mustn't see it
+	if _,err := cached.GetCarByID(tenantContext(uuid.New()),id); !errors.Is(err,models.ErrRecordNotFound) {
+		t.Errorf("another dealership's read: %v, want %v",err,models.ErrRecordNotFound)
+	}
+	if _,err := cached.GetCarByID(context.Background(),id); err != nil {
+		t.Errorf("read across dealerships: %v",err)
+	}
+}
+
+func TestCarCacheInvalidation(t *testing.T) {
+	tests := []struct {
+		name string
+		change func(ctx context.Context,s *CarStore,id string) error
+	}{
+		{name: "update",change: func(ctx context.Context,s *CarStore,id string) error {
+			_,err := s.UpdateCar(ctx,id,"",&models.CarRequest{Name: "Axela"})
+			return err
+		}},
+		{name: "delete",change: func(ctx context.Context,s *CarStore,id string) error {
+			_,err := s.DeleteCar(ctx,id,"")
+			return err
+		}},
+		{name: "status",change: func(ctx context.Context,s *CarStore,id string) error {
+			_,err := s.UpdateCarStatus(ctx,id,models.CarStatusAvailable,models.CarStatusChange{Status: models.CarStatusSold})
+			return err
+		}},
+		{name: "event",change: func(ctx context.Context,s *CarStore,id string) error {
+			return s.cache.Publish(ctx,models.NewEvent(models.EventCarUpdated,uuid.Nil,"",map[string]string{"id": id}))
+		}},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			home := uuid.New()
+			car := models.Car{ID: uuid.New(),TenantID: home,Name: "Demio",Status: models.CarStatusAvailable}
+			cached,next := newCarStore(car)
+			ctx := tenantContext(home)
+			id := car.ID.String()
+			if _,err := cached.GetCarByID(ctx,id); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.change(ctx,cached,id); err != nil {
+				t.Fatal(err)
+			}
+			got,err := cached.GetCarByID(ctx,id)
+			if n := next.reads.Load(); n != 2 {
+				t.Fatalf("store read %d times, want the change to force a second read",n)
+			}
+			if want,ok := next.cars[id]; ok && (err != nil || got.Name != want.Name || got.Status != want.Status) {
+				t.Errorf("read %+v, %v after the change, want %+v",got,err,want)
+			}
+		})
+	}
+}
+
+func TestCarCacheCollapsesMisses(t *testing.T) {
+	home := uuid.New()
+	// an expired reservation isn't cached, so every read that misses the flight reaches the store
+	expired := time.Now().Add(-time.Minute)
+	car := models.Car{ID: uuid.New(),TenantID: home,Status: models.CarStatusReserved,ReservedBy: "buyer",ReservedUntil: &expired}
+	cached,next := newCarStore(car)
+	next.release = make(chan struct{})
+
+	var wg sync.WaitGroup
+	errs := make(chan error,10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(){
+			defer wg.Done()
+			_,err := cached.GetCarByID(tenantContext(home),car.ID.String())
+			errs <- err
+		}()
+	}
+	// let every reader join the first one's flight before it returns
+	for next.reads.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := next.reads.Load(); n != 1 {
+		t.Errorf("store read %d times, want 1",n)
+	}
+}
+
+func TestCarCacheFlightsPerTenant(t *testing.T) {
+	home := uuid.New()
+	car := models.Car{ID: uuid.New(),TenantID: home,Status: models.CarStatusAvailable}
+	cached,next := newCarStore(car)
+	next.release = make(chan struct{})
+
+	results := make(chan error,2)
+	for _,tenantID := range []uuid.UUID{uuid.New(),home} {
+		go func(){
+			_,err := cached.GetCarByID(tenantContext(tenantID),car.ID.String())
+			results <- err
+		}()
+	}
+	// both reads reach the store, another dealership's miss is never shared
+	for next.reads.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	close(next.release)
+	var found,notFound int
+	for i := 0; i < 2; i++ {
+		switch err := <-results; {
+		case err == nil:
+			found++
+		case errors.Is(err,models.ErrRecordNotFound):
+			notFound++
+		default:
+			t.Fatal(err)
+		}
+	}
+	if found != 1 || notFound != 1 {
+		t.Errorf("%d found and %d not found, want one of each",found,notFound)
+	}
+}
+
+func TestTTLFor(t *testing.T) {
+	s := &CarStore{ttl: time.Minute}
+	at := func(d time.Duration) *time.Time {
+		until := time.Now().Add(d)
+		return &until
+	}
+	tests := []struct {
+		name string
+		car models.Car
+		min time.Duration
+		max time.Duration
+	}{
+		{name: "available",car: models.Car{Status: models.CarStatusAvailable},min: time.Minute,max: time.Minute},
+		{name: "reservation outlasting the ttl",car: models.Car{Status: models.CarStatusReserved,ReservedUntil: at(time.Hour)},min: time.Minute,max: time.Minute},
+		{name: "reservation ending first",car: models.Car{Status: models.CarStatusReserved,ReservedUntil: at(10 * time.Second)},min: 9 * time.Second,max: 10 * time.Second},
+		{name: "expired reservation",car: models.Car{Status: models.CarStatusReserved,ReservedUntil: at(-time.Second)},min: -2 * time.Second,max: 0},
+		{name: "reservation without an end",car: models.Car{Status: models.CarStatusReserved},min: time.Minute,max: time.Minute},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			if got := s.ttlFor(tt.car); got < tt.min || got > tt.max {
+				t.Errorf("ttlFor = %v, want between %v and %v",got,tt.min,tt.max)
+			}
+		})
+	}
+}
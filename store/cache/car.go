@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// CarStore caches GetCarByID, everything else goes straight to the wrapped store.
+type CarStore struct {
+	next store.CarStoreInterface
+	engines store.EngineStoreInterface
+	cache *Cache
+	ttl time.Duration
+}
+
+// NewCarStore wraps next, engines is used to refresh the engine specs embedded in
+// cached cars and should itself be cached. A ttl of zero disables caching.
+func NewCarStore(next store.CarStoreInterface,engines store.EngineStoreInterface,cache *Cache,ttl time.Duration) *CarStore {
+	return &CarStore{
+		next: next,
+		engines: engines,
+		cache: cache,
+		ttl: ttl,
+	}
+}
+
+func (s *CarStore)GetCarByID(ctx context.Context,id string) (models.Car,error) {
+	tracer := otel.Tracer("CarCache")
+	ctx,span := tracer.Start(ctx, "GetCarByID-Cache")
+	defer span.End()
+
+	carID,err := uuid.Parse(id)
+	if err != nil || s.ttl <= 0 {
+		return s.next.GetCarByID(ctx,id)
+	}
+	car,hit,err := load(ctx,s.cache,"car",carKey(carID),s.ttlFor,func(ctx context.Context) (models.Car,error) {
+		return s.next.GetCarByID(ctx,id)
+	})
+	if err != nil {
+		return models.Car{},err
+	}
+	if tenantID := auth.TenantID(ctx); tenantID.Valid && car.TenantID != tenantID.UUID {
+		return models.Car{},models.ErrRecordNotFound
+	}
+	if hit {
+		engine,err := s.engines.GetEngineByID(ctx,car.Engine.EngineID.String())
+		if err != nil {
+			// deleting an engine cascades to its cars without a car event
+			s.cache.invalidate(ctx,carKey(carID))
+			return s.next.GetCarByID(ctx,id)
+		}
+		car.Engine.Displacement = engine.Displacement
+		car.Engine.NoOfCylinders = engine.NoOfCylinders
+		car.Engine.CarRange = engine.CarRange
+		car.Engine.FuelType = engine.FuelType
+		car.Engine.BatteryCapacityKWh = engine.BatteryCapacityKWh
+		car.Engine.MotorPowerKW = engine.MotorPowerKW
+		car.Engine.ChargingStandard = engine.ChargingStandard
+		car.Engine.Horsepower = engine.Horsepower
+		car.Engine.TorqueNm = engine.TorqueNm
+		car.Engine.CO2GPerKm = engine.CO2GPerKm
+		car.Engine.UpdatedAt = engine.UpdatedAt
+	}
+	return car,nil
+}
+
+// ttlFor keeps a reservation from being served after it has expired.
+func (s *CarStore)ttlFor(car models.Car) time.Duration {
+	if car.Status == models.CarStatusReserved && car.ReservedUntil != nil {
+		if until := time.Until(*car.ReservedUntil); until < s.ttl {
+			return until
+		}
+	}
+	return s.ttl
+}
+
+func (s *CarStore)GetCarsByBrand(ctx context.Context,brand string,isEngine bool,filter models.CarFilter) ([]models.Car,error) {
+	return s.next.GetCarsByBrand(ctx,brand,isEngine,filter)
+}
+
+func (s *CarStore)GetCarsByOwner(ctx context.Context,userID string) ([]models.Car,error) {
+	return s.next.GetCarsByOwner(ctx,userID)
+}
+
+func (s *CarStore)CreateCar(ctx context.Context,carReq *models.CarRequest) (models.Car,error) {
+	return s.next.CreateCar(ctx,carReq)
+}
+
+func (s *CarStore)UpdateCar(ctx context.Context,id string,ownerID string,carReq *models.CarRequest) (models.Car,error) {
+	car,err := s.next.UpdateCar(ctx,id,ownerID,carReq)
+	if err != nil {
+		return models.Car{},err
+	}
+	s.cache.invalidate(ctx,carKey(car.ID))
+	return car,nil
+}
+
+func (s *CarStore)DeleteCar(ctx context.Context,id string,ownerID string) (models.Car,error) {
+	car,err := s.next.DeleteCar(ctx,id,ownerID)
+	if err != nil {
+		return models.Car{},err
+	}
+	s.cache.invalidate(ctx,carKey(car.ID))
+	return car,nil
+}
+
+func (s *CarStore)UpdateCarStatus(ctx context.Context,id string,from string,to models.CarStatusChange) (models.Car,error) {
+	car,err := s.next.UpdateCarStatus(ctx,id,from,to)
+	if err != nil {
+		return models.Car{},err
+	}
+	s.cache.invalidate(ctx,carKey(car.ID))
+	return car,nil
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"go.opentelemetry.io/otel"
+)
+
+// EngineStore caches GetEngineByID, everything else goes straight to the wrapped store.
+type EngineStore struct {
+	next store.EngineStoreInterface
+	cache *Cache
+	ttl time.Duration
+}
+
+// NewEngineStore wraps next, a ttl of zero disables caching.
+func NewEngineStore(next store.EngineStoreInterface,cache *Cache,ttl time.Duration) *EngineStore {
+	return &EngineStore{
+		next: next,
+		cache: cache,
+		ttl: ttl,
+	}
+}
+
+func (s *EngineStore)GetEngineByID(ctx context.Context,id string) (models.Engine,error) {
+	tracer := otel.Tracer("EngineCache")
+	ctx,span := tracer.Start(ctx, "GetEngineByID-Cache")
+	defer span.End()
+
+	engineID,err := uuid.Parse(id)
+	if err != nil || s.ttl <= 0 {
+		return s.next.GetEngineByID(ctx,id)
+	}
+	engine,_,err := load(ctx,s.cache,"engine",engineKey(engineID),func(models.Engine) time.Duration { return s.ttl },func(ctx context.Context) (models.Engine,error) {
+		return s.next.GetEngineByID(ctx,id)
+	})
+	if err != nil {
+		return models.Engine{},err
+	}
+	if tenantID := auth.TenantID(ctx); tenantID.Valid && engine.TenantID != tenantID.UUID {
+		return models.Engine{},models.ErrRecordNotFound
+	}
+	return engine,nil
+}
+
+// GetEnginesByIDs serves what it can from the cache and loads the rest in one call.
+func (s *EngineStore)GetEnginesByIDs(ctx context.Context,ids []string) ([]models.Engine,error) {
+	tracer := otel.Tracer("EngineCache")
+	ctx,span := tracer.Start(ctx, "GetEnginesByIDs-Cache")
+	defer span.End()
+
+	if s.ttl <= 0 {
+		return s.next.GetEnginesByIDs(ctx,ids)
+	}
+	tenantID := auth.TenantID(ctx)
+	engines := []models.Engine{}
+	missing := []string{}
+	for _,id := range ids {
+		engineID,err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+		engine,ok := s.cached(ctx,engineKey(engineID))
+		if !ok {
+			missing = append(missing,id)
+			continue
+		}
+		if !tenantID.Valid || engine.TenantID == tenantID.UUID {
+			engines = append(engines,engine)
+		}
+	}
+	if len(missing) == 0 {
+		return engines,nil
+	}
+	loaded,err := s.next.GetEnginesByIDs(ctx,missing)
+	if err != nil {
+		return nil,err
+	}
+	for _,engine := range loaded {
+		s.cache.set(ctx,engineKey(engine.EngineID),engine,s.ttl)
+	}
+	return append(engines,loaded...),nil
+}
+
+func (s *EngineStore)GetEngines(ctx context.Context,limit,offset int) ([]models.Engine,error) {
+	return s.next.GetEngines(ctx,limit,offset)
+}
+
+func (s *EngineStore)cached(ctx context.Context,key string) (models.Engine,bool) {
+	var engine models.Engine
+	data,ok,err := s.cache.backend.Get(ctx,key)
+	if err != nil {
+		log.Println("Error reading cache: ",err)
+	}
+	if !ok || json.Unmarshal(data,&engine) != nil {
+		cacheRequests.WithLabelValues("engine","miss").Inc()
+		return models.Engine{},false
+	}
+	cacheRequests.WithLabelValues("engine","hit").Inc()
+	return engine,true
+}
+
+func (s *EngineStore)CreateEngine(ctx context.Context,engineReq *models.EngineRequest) (models.Engine,error) {
+	return s.next.CreateEngine(ctx,engineReq)
+}
+
+func (s *EngineStore)UpdateEngine(ctx context.Context,id string,engineReq *models.EngineRequest) (models.Engine,error) {
+	engine,err := s.next.UpdateEngine(ctx,id,engineReq)
+	if err != nil {
+		return models.Engine{},err
+	}
+	s.cache.invalidate(ctx,engineKey(engine.EngineID))
+	return engine,nil
+}
+
+func (s *EngineStore)DeleteEngine(ctx context.Context,id string) (models.Engine,error) {
+	engine,err := s.next.DeleteEngine(ctx,id)
+	if err != nil {
+		return models.Engine{},err
+	}
+	s.cache.invalidate(ctx,engineKey(engine.EngineID))
+	return engine,nil
+}
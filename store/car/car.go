@@ -6,7 +6,10 @@ import (
 	"errors"
 	"time"
 
+	"github.com/iangechuki/go_carzone/auth"
 	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"github.com/lib/pq"
 	"go.opentelemetry.io/otel"
 
 	"github.com/google/uuid"
@@ -23,20 +26,42 @@ func New(db *sql.DB) *Store {
 	}
 }
 
-func (s *Store) CreateCar(ctx context.Context,carReq *models.CarRequest) (models.Car,error) {
+// a reservation past its expiry reads as available, so nothing has to run to release it
+const reservationExpired = `(c.status = 'reserved' AND c.reserved_until <= NOW())`
+
+const carStatusExpr = `CASE WHEN ` + reservationExpired + ` THEN 'available' ELSE c.status END`
+
+// a lapsed reservation changes the car when it lapses, so updated_at, and
+// with it Last-Modified, moves up to the expiry
+const carUpdatedAtExpr = `GREATEST(c.updated_at, CASE WHEN ` + reservationExpired + ` THEN c.reserved_until END)`
+
+const carStatusColumns = carStatusExpr +
+	`, CASE WHEN ` + reservationExpired + ` THEN '' ELSE c.reserved_by END` +
+	`, CASE WHEN ` + reservationExpired + ` THEN NULL ELSE c.reserved_until END` +
+	`, c.sold_at`
+
+// the listing details, a car without a VIN reads as an empty one
+const carDetailColumns = `c.model, COALESCE(c.vin, ''), c.odometer_km, c.transmission, c.drivetrain, c.colour, c.body_type, c.condition, c.features`
+
+// the specs of the joined engine
+const engineSpecColumns = `e.displacement, e.no_of_cylinders, e.car_range, e.fuel_type, e.battery_capacity_kwh, e.motor_power_kw, e.charging_standard, e.horsepower, e.torque_nm, e.co2_g_per_km`
+
+// fuelMatches lets a car run on its engine's powertrain, engines recorded
+// without one take any fuel.
+func fuelMatches(engineFuelType,fuelType string) bool {
+	return engineFuelType == "" || engineFuelType == fuelType
+}
+
+func (s *Store) CreateCar(ctx context.Context,carReq *models.CarRequest) (created models.Car,err error) {
 	tracer := otel.Tracer("CarStore")
 	ctx,span := tracer.Start(ctx, "CreateCar-Store")
 	defer span.End()
 
-	var createdCar models.Car
-	var engineID uuid.UUID
-	err := s.db.QueryRowContext(ctx,"SELECT id FROM engine WHERE id = $1",carReq.Engine.EngineID).Scan(&engineID)
-	if err != nil {
-		if errors.Is(err,sql.ErrNoRows) {
-			return models.Car{},errors.New("engine not found")
-		}
-		return models.Car{},err
+	tenantID := auth.TenantID(ctx)
+	if !tenantID.Valid {
+		return models.Car{},models.ErrTenantRequired
 	}
+	var createdCar models.Car
 	carID := uuid.New()
 
 	createdAt := time.Now()
@@ -44,12 +69,24 @@ func (s *Store) CreateCar(ctx context.Context,carReq *models.CarRequest) (models
 
 	newCar := models.Car{
 		ID: carID,
+		TenantID: tenantID.UUID,
+		CreatedBy: auth.UserID(ctx),
 		Name: carReq.Name,
 		Year: carReq.Year,
 		Brand: carReq.Brand,
+		Model: carReq.Model,
 		FuelType: carReq.FuelType,
 		Engine: carReq.Engine,
 		Price: carReq.Price,
+		VIN: carReq.VIN,
+		OdometerKm: carReq.OdometerKm,
+		Transmission: carReq.Transmission,
+		Drivetrain: carReq.Drivetrain,
+		Colour: carReq.Colour,
+		BodyType: carReq.BodyType,
+		Condition: carReq.Condition,
+		Features: carReq.Features,
+		Status: carReq.Status,
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
 	}
@@ -60,16 +97,47 @@ func (s *Store) CreateCar(ctx context.Context,carReq *models.CarRequest) (models
 	defer func () {
 		if err != nil {
 			tx.Rollback()
+			return
 		}
 		err = tx.Commit()
 	}()
-	query := `INSERT INTO car (id, name, year, brand, fuel_type, engine_id, price, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
-	err = tx.QueryRowContext(ctx, query, newCar.ID, newCar.Name, newCar.Year, newCar.Brand, newCar.FuelType, newCar.Engine.EngineID, newCar.Price, newCar.CreatedAt, newCar.UpdatedAt).Scan(&createdCar.ID)
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Car{},err
+	}
+	// FOR SHARE keeps the engine's powertrain from changing until the car is in
+	var engineFuelType string
+	err = tx.QueryRowContext(ctx,"SELECT fuel_type FROM engine WHERE id = $1 AND tenant_id = $2 FOR SHARE",carReq.Engine.EngineID,tenantID).Scan(&engineFuelType)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			err = models.ErrEngineNotFound
+		}
+		return models.Car{},err
+	}
+	if !fuelMatches(engineFuelType,carReq.FuelType) {
+		err = models.ErrEngineFuelMismatch
+		return models.Car{},err
+	}
+	if newCar.Status == "" {
+		newCar.Status = models.CarStatusAvailable
+	}
+	newCar.Features = features(newCar.Features)
+	query := `INSERT INTO car (id, tenant_id, created_by, name, year, brand, fuel_type, engine_id, price, status, created_at, updated_at,
+		vin, odometer_km, transmission, drivetrain, colour, body_type, condition, features, model)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, ''), $14, $15, $16, $17, $18, $19, $20, $21) RETURNING id`
+	err = tx.QueryRowContext(ctx, query, newCar.ID, newCar.TenantID, newCar.CreatedBy, newCar.Name, newCar.Year, newCar.Brand, newCar.FuelType, newCar.Engine.EngineID, newCar.Price, newCar.Status, newCar.CreatedAt, newCar.UpdatedAt,
+		newCar.VIN, newCar.OdometerKm, newCar.Transmission, newCar.Drivetrain, newCar.Colour, newCar.BodyType, newCar.Condition, pq.Array(newCar.Features), newCar.Model).Scan(&createdCar.ID)
 
 	if err != nil {
+		if isVINTaken(err) {
+			err = models.ErrVINTaken
+		}
+		return models.Car{},err
+	}
+	newCar.ID = createdCar.ID
+	if err = store.RecordEvent(ctx,tx,models.EventCarCreated,newCar.TenantID,newCar); err != nil {
 		return models.Car{},err
 	}
-	return createdCar,nil
+	return newCar,nil
 }
 func (s *Store)GetCarByID(ctx context.Context,id string) (models.Car,error) {
 	tracer := otel.Tracer("CarStore")
@@ -78,24 +146,52 @@ func (s *Store)GetCarByID(ctx context.Context,id string) (models.Car,error) {
 
 	var car models.Car
 
-	query := `SELECT c.id, c.name, c.year, c.brand, c.fuel_type,c.engine_id,c.price,
-	c.created_at, c.updated_at,e.displacement, e.no_of_cylinders, e.car_range
+	query := `SELECT c.id, c.tenant_id, c.created_by, c.name, c.year, c.brand, c.fuel_type,c.engine_id,c.price,
+	`+carDetailColumns+`, `+carStatusColumns+`, c.created_at, `+carUpdatedAtExpr+`, `+engineSpecColumns+`, e.updated_at
 	FROM car c
-	LEFT JOIN engine e ON c.engine_id = e.id WHERE c.id = $1`
+	LEFT JOIN engine e ON c.engine_id = e.id WHERE c.id = $1 AND ($2::uuid IS NULL OR c.tenant_id = $2)`
 
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
+	tx,err := store.ReadTx(ctx,s.db)
+	if err != nil {
+		return models.Car{},err
+	}
+	defer tx.Rollback()
+	err = tx.QueryRowContext(ctx, query, id, auth.TenantID(ctx)).Scan(
 		&car.ID,
+		&car.TenantID,
+		&car.CreatedBy,
 		&car.Name,
 		&car.Year,
 		&car.Brand,
 		&car.FuelType,
 		&car.Engine.EngineID,
 		&car.Price,
+		&car.Model,
+		&car.VIN,
+		&car.OdometerKm,
+		&car.Transmission,
+		&car.Drivetrain,
+		&car.Colour,
+		&car.BodyType,
+		&car.Condition,
+		pq.Array(&car.Features),
+		&car.Status,
+		&car.ReservedBy,
+		&car.ReservedUntil,
+		&car.SoldAt,
 		&car.CreatedAt,
 		&car.UpdatedAt,
 		&car.Engine.Displacement,
 		&car.Engine.NoOfCylinders,
 		&car.Engine.CarRange,
+		&car.Engine.FuelType,
+		&car.Engine.BatteryCapacityKWh,
+		&car.Engine.MotorPowerKW,
+		&car.Engine.ChargingStandard,
+		&car.Engine.Horsepower,
+		&car.Engine.TorqueNm,
+		&car.Engine.CO2GPerKm,
+		&car.Engine.UpdatedAt,
 	)
 	if err != nil {
 		switch err {
@@ -105,9 +201,10 @@ func (s *Store)GetCarByID(ctx context.Context,id string) (models.Car,error) {
 			return models.Car{},err
 		}
 	}
+	car.Engine.TenantID = car.TenantID
 	return car,nil
 }
-func (s *Store)GetCarsByBrand(ctx context.Context,brand string,isEngine bool) ([]models.Car,error) {
+func (s *Store)GetCarsByBrand(ctx context.Context,brand string,isEngine bool,filter models.CarFilter) ([]models.Car,error) {
 	
 	tracer := otel.Tracer("CarStore")
 	ctx,span := tracer.Start(ctx, "GetCarsByBrand-Store")
@@ -115,15 +212,30 @@ func (s *Store)GetCarsByBrand(ctx context.Context,brand string,isEngine bool) ([
 
 	var cars []models.Car
 	var query string
+	// empty filter fields match every car
+	where := `($1 = '' OR c.brand = $1) AND ($2::uuid IS NULL OR c.tenant_id = $2)
+		AND (COALESCE(cardinality($3::text[]), 0) = 0 OR `+carStatusExpr+` = ANY($3))
+		AND ($6 = '' OR c.vin = $6) AND ($7 = '' OR c.transmission = $7) AND ($8 = '' OR c.drivetrain = $8)
+		AND ($9 = '' OR c.body_type = $9) AND ($10 = '' OR c.condition = $10) AND ($11 = '' OR lower(c.colour) = lower($11))
+		AND ($12 = 0 OR c.odometer_km <= $12) AND (COALESCE(cardinality($13::text[]), 0) = 0 OR c.features @> $13)`
 	if isEngine {
-		query = `SELECT c.id, c.name, c.year, c.brand, c.fuel_type,c.engine_id,c.price,
-		c.created_at, c.updated_at, e.id, e.displacement, e.no_of_cylinders, e.car_range
+		query = `SELECT c.id, c.tenant_id, c.created_by, c.name, c.year, c.brand, c.fuel_type,c.engine_id,c.price,
+		`+carDetailColumns+`, `+carStatusColumns+`, c.created_at, `+carUpdatedAtExpr+`, `+engineSpecColumns+`
 		FROM car c
-		LEFT JOIN engine e ON c.engine_id = e.id WHERE c.brand = $1`
+		LEFT JOIN engine e ON c.engine_id = e.id WHERE `+where+`
+		ORDER BY c.created_at DESC, c.id LIMIT NULLIF($4, 0) OFFSET $5`
 	} else {
-		query = `SELECT id, name, year, brand, fuel_type, engine_id, price, created_at, updated_at FROM car WHERE brand = $1`
+		query = `SELECT c.id, c.tenant_id, c.created_by, c.name, c.year, c.brand, c.fuel_type, c.engine_id, c.price, `+carDetailColumns+`, `+carStatusColumns+`, c.created_at, `+carUpdatedAtExpr+`
+		FROM car c WHERE `+where+`
+		ORDER BY c.created_at DESC, c.id LIMIT NULLIF($4, 0) OFFSET $5`
 	}
-	rows,err := s.db.QueryContext(ctx, query, brand)
+	tx,err := store.ReadTx(ctx,s.db)
+	if err != nil {
+		return []models.Car{},err
+	}
+	defer tx.Rollback()
+	rows,err := tx.QueryContext(ctx, query, brand, auth.TenantID(ctx), pq.Array(filter.Statuses), filter.Limit, filter.Offset,
+		filter.VIN, filter.Transmission, filter.Drivetrain, filter.BodyType, filter.Condition, filter.Colour, filter.MaxOdometerKm, pq.Array(filter.Features))
 	if err != nil {
 		return []models.Car{},err
 	}
@@ -131,34 +243,70 @@ func (s *Store)GetCarsByBrand(ctx context.Context,brand string,isEngine bool) ([
 	for rows.Next() {
 		var car models.Car
 		if isEngine {
-			var engine models.Engine
 			err := rows.Scan(
 				&car.ID,
+				&car.TenantID,
+				&car.CreatedBy,
 				&car.Name,
 				&car.Year,
 				&car.Brand,
 				&car.FuelType,
 				&car.Engine.EngineID,
 				&car.Price,
+				&car.Model,
+				&car.VIN,
+				&car.OdometerKm,
+				&car.Transmission,
+				&car.Drivetrain,
+				&car.Colour,
+				&car.BodyType,
+				&car.Condition,
+				pq.Array(&car.Features),
+				&car.Status,
+				&car.ReservedBy,
+				&car.ReservedUntil,
+				&car.SoldAt,
 				&car.CreatedAt,
 				&car.UpdatedAt,
 				&car.Engine.Displacement,
 				&car.Engine.NoOfCylinders,
 				&car.Engine.CarRange,
+				&car.Engine.FuelType,
+				&car.Engine.BatteryCapacityKWh,
+				&car.Engine.MotorPowerKW,
+				&car.Engine.ChargingStandard,
+				&car.Engine.Horsepower,
+				&car.Engine.TorqueNm,
+				&car.Engine.CO2GPerKm,
 			)
 			if err != nil {
 				return []models.Car{},err
 			}
-			car.Engine = engine
+			car.Engine.TenantID = car.TenantID
 		} else {
 			err := rows.Scan(
 				&car.ID,
+				&car.TenantID,
+				&car.CreatedBy,
 				&car.Name,
 				&car.Year,
 				&car.Brand,
 				&car.FuelType,
 				&car.Engine.EngineID,
 				&car.Price,
+				&car.Model,
+				&car.VIN,
+				&car.OdometerKm,
+				&car.Transmission,
+				&car.Drivetrain,
+				&car.Colour,
+				&car.BodyType,
+				&car.Condition,
+				pq.Array(&car.Features),
+				&car.Status,
+				&car.ReservedBy,
+				&car.ReservedUntil,
+				&car.SoldAt,
 				&car.CreatedAt,
 				&car.UpdatedAt,
 			)
@@ -173,7 +321,77 @@ func (s *Store)GetCarsByBrand(ctx context.Context,brand string,isEngine bool) ([
 	}
 	return cars,nil
 }
-func (s *Store)UpdateCar(ctx context.Context,id string,carReq *models.CarRequest) (models.Car,error) {
+func (s *Store)GetCarsByOwner(ctx context.Context,userID string) ([]models.Car,error) {
+	tracer := otel.Tracer("CarStore")
+	ctx,span := tracer.Start(ctx, "GetCarsByOwner-Store")
+	defer span.End()
+
+	query := `SELECT c.id, c.tenant_id, c.created_by, c.name, c.year, c.brand, c.fuel_type,c.engine_id,c.price,
+	`+carDetailColumns+`, `+carStatusColumns+`, c.created_at, `+carUpdatedAtExpr+`, `+engineSpecColumns+`
+	FROM car c
+	LEFT JOIN engine e ON c.engine_id = e.id WHERE c.created_by = $1 AND ($2::uuid IS NULL OR c.tenant_id = $2)
+	ORDER BY c.created_at DESC`
+	tx,err := store.ReadTx(ctx,s.db)
+	if err != nil {
+		return nil,err
+	}
+	defer tx.Rollback()
+	rows,err := tx.QueryContext(ctx, query, userID, auth.TenantID(ctx))
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	cars := []models.Car{}
+	for rows.Next() {
+		var car models.Car
+		err := rows.Scan(
+			&car.ID,
+			&car.TenantID,
+			&car.CreatedBy,
+			&car.Name,
+			&car.Year,
+			&car.Brand,
+			&car.FuelType,
+			&car.Engine.EngineID,
+			&car.Price,
+			&car.Model,
+			&car.VIN,
+			&car.OdometerKm,
+			&car.Transmission,
+			&car.Drivetrain,
+			&car.Colour,
+			&car.BodyType,
+			&car.Condition,
+			pq.Array(&car.Features),
+			&car.Status,
+			&car.ReservedBy,
+			&car.ReservedUntil,
+			&car.SoldAt,
+			&car.CreatedAt,
+			&car.UpdatedAt,
+			&car.Engine.Displacement,
+			&car.Engine.NoOfCylinders,
+			&car.Engine.CarRange,
+			&car.Engine.FuelType,
+			&car.Engine.BatteryCapacityKWh,
+			&car.Engine.MotorPowerKW,
+			&car.Engine.ChargingStandard,
+			&car.Engine.Horsepower,
+			&car.Engine.TorqueNm,
+			&car.Engine.CO2GPerKm,
+		)
+		if err != nil {
+			return nil,err
+		}
+		car.Engine.TenantID = car.TenantID
+		cars = append(cars,car)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	return cars,nil
+}
+func (s *Store)UpdateCar(ctx context.Context,id string,ownerID string,carReq *models.CarRequest) (car models.Car,err error) {
 	tracer := otel.Tracer("CarStore")
 	ctx,span := tracer.Start(ctx, "UpdateCar-Store")
 	defer span.End()
@@ -187,32 +405,77 @@ func (s *Store)UpdateCar(ctx context.Context,id string,carReq *models.CarRequest
 	 defer func() {
 		if err != nil {
 			tx.Rollback()
+			return
 		}
 		err = tx.Commit()
 	 }()
+	 if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Car{},err
+	 }
+	 var engineFuelType string
+	 err = tx.QueryRowContext(ctx,"SELECT fuel_type FROM engine WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2) FOR SHARE",carReq.Engine.EngineID,auth.TenantID(ctx)).Scan(&engineFuelType)
+	 if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			err = models.ErrEngineNotFound
+		}
+		return models.Car{},err
+	 }
+	 if !fuelMatches(engineFuelType,carReq.FuelType) {
+		err = models.ErrEngineFuelMismatch
+		return models.Car{},err
+	 }
+	 // the new engine has to belong to the same dealership as the car
 	 query := `
-		UPDATE car
-		SET name = $2, year = $3, brand = $4, fuel_type = $5, engine_id = $6, price = $7, updated_at = $8
-		WHERE id = $1
-		RETURNING id, name, year, brand, fuel_type, engine_id, price, created_at, updated_at
+		UPDATE car c
+		SET name = $2, year = $3, brand = $4, fuel_type = $5, engine_id = $6, price = $7, updated_at = $8,
+			vin = NULLIF($10, ''), odometer_km = $11, transmission = $12, drivetrain = $13, colour = $14, body_type = $15, condition = $16, features = $17, model = $18
+		WHERE c.id = $1 AND ($9::uuid IS NULL OR c.tenant_id = $9) AND ($19 = '' OR c.created_by = $19)
+		AND EXISTS (SELECT 1 FROM engine e WHERE e.id = $6 AND e.tenant_id = c.tenant_id)
+		RETURNING c.id, c.tenant_id, c.created_by, c.name, c.year, c.brand, c.fuel_type, c.engine_id, c.price, `+carDetailColumns+`, `+carStatusColumns+`, c.created_at, `+carUpdatedAtExpr+`
 	 `
-	 err = tx.QueryRowContext(ctx, query, id, carReq.Name, carReq.Year, carReq.Brand, carReq.FuelType, carReq.Engine.EngineID, carReq.Price, time.Now()).Scan(
+	 err = tx.QueryRowContext(ctx, query, id, carReq.Name, carReq.Year, carReq.Brand, carReq.FuelType, carReq.Engine.EngineID, carReq.Price, time.Now(), auth.TenantID(ctx),
+		carReq.VIN, carReq.OdometerKm, carReq.Transmission, carReq.Drivetrain, carReq.Colour, carReq.BodyType, carReq.Condition, pq.Array(features(carReq.Features)), carReq.Model, ownerID).Scan(
 		&updatedCar.ID,
+		&updatedCar.TenantID,
+		&updatedCar.CreatedBy,
 		&updatedCar.Name,
 		&updatedCar.Year,
 		&updatedCar.Brand,
 		&updatedCar.FuelType,
 		&updatedCar.Engine.EngineID,
 		&updatedCar.Price,
+		&updatedCar.Model,
+		&updatedCar.VIN,
+		&updatedCar.OdometerKm,
+		&updatedCar.Transmission,
+		&updatedCar.Drivetrain,
+		&updatedCar.Colour,
+		&updatedCar.BodyType,
+		&updatedCar.Condition,
+		pq.Array(&updatedCar.Features),
+		&updatedCar.Status,
+		&updatedCar.ReservedBy,
+		&updatedCar.ReservedUntil,
+		&updatedCar.SoldAt,
 		&updatedCar.CreatedAt,
 		&updatedCar.UpdatedAt,
 	 )
 	 if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			err = notOwned(ctx,tx,id,ownerID)
+			return models.Car{},err
+		}
+		if isVINTaken(err) {
+			err = models.ErrVINTaken
+		}
+		return models.Car{},err
+	 }
+	 if err = store.RecordEvent(ctx,tx,models.EventCarUpdated,updatedCar.TenantID,updatedCar); err != nil {
 		return models.Car{},err
 	 }
 	 return updatedCar, nil
 }
-func (s *Store)DeleteCar(ctx context.Context,id string) (models.Car,error) {
+func (s *Store)DeleteCar(ctx context.Context,id string,ownerID string) (car models.Car,err error) {
 	tracer := otel.Tracer("CarStore")
 	ctx,span := tracer.Start(ctx, "DeleteCar-Store")
 	defer span.End()
@@ -225,17 +488,36 @@ func (s *Store)DeleteCar(ctx context.Context,id string) (models.Car,error) {
 	defer func(){
 		if err != nil {
 			tx.Rollback()
+			return
 		}
 		err = tx.Commit()
 	}()
-	err = tx.QueryRowContext(ctx,"SELECT id,name,year,brand,fuel_type,engine_id,price,created_at,updated_at FROM car WHERE id = $1 FOR UPDATE",id).Scan(
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Car{},err
+	}
+	err = tx.QueryRowContext(ctx,"SELECT c.id,c.tenant_id,c.created_by,c.name,c.year,c.brand,c.fuel_type,c.engine_id,c.price,"+carDetailColumns+","+carStatusColumns+",c.created_at,"+carUpdatedAtExpr+" FROM car c WHERE c.id = $1 AND ($2::uuid IS NULL OR c.tenant_id = $2) AND ($3 = '' OR c.created_by = $3) FOR UPDATE",id,auth.TenantID(ctx),ownerID).Scan(
 		&deletedCar.ID,
+		&deletedCar.TenantID,
+		&deletedCar.CreatedBy,
 		&deletedCar.Name,
 		&deletedCar.Year,
 		&deletedCar.Brand,
 		&deletedCar.FuelType,
 		&deletedCar.Engine.EngineID,
 		&deletedCar.Price,
+		&deletedCar.Model,
+		&deletedCar.VIN,
+		&deletedCar.OdometerKm,
+		&deletedCar.Transmission,
+		&deletedCar.Drivetrain,
+		&deletedCar.Colour,
+		&deletedCar.BodyType,
+		&deletedCar.Condition,
+		pq.Array(&deletedCar.Features),
+		&deletedCar.Status,
+		&deletedCar.ReservedBy,
+		&deletedCar.ReservedUntil,
+		&deletedCar.SoldAt,
 		&deletedCar.CreatedAt,
 		&deletedCar.UpdatedAt,
 	)
@@ -243,11 +525,12 @@ func (s *Store)DeleteCar(ctx context.Context,id string) (models.Car,error) {
 
 		switch err {
 		case sql.ErrNoRows:
-			return models.Car{},errors.New("car not found")
+			err = notOwned(ctx,tx,id,ownerID)
+			return models.Car{},err
 		default:
 			return models.Car{},err
 		}}
-	result,err := tx.ExecContext(ctx,"DELETE FROM car WHERE id = $1",id)
+	result,err := tx.ExecContext(ctx,"DELETE FROM car WHERE id = $1 AND ($2 = '' OR created_by = $2)",id,ownerID)
 	if err != nil {
 		return models.Car{},err
 	}
@@ -258,5 +541,103 @@ func (s *Store)DeleteCar(ctx context.Context,id string) (models.Car,error) {
 	if rowsAffected == 0 {
 		return models.Car{},errors.New("no rows deleted")
 	}
+	if err = store.RecordEvent(ctx,tx,models.EventCarDeleted,deletedCar.TenantID,deletedCar); err != nil {
+		return models.Car{},err
+	}
 	return deletedCar,nil
-}
\ No newline at end of file
+}
+// notOwned explains why a change scoped to ownerID matched no car, the car
+// is either someone else's or not there at all.
+func notOwned(ctx context.Context,tx *sql.Tx,id string,ownerID string) error {
+	if ownerID == "" {
+		return models.ErrRecordNotFound
+	}
+	var othersCar bool
+	err := tx.QueryRowContext(ctx,"SELECT EXISTS (SELECT 1 FROM car WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2) AND created_by <> $3)",id,auth.TenantID(ctx),ownerID).Scan(&othersCar)
+	if err != nil {
+		return err
+	}
+	if othersCar {
+		return models.ErrForbidden
+	}
+	return models.ErrRecordNotFound
+}
+// UpdateCarStatus moves a car from status from to status to, failing with
+// models.ErrStatusConflict when another request changed the status first.
+func (s *Store)UpdateCarStatus(ctx context.Context,id string,from string,to models.CarStatusChange) (car models.Car,err error) {
+	tracer := otel.Tracer("CarStore")
+	ctx,span := tracer.Start(ctx, "UpdateCarStatus-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Car{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Car{},err
+	}
+	now := time.Now()
+	query := `
+		UPDATE car c
+		SET status = $3, reserved_by = $4, reserved_until = $5,
+			sold_at = CASE WHEN $3 = 'sold' THEN $6 ELSE c.sold_at END, updated_at = $6
+		WHERE c.id = $1 AND ($7::uuid IS NULL OR c.tenant_id = $7) AND `+carStatusExpr+` = $2
+		RETURNING c.id, c.tenant_id, c.created_by, c.name, c.year, c.brand, c.fuel_type, c.engine_id, c.price, `+carDetailColumns+`, `+carStatusColumns+`, c.created_at, `+carUpdatedAtExpr+`
+	`
+	err = tx.QueryRowContext(ctx,query,id,from,to.Status,to.ReservedBy,to.ReservedUntil,now,auth.TenantID(ctx)).Scan(
+		&car.ID,
+		&car.TenantID,
+		&car.CreatedBy,
+		&car.Name,
+		&car.Year,
+		&car.Brand,
+		&car.FuelType,
+		&car.Engine.EngineID,
+		&car.Price,
+		&car.Model,
+		&car.VIN,
+		&car.OdometerKm,
+		&car.Transmission,
+		&car.Drivetrain,
+		&car.Colour,
+		&car.BodyType,
+		&car.Condition,
+		pq.Array(&car.Features),
+		&car.Status,
+		&car.ReservedBy,
+		&car.ReservedUntil,
+		&car.SoldAt,
+		&car.CreatedAt,
+		&car.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.Car{},models.ErrStatusConflict
+		}
+		return models.Car{},err
+	}
+	if err = store.RecordEvent(ctx,tx,models.EventCarUpdated,car.TenantID,car); err != nil {
+		return models.Car{},err
+	}
+	return car,nil
+}
+
+// features keeps a car without features at '{}' rather than NULL.
+func features(features []string) []string {
+	if features == nil {
+		return []string{}
+	}
+	return features
+}
+
+func isVINTaken(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err,&pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "car_tenant_vin_key"
+}
@@ -0,0 +1,440 @@
+package catalogue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// Store keeps the brand and model catalogue, which every dealership shares.
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx so reads can run inside a transaction.
+type queryer interface {
+	QueryContext(ctx context.Context,query string,args ...interface{}) (*sql.Rows,error)
+}
+
+func (s *Store)ListBrands(ctx context.Context) ([]models.Brand,error) {
+	tracer := otel.Tracer("CatalogueStore")
+	ctx,span := tracer.Start(ctx, "ListBrands-Store")
+	defer span.End()
+
+	return loadBrands(ctx,s.db,"")
+}
+
+func (s *Store)GetBrandByID(ctx context.Context,id string) (models.Brand,error) {
+	tracer := otel.Tracer("CatalogueStore")
+	ctx,span := tracer.Start(ctx, "GetBrandByID-Store")
+	defer span.End()
+
+	return loadBrand(ctx,s.db,id)
+}
+
+func (s *Store)CreateBrand(ctx context.Context,brandReq *models.BrandRequest) (brand models.Brand,err error) {
+	tracer := otel.Tracer("CatalogueStore")
+	ctx,span := tracer.Start(ctx, "CreateBrand-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Brand{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	id := uuid.New()
+	now := time.Now()
+	_,err = tx.ExecContext(ctx,"INSERT INTO brand (id, name, created_at, updated_at) VALUES ($1, $2, $3, $3)",id,brandReq.Name,now)
+	if err != nil {
+		return models.Brand{},conflict(err)
+	}
+	if err = insertBrandAliases(ctx,tx,id,brandReq); err != nil {
+		return models.Brand{},err
+	}
+	return loadBrand(ctx,tx,id.String())
+}
+
+// UpdateBrand replaces the brand's name and aliases. Cars listed under the
+// old name are renamed with it, without car.updated events since the
+// listing itself didn't change.
+func (s *Store)UpdateBrand(ctx context.Context,id string,brandReq *models.BrandRequest) (brand models.Brand,err error) {
+	tracer := otel.Tracer("CatalogueStore")
+	ctx,span := tracer.Start(ctx, "UpdateBrand-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Brand{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	// renames and in use checks span every dealership's cars
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Brand{},err
+	}
+	var brandID uuid.UUID
+	var oldName string
+	err = tx.QueryRowContext(ctx,"SELECT id, name FROM brand WHERE id = $1 FOR UPDATE",id).Scan(&brandID,&oldName)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.Brand{},models.ErrRecordNotFound
+		}
+		return models.Brand{},err
+	}
+	now := time.Now()
+	if _,err = tx.ExecContext(ctx,"UPDATE brand SET name = $2, updated_at = $3 WHERE id = $1",brandID,brandReq.Name,now); err != nil {
+		return models.Brand{},conflict(err)
+	}
+	if _,err = tx.ExecContext(ctx,"DELETE FROM brand_alias WHERE brand_id = $1",brandID); err != nil {
+		return models.Brand{},err
+	}
+	if err = insertBrandAliases(ctx,tx,brandID,brandReq); err != nil {
+		return models.Brand{},err
+	}
+	if oldName != brandReq.Name {
+		if _,err = tx.ExecContext(ctx,"UPDATE car SET brand = $2, updated_at = $3 WHERE brand = $1",oldName,brandReq.Name,now); err != nil {
+			return models.Brand{},err
+		}
+	}
+	return loadBrand(ctx,tx,brandID.String())
+}
+
+// DeleteBrand fails with models.ErrCatalogueEntryInUse while cars are listed under the brand.
+func (s *Store)DeleteBrand(ctx context.Context,id string) (brand models.Brand,err error) {
+	tracer := otel.Tracer("CatalogueStore")
+	ctx,span := tracer.Start(ctx, "DeleteBrand-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Brand{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	// renames and in use checks span every dealership's cars
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Brand{},err
+	}
+	if _,err = tx.ExecContext(ctx,"SELECT 1 FROM brand WHERE id = $1 FOR UPDATE",id); err != nil {
+		return models.Brand{},err
+	}
+	brand,err = loadBrand(ctx,tx,id)
+	if err != nil {
+		return models.Brand{},err
+	}
+	var inUse bool
+	if err = tx.QueryRowContext(ctx,"SELECT EXISTS (SELECT 1 FROM car WHERE brand = $1)",brand.Name).Scan(&inUse); err != nil {
+		return models.Brand{},err
+	}
+	if inUse {
+		return models.Brand{},models.ErrCatalogueEntryInUse
+	}
+	if _,err = tx.ExecContext(ctx,"DELETE FROM brand WHERE id = $1",brand.ID); err != nil {
+		return models.Brand{},err
+	}
+	return brand,nil
+}
+
+func (s *Store)CreateModel(ctx context.Context,brandID string,modelReq *models.CarModelRequest) (model models.CarModel,err error) {
+	tracer := otel.Tracer("CatalogueStore")
+	ctx,span := tracer.Start(ctx, "CreateModel-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.CarModel{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	var brand uuid.UUID
+	if err = tx.QueryRowContext(ctx,"SELECT id FROM brand WHERE id = $1 FOR UPDATE",brandID).Scan(&brand); err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.CarModel{},models.ErrRecordNotFound
+		}
+		return models.CarModel{},err
+	}
+	id := uuid.New()
+	now := time.Now()
+	_,err = tx.ExecContext(ctx,"INSERT INTO car_model (id, brand_id, name, created_at, updated_at) VALUES ($1, $2, $3, $4, $4)",id,brand,modelReq.Name,now)
+	if err != nil {
+		return models.CarModel{},conflict(err)
+	}
+	if err = insertModelAliases(ctx,tx,brand,id,modelReq); err != nil {
+		return models.CarModel{},err
+	}
+	return loadModel(ctx,tx,brand.String(),id.String())
+}
+
+// UpdateModel renames the brand's cars listed under the old model name, the
+// same way UpdateBrand does.
+func (s *Store)UpdateModel(ctx context.Context,brandID string,id string,modelReq *models.CarModelRequest) (model models.CarModel,err error) {
+	tracer := otel.Tracer("CatalogueStore")
+	ctx,span := tracer.Start(ctx, "UpdateModel-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.CarModel{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	// renames and in use checks span every dealership's cars
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.CarModel{},err
+	}
+	var brand,modelID uuid.UUID
+	var brandName,oldName string
+	err = tx.QueryRowContext(ctx,`SELECT b.id, b.name, m.id, m.name FROM car_model m JOIN brand b ON b.id = m.brand_id
+		WHERE m.id = $1 AND m.brand_id = $2 FOR UPDATE OF m`,id,brandID).Scan(&brand,&brandName,&modelID,&oldName)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.CarModel{},models.ErrRecordNotFound
+		}
+		return models.CarModel{},err
+	}
+	now := time.Now()
+	if _,err = tx.ExecContext(ctx,"UPDATE car_model SET name = $2, updated_at = $3 WHERE id = $1",modelID,modelReq.Name,now); err != nil {
+		return models.CarModel{},conflict(err)
+	}
+	if _,err = tx.ExecContext(ctx,"DELETE FROM car_model_alias WHERE model_id = $1",modelID); err != nil {
+		return models.CarModel{},err
+	}
+	if err = insertModelAliases(ctx,tx,brand,modelID,modelReq); err != nil {
+		return models.CarModel{},err
+	}
+	if oldName != modelReq.Name {
+		_,err = tx.ExecContext(ctx,"UPDATE car SET model = $3, updated_at = $4 WHERE brand = $1 AND model = $2",brandName,oldName,modelReq.Name,now)
+		if err != nil {
+			return models.CarModel{},err
+		}
+	}
+	return loadModel(ctx,tx,brand.String(),modelID.String())
+}
+
+// DeleteModel fails with models.ErrCatalogueEntryInUse while cars are listed as the model.
+func (s *Store)DeleteModel(ctx context.Context,brandID string,id string) (model models.CarModel,err error) {
+	tracer := otel.Tracer("CatalogueStore")
+	ctx,span := tracer.Start(ctx, "DeleteModel-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.CarModel{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	// renames and in use checks span every dealership's cars
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.CarModel{},err
+	}
+	var brandName string
+	err = tx.QueryRowContext(ctx,`SELECT b.name FROM car_model m JOIN brand b ON b.id = m.brand_id
+		WHERE m.id = $1 AND m.brand_id = $2 FOR UPDATE OF m`,id,brandID).Scan(&brandName)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.CarModel{},models.ErrRecordNotFound
+		}
+		return models.CarModel{},err
+	}
+	model,err = loadModel(ctx,tx,brandID,id)
+	if err != nil {
+		return models.CarModel{},err
+	}
+	var inUse bool
+	if err = tx.QueryRowContext(ctx,"SELECT EXISTS (SELECT 1 FROM car WHERE brand = $1 AND model = $2)",brandName,model.Name).Scan(&inUse); err != nil {
+		return models.CarModel{},err
+	}
+	if inUse {
+		return models.CarModel{},models.ErrCatalogueEntryInUse
+	}
+	if _,err = tx.ExecContext(ctx,"DELETE FROM car_model WHERE id = $1",model.ID); err != nil {
+		return models.CarModel{},err
+	}
+	return model,nil
+}
+
+// insertBrandAliases keys the name as well as the aliases, a spelling can only belong to one brand.
+func insertBrandAliases(ctx context.Context,tx *sql.Tx,brandID uuid.UUID,brandReq *models.BrandRequest) error {
+	for _,alias := range append([]string{brandReq.Name},brandReq.Aliases...) {
+		_,err := tx.ExecContext(ctx,"INSERT INTO brand_alias (alias_key, alias, brand_id) VALUES ($1, $2, $3)",models.CatalogueKey(alias),alias,brandID)
+		if err != nil {
+			return conflict(err)
+		}
+	}
+	return nil
+}
+
+func insertModelAliases(ctx context.Context,tx *sql.Tx,brandID uuid.UUID,modelID uuid.UUID,modelReq *models.CarModelRequest) error {
+	for _,alias := range append([]string{modelReq.Name},modelReq.Aliases...) {
+		_,err := tx.ExecContext(ctx,"INSERT INTO car_model_alias (brand_id, alias_key, alias, model_id) VALUES ($1, $2, $3, $4)",brandID,models.CatalogueKey(alias),alias,modelID)
+		if err != nil {
+			return conflict(err)
+		}
+	}
+	return nil
+}
+
+func loadBrand(ctx context.Context,q queryer,id string) (models.Brand,error) {
+	if id == "" {
+		return models.Brand{},models.ErrRecordNotFound
+	}
+	brands,err := loadBrands(ctx,q,id)
+	if err != nil {
+		return models.Brand{},err
+	}
+	if len(brands) == 0 {
+		return models.Brand{},models.ErrRecordNotFound
+	}
+	return brands[0],nil
+}
+
+func loadModel(ctx context.Context,q queryer,brandID string,id string) (models.CarModel,error) {
+	brand,err := loadBrand(ctx,q,brandID)
+	if err != nil {
+		return models.CarModel{},err
+	}
+	for _,model := range brand.Models {
+		if model.ID.String() == id {
+			return model,nil
+		}
+	}
+	return models.CarModel{},models.ErrRecordNotFound
+}
+
+// loadBrands reads every brand, or only the one with id when it is set,
+// along with their models and aliases.
+func loadBrands(ctx context.Context,q queryer,id string) ([]models.Brand,error) {
+	brands := []models.Brand{}
+	index := make(map[uuid.UUID]int)
+	rows,err := q.QueryContext(ctx,"SELECT id, name, created_at, updated_at FROM brand WHERE ($1 = '' OR id::text = $1) ORDER BY name",id)
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		brand := models.Brand{Aliases: []string{},Models: []models.CarModel{}}
+		if err := rows.Scan(&brand.ID,&brand.Name,&brand.CreatedAt,&brand.UpdatedAt); err != nil {
+			return nil,err
+		}
+		index[brand.ID] = len(brands)
+		brands = append(brands,brand)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+
+	// the alias row spelling the name itself isn't an alias to clients
+	err = eachAlias(ctx,q,`SELECT a.brand_id, a.alias FROM brand_alias a JOIN brand b ON b.id = a.brand_id
+		WHERE a.alias <> b.name AND ($1 = '' OR b.id::text = $1) ORDER BY a.alias`,id,func(brandID uuid.UUID,alias string) {
+		if i,ok := index[brandID]; ok {
+			brands[i].Aliases = append(brands[i].Aliases,alias)
+		}
+	})
+	if err != nil {
+		return nil,err
+	}
+
+	modelIndex := make(map[uuid.UUID][2]int)
+	modelRows,err := q.QueryContext(ctx,"SELECT id, brand_id, name, created_at, updated_at FROM car_model WHERE ($1 = '' OR brand_id::text = $1) ORDER BY name",id)
+	if err != nil {
+		return nil,err
+	}
+	defer modelRows.Close()
+	for modelRows.Next() {
+		model := models.CarModel{Aliases: []string{}}
+		if err := modelRows.Scan(&model.ID,&model.BrandID,&model.Name,&model.CreatedAt,&model.UpdatedAt); err != nil {
+			return nil,err
+		}
+		i,ok := index[model.BrandID]
+		if !ok {
+			continue
+		}
+		modelIndex[model.ID] = [2]int{i,len(brands[i].Models)}
+		brands[i].Models = append(brands[i].Models,model)
+	}
+	if err = modelRows.Err(); err != nil {
+		return nil,err
+	}
+
+	err = eachAlias(ctx,q,`SELECT a.model_id, a.alias FROM car_model_alias a JOIN car_model m ON m.id = a.model_id
+		WHERE a.alias <> m.name AND ($1 = '' OR a.brand_id::text = $1) ORDER BY a.alias`,id,func(modelID uuid.UUID,alias string) {
+		if i,ok := modelIndex[modelID]; ok {
+			brands[i[0]].Models[i[1]].Aliases = append(brands[i[0]].Models[i[1]].Aliases,alias)
+		}
+	})
+	if err != nil {
+		return nil,err
+	}
+	return brands,nil
+}
+
+func eachAlias(ctx context.Context,q queryer,query string,id string,add func(owner uuid.UUID,alias string)) error {
+	rows,err := q.QueryContext(ctx,query,id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var owner uuid.UUID
+		var alias string
+		if err := rows.Scan(&owner,&alias); err != nil {
+			return err
+		}
+		add(owner,alias)
+	}
+	return rows.Err()
+}
+
+// conflict reports any unique violation as models.ErrCatalogueConflict, the
+// brand and model names and every alias key are unique.
+func conflict(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err,&pqErr) && pqErr.Code == "23505" {
+		return models.ErrCatalogueConflict
+	}
+	return err
+}
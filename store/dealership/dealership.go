@@ -0,0 +1,149 @@
+package dealership
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/models"
+	"go.opentelemetry.io/otel"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+func (s *Store)GetDealershipByID(ctx context.Context,id string) (models.Dealership,error) {
+	tracer := otel.Tracer("DealershipStore")
+	ctx,span := tracer.Start(ctx, "GetDealershipByID-Store")
+	defer span.End()
+
+	var dealership models.Dealership
+	err := s.db.QueryRowContext(ctx,"SELECT id,name,slug,created_at,updated_at FROM dealership WHERE id = $1",id).Scan(
+		&dealership.ID,
+		&dealership.Name,
+		&dealership.Slug,
+		&dealership.CreatedAt,
+		&dealership.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.Dealership{},models.ErrRecordNotFound
+		}
+		return models.Dealership{},err
+	}
+	return dealership,nil
+}
+
+func (s *Store)ListDealerships(ctx context.Context) ([]models.Dealership,error) {
+	tracer := otel.Tracer("DealershipStore")
+	ctx,span := tracer.Start(ctx, "ListDealerships-Store")
+	defer span.End()
+
+	rows,err := s.db.QueryContext(ctx,"SELECT id,name,slug,created_at,updated_at FROM dealership ORDER BY name")
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	dealerships := []models.Dealership{}
+	for rows.Next() {
+		var dealership models.Dealership
+		err := rows.Scan(
+			&dealership.ID,
+			&dealership.Name,
+			&dealership.Slug,
+			&dealership.CreatedAt,
+			&dealership.UpdatedAt,
+		)
+		if err != nil {
+			return nil,err
+		}
+		dealerships = append(dealerships,dealership)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	return dealerships,nil
+}
+
+func (s *Store)CreateDealership(ctx context.Context,dealershipReq *models.DealershipRequest) (models.Dealership,error) {
+	tracer := otel.Tracer("DealershipStore")
+	ctx,span := tracer.Start(ctx, "CreateDealership-Store")
+	defer span.End()
+
+	now := time.Now()
+	dealership := models.Dealership{
+		ID: uuid.New(),
+		Name: dealershipReq.Name,
+		Slug: dealershipReq.Slug,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	_,err := s.db.ExecContext(ctx,"INSERT INTO dealership (id,name,slug,created_at,updated_at) VALUES ($1,$2,$3,$4,$5)",
+		dealership.ID,
+		dealership.Name,
+		dealership.Slug,
+		dealership.CreatedAt,
+		dealership.UpdatedAt,
+	)
+	if err != nil {
+		return models.Dealership{},err
+	}
+	return dealership,nil
+}
+
+func (s *Store)UpdateDealership(ctx context.Context,id string,dealershipReq *models.DealershipRequest) (models.Dealership,error) {
+	tracer := otel.Tracer("DealershipStore")
+	ctx,span := tracer.Start(ctx, "UpdateDealership-Store")
+	defer span.End()
+
+	var dealership models.Dealership
+	err := s.db.QueryRowContext(ctx,
+		`UPDATE dealership SET name = $2, slug = $3, updated_at = $4 WHERE id = $1
+		RETURNING id,name,slug,created_at,updated_at`,
+		id,dealershipReq.Name,dealershipReq.Slug,time.Now()).Scan(
+		&dealership.ID,
+		&dealership.Name,
+		&dealership.Slug,
+		&dealership.CreatedAt,
+		&dealership.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.Dealership{},models.ErrRecordNotFound
+		}
+		return models.Dealership{},err
+	}
+	return dealership,nil
+}
+
+// DeleteDealership fails while the dealership still owns cars, engines or api keys.
+func (s *Store)DeleteDealership(ctx context.Context,id string) (models.Dealership,error) {
+	tracer := otel.Tracer("DealershipStore")
+	ctx,span := tracer.Start(ctx, "DeleteDealership-Store")
+	defer span.End()
+
+	var dealership models.Dealership
+	err := s.db.QueryRowContext(ctx,"DELETE FROM dealership WHERE id = $1 RETURNING id,name,slug,created_at,updated_at",id).Scan(
+		&dealership.ID,
+		&dealership.Name,
+		&dealership.Slug,
+		&dealership.CreatedAt,
+		&dealership.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.Dealership{},models.ErrRecordNotFound
+		}
+		return models.Dealership{},err
+	}
+	return dealership,nil
+}
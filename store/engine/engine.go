@@ -5,8 +5,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/iangechuki/go_carzone/auth"
 	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"github.com/lib/pq"
 	"go.opentelemetry.io/otel"
 
 	"github.com/google/uuid"
@@ -22,6 +26,33 @@ func New(db *sql.DB) *EngineStore {
 	}
 }
 
+// engineColumns are read by scanEngine
+const engineColumns = `id,tenant_id,fuel_type,displacement,no_of_cylinders,car_range,battery_capacity_kwh,motor_power_kw,charging_standard,horsepower,torque_nm,co2_g_per_km,updated_at`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEngine(row scanner) (models.Engine,error) {
+	var engine models.Engine
+	err := row.Scan(
+		&engine.EngineID,
+		&engine.TenantID,
+		&engine.FuelType,
+		&engine.Displacement,
+		&engine.NoOfCylinders,
+		&engine.CarRange,
+		&engine.BatteryCapacityKWh,
+		&engine.MotorPowerKW,
+		&engine.ChargingStandard,
+		&engine.Horsepower,
+		&engine.TorqueNm,
+		&engine.CO2GPerKm,
+		&engine.UpdatedAt,
+	)
+	return engine,err
+}
+
 func (s *EngineStore) GetEngineByID(ctx context.Context,id string) (models.Engine,error) {
 	tracer := otel.Tracer("EngineStore")
 	ctx,span := tracer.Start(ctx, "GetEngineByID-Store")
@@ -42,13 +73,10 @@ func (s *EngineStore) GetEngineByID(ctx context.Context,id string) (models.Engin
 			}
 		}
 	}()
-	err = tx.QueryRowContext(ctx,"SELECT id,displacement,no_of_cylinders,car_range FROM engine WHERE id = $1",id).
-	Scan(
-		&engine.EngineID,
-		&engine.Displacement,
-		&engine.NoOfCylinders,
-		&engine.CarRange,
-	)
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Engine{},err
+	}
+	engine,err = scanEngine(tx.QueryRowContext(ctx,"SELECT "+engineColumns+" FROM engine WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)",id,auth.TenantID(ctx)))
 	if err != nil {
 		switch err {
 		case sql.ErrNoRows:
@@ -60,11 +88,83 @@ func (s *EngineStore) GetEngineByID(ctx context.Context,id string) (models.Engin
 	return engine,nil
 }
 
+// GetEnginesByIDs returns the engines found among ids in no particular order, missing ones are left out.
+func (s *EngineStore) GetEnginesByIDs(ctx context.Context,ids []string) ([]models.Engine,error) {
+	tracer := otel.Tracer("EngineStore")
+	ctx,span := tracer.Start(ctx, "GetEnginesByIDs-Store")
+	defer span.End()
+
+	// an id that is not a uuid cannot match, and would fail the whole array cast
+	engineIDs := []string{}
+	for _,id := range ids {
+		if _,err := uuid.Parse(id); err == nil {
+			engineIDs = append(engineIDs,id)
+		}
+	}
+	tx,err := store.ReadTx(ctx,s.db)
+	if err != nil {
+		return nil,err
+	}
+	defer tx.Rollback()
+	rows,err := tx.QueryContext(ctx,"SELECT "+engineColumns+" FROM engine WHERE id = ANY($1::uuid[]) AND ($2::uuid IS NULL OR tenant_id = $2)",pq.Array(engineIDs),auth.TenantID(ctx))
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	engines := []models.Engine{}
+	for rows.Next() {
+		engine,err := scanEngine(rows)
+		if err != nil {
+			return nil,err
+		}
+		engines = append(engines,engine)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	return engines,nil
+}
+
+// GetEngines pages through the caller's engines, most recently updated first. A limit of zero returns them all.
+func (s *EngineStore) GetEngines(ctx context.Context,limit,offset int) ([]models.Engine,error) {
+	tracer := otel.Tracer("EngineStore")
+	ctx,span := tracer.Start(ctx, "GetEngines-Store")
+	defer span.End()
+
+	tx,err := store.ReadTx(ctx,s.db)
+	if err != nil {
+		return nil,err
+	}
+	defer tx.Rollback()
+	rows,err := tx.QueryContext(ctx,`SELECT `+engineColumns+` FROM engine
+		WHERE ($1::uuid IS NULL OR tenant_id = $1) ORDER BY updated_at DESC, id LIMIT NULLIF($2, 0) OFFSET $3`,auth.TenantID(ctx),limit,offset)
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	engines := []models.Engine{}
+	for rows.Next() {
+		engine,err := scanEngine(rows)
+		if err != nil {
+			return nil,err
+		}
+		engines = append(engines,engine)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	return engines,nil
+}
+
 func (s *EngineStore) CreateEngine(ctx context.Context,engineReq *models.EngineRequest) (models.Engine,error) {
 	tracer := otel.Tracer("EngineStore")
 	ctx,span := tracer.Start(ctx, "CreateEngine-Store")
 	defer span.End()
 
+	tenantID := auth.TenantID(ctx)
+	if !tenantID.Valid {
+		return models.Engine{},models.ErrTenantRequired
+	}
 	tx,err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return models.Engine{},err
@@ -80,23 +180,49 @@ func (s *EngineStore) CreateEngine(ctx context.Context,engineReq *models.EngineR
 			}
 		}
 	}()
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Engine{},err
+	}
 	engineID := uuid.New()
+	now := time.Now()
 
-	_,err = tx.ExecContext(ctx,"INSERT INTO engine (id,displacement,no_of_cylinders,car_range) VALUES ($1,$2,$3,$4)",
+	_,err = tx.ExecContext(ctx,`INSERT INTO engine (id,tenant_id,displacement,no_of_cylinders,car_range,created_at,updated_at,
+		fuel_type,battery_capacity_kwh,motor_power_kw,charging_standard,horsepower,torque_nm,co2_g_per_km)
+		VALUES ($1,$2,$3,$4,$5,$6,$6,$7,$8,$9,$10,$11,$12,$13)`,
 		engineID,
+		tenantID,
 		engineReq.Displacement,
 		engineReq.NoOfCylinders,
 		engineReq.CarRange,
+		now,
+		engineReq.FuelType,
+		engineReq.BatteryCapacityKWh,
+		engineReq.MotorPowerKW,
+		engineReq.ChargingStandard,
+		engineReq.Horsepower,
+		engineReq.TorqueNm,
+		engineReq.CO2GPerKm,
 	)
 	if err != nil {
 		return models.Engine{},err
 	}
 	engine := models.Engine{
 		EngineID: engineID,
+		TenantID: tenantID.UUID,
+		FuelType: engineReq.FuelType,
 		Displacement: engineReq.Displacement,
 		NoOfCylinders: engineReq.NoOfCylinders,
 		CarRange: engineReq.CarRange,
-
+		BatteryCapacityKWh: engineReq.BatteryCapacityKWh,
+		MotorPowerKW: engineReq.MotorPowerKW,
+		ChargingStandard: engineReq.ChargingStandard,
+		Horsepower: engineReq.Horsepower,
+		TorqueNm: engineReq.TorqueNm,
+		CO2GPerKm: engineReq.CO2GPerKm,
+		UpdatedAt: now,
+	}
+	if err = store.RecordEvent(ctx,tx,models.EventEngineCreated,engine.TenantID,engine); err != nil {
+		return models.Engine{},err
 	}
 	return engine,nil
 }
@@ -125,33 +251,76 @@ func (s *EngineStore) UpdateEngine(ctx context.Context,id string,engineReq *mode
 			}
 		}
 	}()
-	results,err := tx.ExecContext(
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Engine{},err
+	}
+	var tenantID uuid.UUID
+	now := time.Now()
+	err = tx.QueryRowContext(
 		ctx,
 		`UPDATE engine 
 		SET displacement = $1,
 			no_of_cylinders = $2,
-			car_range = $3
-		WHERE id = $4`,
+			car_range = $3,
+			updated_at = $6,
+			fuel_type = $7,
+			battery_capacity_kwh = $8,
+			motor_power_kw = $9,
+			charging_standard = $10,
+			horsepower = $11,
+			torque_nm = $12,
+			co2_g_per_km = $13
+		WHERE id = $4 AND ($5::uuid IS NULL OR tenant_id = $5)
+		RETURNING tenant_id`,
 		engineReq.Displacement,
 		engineReq.NoOfCylinders,
 		engineReq.CarRange,
 		engineID,
-	)
-	if err != nil {
-		return models.Engine{},err
-	}
-	rowsAffected,err := results.RowsAffected()
+		auth.TenantID(ctx),
+		now,
+		engineReq.FuelType,
+		engineReq.BatteryCapacityKWh,
+		engineReq.MotorPowerKW,
+		engineReq.ChargingStandard,
+		engineReq.Horsepower,
+		engineReq.TorqueNm,
+		engineReq.CO2GPerKm,
+	).Scan(&tenantID)
 	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.Engine{},errors.New("no rows updated")
+		}
 		return models.Engine{},err
 	}
-	if rowsAffected == 0 {
-		return models.Engine{},errors.New("no rows updated")
+	// cars already fitted with the engine must run on its new powertrain
+	if engineReq.FuelType != "" {
+		var mismatch bool
+		err = tx.QueryRowContext(ctx,"SELECT EXISTS (SELECT 1 FROM car WHERE engine_id = $1 AND fuel_type <> $2)",engineID,engineReq.FuelType).Scan(&mismatch)
+		if err != nil {
+			return models.Engine{},err
+		}
+		if mismatch {
+			err = models.ErrEngineFuelMismatch
+			return models.Engine{},err
+		}
 	}
 	engine := models.Engine{
 		EngineID: engineID,
+		TenantID: tenantID,
+		FuelType: engineReq.FuelType,
 		Displacement: engineReq.Displacement,
 		NoOfCylinders: engineReq.NoOfCylinders,
 		CarRange: engineReq.CarRange,
+		BatteryCapacityKWh: engineReq.BatteryCapacityKWh,
+		MotorPowerKW: engineReq.MotorPowerKW,
+		ChargingStandard: engineReq.ChargingStandard,
+		Horsepower: engineReq.Horsepower,
+		TorqueNm: engineReq.TorqueNm,
+		CO2GPerKm: engineReq.CO2GPerKm,
+		UpdatedAt: now,
+	}
+	if err = store.RecordEvent(ctx,tx,models.EventEngineUpdated,engine.TenantID,engine); err != nil {
+		return models.Engine{},err
 	}
 	return engine,nil
 }
@@ -176,17 +345,15 @@ func (s *EngineStore) DeleteEngine(ctx context.Context,id string) (models.Engine
             err = tx.Commit()
         }
     }()
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Engine{},err
+	}
 	var engine models.Engine
 
-	err = tx.QueryRowContext(
+	engine,err = scanEngine(tx.QueryRowContext(
 		ctx,
-		`SELECT id,displacement,no_of_cylinders,car_range 
-		FROM engine WHERE id = $1`,engineID).Scan(
-		&engine.EngineID,
-		&engine.Displacement,
-		&engine.NoOfCylinders,
-		&engine.CarRange,
-	)
+		`SELECT `+engineColumns+`
+		FROM engine WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`,engineID,auth.TenantID(ctx)))
 	if err != nil {
 		switch err {
 		case sql.ErrNoRows:
@@ -206,5 +373,8 @@ func (s *EngineStore) DeleteEngine(ctx context.Context,id string) (models.Engine
 	if rowsAffected == 0 {
 		return models.Engine{},errors.New("no rows deleted")
 	}
+	if err = store.RecordEvent(ctx,tx,models.EventEngineDeleted,engine.TenantID,engine); err != nil {
+		return models.Engine{},err
+	}
 	return engine,nil
 }
\ No newline at end of file
@@ -0,0 +1,88 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/iangechuki/go_carzone/models"
+	"go.opentelemetry.io/otel"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+func (s *Store)Begin(ctx context.Context,userID string,key string,fingerprint string,ttl time.Duration,lease time.Duration) (models.IdempotencyRecord,bool,error) {
+	tracer := otel.Tracer("IdempotencyStore")
+	ctx,span := tracer.Start(ctx, "Begin-Store")
+	defer span.End()
+
+	// an expired key, or one whose request stopped holding it, is claimed
+	// again as if it had never been used
+	now := time.Now()
+	var claimed bool
+	err := s.db.QueryRowContext(ctx,`
+		INSERT INTO idempotency_key (user_id, key, fingerprint, created_at, expires_at, locked_until) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status_code = 0, content_type = '', body = NULL,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at, locked_until = EXCLUDED.locked_until
+		WHERE idempotency_key.expires_at < $4 OR (idempotency_key.status_code = 0 AND idempotency_key.locked_until < $4)
+		RETURNING true`,userID,key,fingerprint,now,now.Add(ttl),now.Add(lease)).Scan(&claimed)
+	if err == nil {
+		return models.IdempotencyRecord{Fingerprint: fingerprint},true,nil
+	}
+	if !errors.Is(err,sql.ErrNoRows) {
+		return models.IdempotencyRecord{},false,err
+	}
+	var record models.IdempotencyRecord
+	err = s.db.QueryRowContext(ctx,`SELECT fingerprint, status_code, content_type, COALESCE(body, '') FROM idempotency_key WHERE user_id = $1 AND key = $2`,userID,key).Scan(
+		&record.Fingerprint,
+		&record.StatusCode,
+		&record.ContentType,
+		&record.Body,
+	)
+	if err != nil {
+		return models.IdempotencyRecord{},false,err
+	}
+	return record,false,nil
+}
+
+func (s *Store)Complete(ctx context.Context,userID string,key string,record models.IdempotencyRecord) error {
+	tracer := otel.Tracer("IdempotencyStore")
+	ctx,span := tracer.Start(ctx, "Complete-Store")
+	defer span.End()
+
+	_,err := s.db.ExecContext(ctx,`UPDATE idempotency_key SET status_code = $3, content_type = $4, body = $5 WHERE user_id = $1 AND key = $2`,
+		userID,key,record.StatusCode,record.ContentType,record.Body)
+	return err
+}
+
+func (s *Store)Release(ctx context.Context,userID string,key string) error {
+	tracer := otel.Tracer("IdempotencyStore")
+	ctx,span := tracer.Start(ctx, "Release-Store")
+	defer span.End()
+
+	_,err := s.db.ExecContext(ctx,`DELETE FROM idempotency_key WHERE user_id = $1 AND key = $2 AND status_code = 0`,userID,key)
+	return err
+}
+
+// Purge deletes keys that expired before the given time.
+func (s *Store)Purge(ctx context.Context,before time.Time) (int64,error) {
+	tracer := otel.Tracer("IdempotencyStore")
+	ctx,span := tracer.Start(ctx, "Purge-Store")
+	defer span.End()
+
+	result,err := s.db.ExecContext(ctx,`DELETE FROM idempotency_key WHERE expires_at < $1`,before)
+	if err != nil {
+		return 0,err
+	}
+	return result.RowsAffected()
+}
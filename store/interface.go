@@ -2,20 +2,104 @@ package store
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
+
 	"github.com/iangechuki/go_carzone/models"
 )
 
 type CarStoreInterface interface {
 	CreateCar(ctx context.Context,carReq *models.CarRequest) (models.Car,error)
 	GetCarByID(ctx context.Context,id string) (models.Car,error)
-	GetCarsByBrand(ctx context.Context,brand string,isEngine bool) ([]models.Car,error)
-	UpdateCar(ctx context.Context,id string,carReq *models.CarRequest) (models.Car,error)
-	DeleteCar(ctx context.Context,id string) (models.Car,error)
+	GetCarsByBrand(ctx context.Context,brand string,isEngine bool,filter models.CarFilter) ([]models.Car,error)
+	GetCarsByOwner(ctx context.Context,userID string) ([]models.Car,error)
+	// UpdateCar and DeleteCar only touch a car created by ownerID, any car when it is empty.
+	UpdateCar(ctx context.Context,id string,ownerID string,carReq *models.CarRequest) (models.Car,error)
+	DeleteCar(ctx context.Context,id string,ownerID string) (models.Car,error)
+	UpdateCarStatus(ctx context.Context,id string,from string,to models.CarStatusChange) (models.Car,error)
 }
 
 type EngineStoreInterface interface {
 	GetEngineByID(ctx context.Context,id string) (models.Engine,error)
+	GetEnginesByIDs(ctx context.Context,ids []string) ([]models.Engine,error)
+	GetEngines(ctx context.Context,limit,offset int) ([]models.Engine,error)
 	CreateEngine(ctx context.Context,engineReq *models.EngineRequest) (models.Engine,error)
 	UpdateEngine(ctx context.Context,id string,engineReq *models.EngineRequest) (models.Engine,error)
 	DeleteEngine(ctx context.Context,id string) (models.Engine,error)
-}
\ No newline at end of file
+}
+
+type UserStoreInterface interface {
+	CreateUser(ctx context.Context,user models.User,passwordHash string) (models.User,error)
+	GetUserByUsername(ctx context.Context,username string) (models.User,string,error)
+}
+
+type APIKeyStoreInterface interface {
+	CreateAPIKey(ctx context.Context,key models.APIKey,keyHash string) (models.APIKey,error)
+	GetAPIKeyByHash(ctx context.Context,keyHash string) (models.APIKey,error)
+	ListAPIKeys(ctx context.Context) ([]models.APIKey,error)
+	RevokeAPIKey(ctx context.Context,id string) (models.APIKey,error)
+	TouchAPIKey(ctx context.Context,id string,usedAt time.Time) error
+}
+
+type DealershipStoreInterface interface {
+	GetDealershipByID(ctx context.Context,id string) (models.Dealership,error)
+	ListDealerships(ctx context.Context) ([]models.Dealership,error)
+	CreateDealership(ctx context.Context,dealershipReq *models.DealershipRequest) (models.Dealership,error)
+	UpdateDealership(ctx context.Context,id string,dealershipReq *models.DealershipRequest) (models.Dealership,error)
+	DeleteDealership(ctx context.Context,id string) (models.Dealership,error)
+}
+
+type CatalogueStoreInterface interface {
+	ListBrands(ctx context.Context) ([]models.Brand,error)
+	GetBrandByID(ctx context.Context,id string) (models.Brand,error)
+	CreateBrand(ctx context.Context,brandReq *models.BrandRequest) (models.Brand,error)
+	UpdateBrand(ctx context.Context,id string,brandReq *models.BrandRequest) (models.Brand,error)
+	DeleteBrand(ctx context.Context,id string) (models.Brand,error)
+	CreateModel(ctx context.Context,brandID string,modelReq *models.CarModelRequest) (models.CarModel,error)
+	UpdateModel(ctx context.Context,brandID string,id string,modelReq *models.CarModelRequest) (models.CarModel,error)
+	DeleteModel(ctx context.Context,brandID string,id string) (models.CarModel,error)
+}
+
+type TestDriveStoreInterface interface {
+	GetSchedule(ctx context.Context,tenantID uuid.UUID) (models.TestDriveSchedule,error)
+	ReplaceSchedule(ctx context.Context,tenantID uuid.UUID,schedule *models.TestDriveSchedule) (models.TestDriveSchedule,error)
+	GetTestDriveByID(ctx context.Context,id string) (models.TestDrive,error)
+	GetTestDrivesByCar(ctx context.Context,carID string,customerID string,from time.Time,to time.Time) ([]models.TestDrive,error)
+	CreateTestDrive(ctx context.Context,testDrive models.TestDrive) (models.TestDrive,error)
+	CancelTestDrive(ctx context.Context,id string) (models.TestDrive,error)
+}
+
+type OrderStoreInterface interface {
+	GetOrderByID(ctx context.Context,id string) (models.Order,error)
+	ListOrders(ctx context.Context,buyerID string) ([]models.Order,error)
+	CreateOrder(ctx context.Context,order models.Order) (models.Order,error)
+	AddPayment(ctx context.Context,id string,payment models.Payment) (models.Order,error)
+	CloseOrder(ctx context.Context,id string,status string) (models.Order,error)
+}
+
+type WebhookStoreInterface interface {
+	CreateWebhook(ctx context.Context,webhook models.Webhook) (models.Webhook,error)
+	GetWebhookByID(ctx context.Context,id string) (models.Webhook,error)
+	ListWebhooks(ctx context.Context) ([]models.Webhook,error)
+	ListActiveWebhooks(ctx context.Context,tenantID uuid.UUID) ([]models.Webhook,error)
+	DeleteWebhook(ctx context.Context,id string) (models.Webhook,error)
+	CreateDeliveries(ctx context.Context,deliveries []models.WebhookDelivery) error
+	GetDeliveryByID(ctx context.Context,id string) (models.WebhookDelivery,error)
+	ListDeliveries(ctx context.Context,webhookID string,limit int) ([]models.WebhookDelivery,error)
+	ClaimDueDeliveries(ctx context.Context,limit int,lease time.Duration) ([]models.WebhookDelivery,error)
+	RecordAttempt(ctx context.Context,delivery models.WebhookDelivery) error
+}
+
+type OutboxStoreInterface interface {
+	ClaimOutbox(ctx context.Context,limit int,lease time.Duration) ([]models.OutboxMessage,error)
+	MarkPublished(ctx context.Context,ids []uuid.UUID) error
+	MarkFailed(ctx context.Context,id uuid.UUID,cause string,nextAttemptAt time.Time) error
+	PurgePublished(ctx context.Context,before time.Time) (int64,error)
+}
+
+// EventLogInterface reads committed events back out of the outbox for car streams.
+type EventLogInterface interface {
+	EventsAfter(ctx context.Context,afterID uuid.UUID,eventTypes []string,tenantID uuid.NullUUID,limit int) ([]models.OutboxMessage,error)
+	Listen(ctx context.Context,handle func(models.OutboxMessage)) error
+}
@@ -0,0 +1,338 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx so reads can run inside a transaction.
+type queryer interface {
+	QueryRowContext(ctx context.Context,query string,args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context,query string,args ...interface{}) (*sql.Rows,error)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+const orderColumns = `id, tenant_id, invoice_number, car_id, buyer_id, buyer_name, buyer_email, agreed_price, fees, fees_total,
+	tax_rate, tax, total, amount_paid, payment_status, created_by, created_at, updated_at, paid_at`
+
+func scanOrder(row scanner) (models.Order,error) {
+	var order models.Order
+	var fees []byte
+	err := row.Scan(
+		&order.ID,
+		&order.TenantID,
+		&order.InvoiceNumber,
+		&order.CarID,
+		&order.BuyerID,
+		&order.BuyerName,
+		&order.BuyerEmail,
+		&order.AgreedPrice,
+		&fees,
+		&order.FeesTotal,
+		&order.TaxRate,
+		&order.Tax,
+		&order.Total,
+		&order.AmountPaid,
+		&order.PaymentStatus,
+		&order.CreatedBy,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+		&order.PaidAt,
+	)
+	if err != nil {
+		return models.Order{},err
+	}
+	if err := json.Unmarshal(fees,&order.Fees); err != nil {
+		return models.Order{},err
+	}
+	order.Payments = []models.Payment{}
+	return order,nil
+}
+
+func getOrder(ctx context.Context,q queryer,id string,lock bool) (models.Order,error) {
+	query := `SELECT `+orderColumns+` FROM car_order WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+	if lock {
+		query += ` FOR UPDATE`
+	}
+	order,err := scanOrder(q.QueryRowContext(ctx,query,id,auth.TenantID(ctx)))
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.Order{},models.ErrRecordNotFound
+		}
+		return models.Order{},err
+	}
+	rows,err := q.QueryContext(ctx,`SELECT id, amount, method, reference, recorded_by, created_at
+	FROM order_payment WHERE order_id = $1 ORDER BY created_at`,order.ID)
+	if err != nil {
+		return models.Order{},err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var payment models.Payment
+		err := rows.Scan(&payment.ID,&payment.Amount,&payment.Method,&payment.Reference,&payment.RecordedBy,&payment.CreatedAt)
+		if err != nil {
+			return models.Order{},err
+		}
+		order.Payments = append(order.Payments,payment)
+	}
+	if err = rows.Err(); err != nil {
+		return models.Order{},err
+	}
+	return order,nil
+}
+
+func (s *Store)GetOrderByID(ctx context.Context,id string) (models.Order,error) {
+	tracer := otel.Tracer("OrderStore")
+	ctx,span := tracer.Start(ctx, "GetOrderByID-Store")
+	defer span.End()
+
+	tx,err := store.ReadTx(ctx,s.db)
+	if err != nil {
+		return models.Order{},err
+	}
+	defer tx.Rollback()
+	return getOrder(ctx,tx,id,false)
+}
+
+// ListOrders lists the dealership's orders newest first, only buyerID's when it is set.
+func (s *Store)ListOrders(ctx context.Context,buyerID string) ([]models.Order,error) {
+	tracer := otel.Tracer("OrderStore")
+	ctx,span := tracer.Start(ctx, "ListOrders-Store")
+	defer span.End()
+
+	tx,err := store.ReadTx(ctx,s.db)
+	if err != nil {
+		return nil,err
+	}
+	defer tx.Rollback()
+	rows,err := tx.QueryContext(ctx,`SELECT `+orderColumns+` FROM car_order
+	WHERE ($1::uuid IS NULL OR tenant_id = $1) AND ($2 = '' OR buyer_id = $2)
+	ORDER BY created_at DESC`,auth.TenantID(ctx),buyerID)
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	orders := []models.Order{}
+	for rows.Next() {
+		order,err := scanOrder(rows)
+		if err != nil {
+			return nil,err
+		}
+		orders = append(orders,order)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	return orders,nil
+}
+
+// CreateOrder places the order and marks its car sold in one transaction. The
+// car row is locked first so concurrent orders for it queue up, and the
+// partial unique index on car_order rejects a second open order regardless.
+func (s *Store)CreateOrder(ctx context.Context,order models.Order) (placed models.Order,err error) {
+	tracer := otel.Tracer("OrderStore")
+	ctx,span := tracer.Start(ctx, "CreateOrder-Store")
+	defer span.End()
+
+	fees,err := json.Marshal(order.Fees)
+	if err != nil {
+		return models.Order{},err
+	}
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Order{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Order{},err
+	}
+	var car models.Car
+	err = tx.QueryRowContext(ctx,`SELECT c.status, c.reserved_by, c.reserved_until
+	FROM car c WHERE c.id = $1 AND c.tenant_id = $2 FOR UPDATE`,order.CarID,order.TenantID).Scan(&car.Status,&car.ReservedBy,&car.ReservedUntil)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			err = models.ErrRecordNotFound
+		}
+		return models.Order{},err
+	}
+	now := time.Now()
+	if !models.ForSaleTo(&car,order.BuyerID,now) {
+		err = models.ErrCarNotForSale
+		return models.Order{},err
+	}
+	row := tx.QueryRowContext(ctx,`INSERT INTO car_order (id, tenant_id, invoice_number, car_id, buyer_id, buyer_name, buyer_email,
+		agreed_price, fees, fees_total, tax_rate, tax, total, payment_status, created_by, created_at, updated_at)
+	VALUES ($1, $2, 'INV-' || lpad(nextval('invoice_number_seq')::text, 6, '0'), $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $15)
+	RETURNING `+orderColumns,
+		order.ID,order.TenantID,order.CarID,order.BuyerID,order.BuyerName,order.BuyerEmail,
+		order.AgreedPrice,fees,order.FeesTotal,order.TaxRate,order.Tax,order.Total,models.PaymentPending,order.CreatedBy,now)
+	created,err := scanOrder(row)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err,&pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "car_order_one_sale_idx" {
+			err = models.ErrCarNotForSale
+		}
+		return models.Order{},err
+	}
+	err = updateCar(ctx,tx,`UPDATE car SET status = 'sold', reserved_by = '', reserved_until = NULL, sold_at = $2, updated_at = $2
+	WHERE id = $1`,order.CarID,now)
+	if err != nil {
+		return models.Order{},err
+	}
+	return created,nil
+}
+
+// AddPayment records a payment against an open order and moves its payment status along.
+func (s *Store)AddPayment(ctx context.Context,id string,payment models.Payment) (updated models.Order,err error) {
+	tracer := otel.Tracer("OrderStore")
+	ctx,span := tracer.Start(ctx, "AddPayment-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Order{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Order{},err
+	}
+	order,err := getOrder(ctx,tx,id,true)
+	if err != nil {
+		return models.Order{},err
+	}
+	if err = order.CheckPayment(payment.Amount); err != nil {
+		return models.Order{},err
+	}
+	_,err = tx.ExecContext(ctx,`INSERT INTO order_payment (id, order_id, amount, method, reference, recorded_by, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`,payment.ID,order.ID,payment.Amount,payment.Method,payment.Reference,payment.RecordedBy,payment.CreatedAt)
+	if err != nil {
+		return models.Order{},err
+	}
+	paid := order.AmountPaid + payment.Amount
+	status := models.PaymentStatusFor(order.Total,paid)
+	_,err = tx.ExecContext(ctx,`UPDATE car_order SET amount_paid = $2, payment_status = $3, updated_at = $4,
+		paid_at = CASE WHEN $3 = 'paid' THEN $4 ELSE paid_at END
+	WHERE id = $1`,order.ID,paid,status,payment.CreatedAt)
+	if err != nil {
+		return models.Order{},err
+	}
+	return getOrder(ctx,tx,id,false)
+}
+
+// CloseOrder cancels or refunds an open order and puts its car back on sale.
+// Orders with payments can only be refunded.
+func (s *Store)CloseOrder(ctx context.Context,id string,status string) (closed models.Order,err error) {
+	tracer := otel.Tracer("OrderStore")
+	ctx,span := tracer.Start(ctx, "CloseOrder-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Order{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.Order{},err
+	}
+	order,err := getOrder(ctx,tx,id,true)
+	if err != nil {
+		return models.Order{},err
+	}
+	if err = order.CheckClose(status); err != nil {
+		return models.Order{},err
+	}
+	now := time.Now()
+	_,err = tx.ExecContext(ctx,`UPDATE car_order SET payment_status = $2, updated_at = $3 WHERE id = $1`,order.ID,status,now)
+	if err != nil {
+		return models.Order{},err
+	}
+	err = updateCar(ctx,tx,`UPDATE car SET status = 'available', sold_at = NULL, updated_at = $2
+	WHERE id = $1 AND status = 'sold'`,order.CarID,now)
+	if err != nil {
+		return models.Order{},err
+	}
+	return getOrder(ctx,tx,id,false)
+}
+
+// updateCar applies the car side of an order and records it as car.updated, the
+// same event the car store records for status changes. A query that matches no
+// car records nothing.
+func updateCar(ctx context.Context,tx *sql.Tx,query string,args ...interface{}) error {
+	var car models.Car
+	err := tx.QueryRowContext(ctx,query+`
+	RETURNING id, tenant_id, created_by, name, year, brand, fuel_type, engine_id, price,
+		model, COALESCE(vin, ''), odometer_km, transmission, drivetrain, colour, body_type, condition, features,
+		status, reserved_by, reserved_until, sold_at, created_at, updated_at`,args...).Scan(
+		&car.ID,
+		&car.TenantID,
+		&car.CreatedBy,
+		&car.Name,
+		&car.Year,
+		&car.Brand,
+		&car.FuelType,
+		&car.Engine.EngineID,
+		&car.Price,
+		&car.Model,
+		&car.VIN,
+		&car.OdometerKm,
+		&car.Transmission,
+		&car.Drivetrain,
+		&car.Colour,
+		&car.BodyType,
+		&car.Condition,
+		pq.Array(&car.Features),
+		&car.Status,
+		&car.ReservedBy,
+		&car.ReservedUntil,
+		&car.SoldAt,
+		&car.CreatedAt,
+		&car.UpdatedAt,
+	)
+	if errors.Is(err,sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return store.RecordEvent(ctx,tx,models.EventCarUpdated,car.TenantID,car)
+}
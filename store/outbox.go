@@ -0,0 +1,25 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+)
+
+// RecordEvent writes an event to the outbox inside tx, so it is published if
+// and only if the change it describes commits. The event id doubles as the
+// idempotency key consumers deduplicate on.
+func RecordEvent(ctx context.Context,tx *sql.Tx,eventType string,tenantID uuid.UUID,data interface{}) error {
+	event := models.NewEvent(eventType,tenantID,auth.Actor(ctx),data)
+	payload,err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_,err = tx.ExecContext(ctx,`INSERT INTO outbox (id, event_type, tenant_id, payload, created_at, next_attempt_at) VALUES ($1, $2, $3, $4, $5, $5)`,
+		event.ID,event.Type,event.TenantID,payload,event.OccurredAt)
+	return err
+}
@@ -0,0 +1,160 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// the channel sequence_outbox announces numbered event ids on
+	notifyChannel = "outbox_events"
+	// the channel the outbox_notify trigger wakes listeners on after an insert
+	pendingChannel = "outbox_pending"
+)
+
+// EventLog reads committed events back out of the outbox, as they happen
+// through LISTEN/NOTIFY and afterwards for clients catching up.
+type EventLog struct {
+	db *sql.DB
+	connStr string
+}
+
+// NewEventLog listens on its own connection to connStr, LISTEN holds a
+// connection for as long as it runs so it can't come from the pool.
+func NewEventLog(db *sql.DB,connStr string) *EventLog {
+	return &EventLog{
+		db: db,
+		connStr: connStr,
+	}
+}
+
+// EventsAfter returns up to limit events of the given types numbered after
+// afterID, in the order they were numbered. It fails with
+// models.ErrRecordNotFound once afterID has been purged.
+func (l *EventLog)EventsAfter(ctx context.Context,afterID uuid.UUID,eventTypes []string,tenantID uuid.NullUUID,limit int) ([]models.OutboxMessage,error) {
+	tracer := otel.Tracer("OutboxStore")
+	ctx,span := tracer.Start(ctx, "EventsAfter-Store")
+	defer span.End()
+
+	var after sql.NullInt64
+	err := l.db.QueryRowContext(ctx,`SELECT seq FROM outbox WHERE id = $1`,afterID).Scan(&after)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return nil,models.ErrRecordNotFound
+		}
+		return nil,err
+	}
+	// never announced, so no client could have seen it
+	if !after.Valid {
+		return nil,models.ErrRecordNotFound
+	}
+	query := `
+		SELECT id, event_type, tenant_id, payload, attempts, created_at FROM outbox
+		WHERE seq > $1 AND event_type = ANY($2) AND ($3::uuid IS NULL OR tenant_id = $3)
+		ORDER BY seq
+		LIMIT $4`
+	rows,err := l.db.QueryContext(ctx,query,after.Int64,pq.Array(eventTypes),tenantID,limit)
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	messages := []models.OutboxMessage{}
+	for rows.Next() {
+		var msg models.OutboxMessage
+		if err := rows.Scan(&msg.ID,&msg.EventType,&msg.TenantID,&msg.Payload,&msg.Attempts,&msg.CreatedAt); err != nil {
+			return nil,err
+		}
+		messages = append(messages,msg)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	return messages,nil
+}
+
+// Listen calls handle with each event as it is numbered until ctx is done.
+// Every instance numbers what was committed when woken on pendingChannel, and
+// on each ping in case a wake up was missed. Events numbered while the
+// connection is being re-established are not seen, clients catch up on them
+// through EventsAfter.
+func (l *EventLog)Listen(ctx context.Context,handle func(models.OutboxMessage)) error {
+	listener := pq.NewListener(l.connStr,time.Second,time.Minute,func(event pq.ListenerEventType,err error) {
+		if err != nil {
+			log.Println("Error listening for outbox events: ",err)
+		}
+	})
+	defer listener.Close()
+	if err := listener.Listen(notifyChannel); err != nil {
+		return err
+	}
+	if err := listener.Listen(pendingChannel); err != nil {
+		return err
+	}
+	l.sequence(ctx)
+	ping := time.NewTicker(90 * time.Second)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ping.C:
+			if err := listener.Ping(); err != nil {
+				log.Println("Error pinging outbox listener: ",err)
+			}
+			l.sequence(ctx)
+		case notification := <-listener.Notify:
+			// nil after a reconnect
+			if notification == nil {
+				log.Println("Outbox listener reconnected, events in between were missed")
+				l.sequence(ctx)
+				continue
+			}
+			if notification.Channel == pendingChannel {
+				l.sequence(ctx)
+				continue
+			}
+			id,err := uuid.Parse(notification.Extra)
+			if err != nil {
+				log.Println("Error: ",err)
+				continue
+			}
+			msg,err := l.getEvent(ctx,id)
+			if err != nil {
+				log.Printf("Error reading outbox event %s: %v",id,err)
+				continue
+			}
+			handle(msg)
+		}
+	}
+}
+
+// sequence numbers the events committed since it last ran, they are
+// announced on notifyChannel once it commits.
+func (l *EventLog)sequence(ctx context.Context) {
+	tracer := otel.Tracer("OutboxStore")
+	ctx,span := tracer.Start(ctx, "Sequence-Store")
+	defer span.End()
+
+	if _,err := l.db.ExecContext(ctx,`SELECT sequence_outbox()`); err != nil {
+		log.Println("Error numbering outbox events: ",err)
+	}
+}
+
+func (l *EventLog)getEvent(ctx context.Context,id uuid.UUID) (models.OutboxMessage,error) {
+	tracer := otel.Tracer("OutboxStore")
+	ctx,span := tracer.Start(ctx, "GetEvent-Store")
+	defer span.End()
+
+	var msg models.OutboxMessage
+	err := l.db.QueryRowContext(ctx,`SELECT id, event_type, tenant_id, payload, attempts, created_at FROM outbox WHERE id = $1`,id).Scan(
+		&msg.ID,&msg.EventType,&msg.TenantID,&msg.Payload,&msg.Attempts,&msg.CreatedAt)
+	return msg,err
+}
@@ -0,0 +1,93 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+// Store is the relay's side of the outbox, events are written by the other
+// stores through store.RecordEvent.
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// ClaimOutbox leases up to limit unpublished messages, oldest first. A
+// message the relay does not mark published before lease passes is claimed
+// again, which is what makes delivery at least once.
+func (s *Store)ClaimOutbox(ctx context.Context,limit int,lease time.Duration) ([]models.OutboxMessage,error) {
+	tracer := otel.Tracer("OutboxStore")
+	ctx,span := tracer.Start(ctx, "ClaimOutbox-Store")
+	defer span.End()
+
+	query := `
+		UPDATE outbox o SET next_attempt_at = NOW() + make_interval(secs => $2), attempts = o.attempts + 1
+		WHERE o.id IN (
+			SELECT id FROM outbox
+			WHERE published_at IS NULL AND next_attempt_at <= NOW()
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING o.id, o.event_type, o.tenant_id, o.payload, o.attempts, o.created_at`
+	rows,err := s.db.QueryContext(ctx,query,limit,lease.Seconds())
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	messages := []models.OutboxMessage{}
+	for rows.Next() {
+		var msg models.OutboxMessage
+		if err := rows.Scan(&msg.ID,&msg.EventType,&msg.TenantID,&msg.Payload,&msg.Attempts,&msg.CreatedAt); err != nil {
+			return nil,err
+		}
+		messages = append(messages,msg)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	return messages,nil
+}
+
+func (s *Store)MarkPublished(ctx context.Context,ids []uuid.UUID) error {
+	tracer := otel.Tracer("OutboxStore")
+	ctx,span := tracer.Start(ctx, "MarkPublished-Store")
+	defer span.End()
+
+	_,err := s.db.ExecContext(ctx,`UPDATE outbox SET published_at = NOW(), last_error = '' WHERE id = ANY($1)`,pq.Array(ids))
+	return err
+}
+
+// MarkFailed records a failed publish and when to try again.
+func (s *Store)MarkFailed(ctx context.Context,id uuid.UUID,cause string,nextAttemptAt time.Time) error {
+	tracer := otel.Tracer("OutboxStore")
+	ctx,span := tracer.Start(ctx, "MarkFailed-Store")
+	defer span.End()
+
+	_,err := s.db.ExecContext(ctx,`UPDATE outbox SET last_error = $2, next_attempt_at = $3 WHERE id = $1`,id,cause,nextAttemptAt)
+	return err
+}
+
+// PurgePublished deletes messages published before the given time.
+func (s *Store)PurgePublished(ctx context.Context,before time.Time) (int64,error) {
+	tracer := otel.Tracer("OutboxStore")
+	ctx,span := tracer.Start(ctx, "PurgePublished-Store")
+	defer span.End()
+
+	result,err := s.db.ExecContext(ctx,`DELETE FROM outbox WHERE published_at < $1`,before)
+	if err != nil {
+		return 0,err
+	}
+	return result.RowsAffected()
+}
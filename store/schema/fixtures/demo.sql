@@ -0,0 +1,60 @@
+-- Demo data for local development, safe to load more than once.
+
+-- Default dealership owning the demo data
+INSERT INTO dealership (id, name, slug) VALUES
+    ('3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', 'CarZone Demo', 'carzone-demo')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO engine (id, tenant_id, displacement, no_of_cylinders, car_range) VALUES
+    ('e1f86b1a-0873-4c19-bae2-fc60329d0140', '3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', 2000, 4, 600),
+    ('f4a9c66b-8e38-419b-93c4-215d5cefb318', '3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', 1600, 4, 550),
+    ('cc2c2a7d-2e21-4f59-b7b8-bd9e5e4cf04c', '3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', 3000, 6, 700),
+    ('9746be12-07b7-42a3-b8ab-7d1f209b63d7', '3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', 1800, 4, 500)
+ON CONFLICT (id) DO NOTHING;
+-- Electric, no displacement or cylinders
+INSERT INTO engine (id, tenant_id, fuel_type, displacement, no_of_cylinders, car_range, battery_capacity_kwh, motor_power_kw, charging_standard, horsepower, torque_nm) VALUES
+    ('2b7e5d94-6c1f-4a38-8e0d-5f9a3c7b1e62', '3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', 'Electric', 0, 0, 510, 57.50, 208, 'ccs2', 283, 420)
+ON CONFLICT (id) DO NOTHING;
+
+-- Models of the demo cars, their brands come with the 0004 migration
+INSERT INTO car_model (id, brand_id, name)
+SELECT seed.id::uuid, b.id, seed.name
+FROM (VALUES
+    ('6a0d5f3e-2b1c-4e7a-9f8d-3c4b5a6e7d01', 'Honda', 'Civic'),
+    ('6a0d5f3e-2b1c-4e7a-9f8d-3c4b5a6e7d02', 'Toyota', 'Corolla'),
+    ('6a0d5f3e-2b1c-4e7a-9f8d-3c4b5a6e7d03', 'Ford', 'Mustang'),
+    ('6a0d5f3e-2b1c-4e7a-9f8d-3c4b5a6e7d04', 'BMW', '3 Series'),
+    ('6a0d5f3e-2b1c-4e7a-9f8d-3c4b5a6e7d05', 'Tesla', 'Model 3')
+) AS seed(id, brand, name)
+JOIN brand b ON b.name = seed.brand
+ON CONFLICT DO NOTHING;
+INSERT INTO car_model_alias (brand_id, alias_key, alias, model_id)
+SELECT m.brand_id, lower(regexp_replace(m.name, '[^[:alnum:]]', '', 'g')), m.name, m.id
+FROM car_model m
+WHERE m.id IN ('6a0d5f3e-2b1c-4e7a-9f8d-3c4b5a6e7d01', '6a0d5f3e-2b1c-4e7a-9f8d-3c4b5a6e7d02', '6a0d5f3e-2b1c-4e7a-9f8d-3c4b5a6e7d03', '6a0d5f3e-2b1c-4e7a-9f8d-3c4b5a6e7d04', '6a0d5f3e-2b1c-4e7a-9f8d-3c4b5a6e7d05')
+ON CONFLICT DO NOTHING;
+INSERT INTO car_model_alias (brand_id, alias_key, alias, model_id)
+SELECT brand_id, '3er', '3er', id FROM car_model WHERE id = '6a0d5f3e-2b1c-4e7a-9f8d-3c4b5a6e7d04'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO car (id, tenant_id, created_by, name, year, brand, model, fuel_type, engine_id, price, odometer_km, transmission, drivetrain, colour, body_type, condition, features) VALUES
+    ('c7c1a6d5-1ec4-4c64-a59a-8a2f6f3d2bf3', '3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', 'admin', 'Honda Civic', '2023', 'Honda', 'Civic', 'Gasoline', 'e1f86b1a-0873-4c19-bae2-fc60329d0140', 25000.00, 0, 'cvt', 'fwd', 'Silver', 'sedan', 'new', '{"Apple CarPlay","Lane keeping assist"}'),
+    ('9d6a56f8-79c3-4931-a5c0-6b290c84ba2f', '3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', 'admin', 'Toyota Corolla', '2022', 'Toyota', 'Corolla', 'Gasoline', 'f4a9c66b-8e38-419b-93c4-215d5cefb318', 22000.00, 18500, 'cvt', 'fwd', 'White', 'sedan', 'used', '{"Reversing camera"}'),
+    ('9b9437c4-3ed1-45a5-b240-0fe3e24e0e4e', '3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', 'admin', 'Ford Mustang', '2024', 'Ford', 'Mustang', 'Gasoline', 'cc2c2a7d-2e21-4f59-b7b8-bd9e5e4cf04c', 40000.00, 0, 'manual', 'rwd', 'Red', 'coupe', 'new', '{"Heated seats","Premium audio"}'),
+    ('5e9df51a-8d7a-4d84-9c58-4ccfe5c7db06', '3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', 'admin', 'BMW 3 Series', '2023', 'BMW', '3 Series', 'Gasoline', '9746be12-07b7-42a3-b8ab-7d1f209b63d7', 35000.00, 32000, 'automatic', 'rwd', 'Black', 'sedan', 'certified_pre_owned', '{"Heated seats","Navigation"}'),
+    ('a3d8f2c6-4b9e-4f17-8c5a-7e1d6b2f9a40', '3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', 'admin', 'Tesla Model 3', '2024', 'Tesla', 'Model 3', 'Electric', '2b7e5d94-6c1f-4a38-8e0d-5f9a3c7b1e62', 42000.00, 0, 'automatic', 'rwd', 'Blue', 'sedan', 'new', '{"Autopilot","Heat pump"}')
+ON CONFLICT (id) DO NOTHING;
+
+-- Default dealership offers 30 minute test drives on weekdays
+INSERT INTO test_drive_schedule (tenant_id, timezone) VALUES
+    ('3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', 'UTC')
+ON CONFLICT (tenant_id) DO NOTHING;
+INSERT INTO test_drive_slot (id, tenant_id, weekday, opens, closes, slot_minutes)
+SELECT gen_random_uuid(), '3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c', d, '09:00', '17:00', 30
+FROM generate_series(1, 5) AS d
+WHERE NOT EXISTS (SELECT 1 FROM test_drive_slot WHERE tenant_id = '3f6c2b1e-5a4d-4c8e-9b7a-1d2e3f4a5b6c');
+
+-- Platform admin admin/admin, the credentials /login used to hard code
+INSERT INTO app_user (id, username, password_hash, roles) VALUES
+    ('0b8e4c52-6f1d-4a7e-9c3b-2d5f8a1e7c40', 'admin', '$2a$10$h1xu.A6B.SxD92fV7Cg2W.VZhvtz1SGUPdmy/JzFRmyqwXUa3b3k6', '{admin}')
+ON CONFLICT (username) DO NOTHING;
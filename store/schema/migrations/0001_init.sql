@@ -0,0 +1,209 @@
+-- The schema as it was before migrations were versioned. Every statement is
+-- idempotent so databases created by the old boot-time schema.sql adopt it.
+
+-- Create dealership (tenant) table
+CREATE TABLE IF NOT EXISTS dealership (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create engine table
+CREATE TABLE IF NOT EXISTS engine (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES dealership(id),
+    displacement INT NOT NULL,
+    no_of_cylinders INT NOT NULL,
+    car_range INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create car table (with its FK inline)
+CREATE TABLE IF NOT EXISTS car (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES dealership(id),
+    created_by VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    year VARCHAR(4) NOT NULL,
+    brand VARCHAR(255) NOT NULL,
+    fuel_type VARCHAR(50) NOT NULL,
+    engine_id UUID NOT NULL REFERENCES engine(id) ON DELETE CASCADE,
+    price DECIMAL(10, 2) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'available'
+        CHECK (status IN ('draft', 'available', 'reserved', 'sold', 'archived')),
+    reserved_by VARCHAR(255) NOT NULL DEFAULT '',
+    reserved_until TIMESTAMP,
+    sold_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS car_tenant_status_idx ON car (tenant_id, status);
+CREATE INDEX IF NOT EXISTS car_tenant_brand_idx ON car (tenant_id, brand);
+CREATE INDEX IF NOT EXISTS car_created_by_idx ON car (created_by);
+
+-- Create api_key table, only the sha256 of each key is stored
+CREATE TABLE IF NOT EXISTS api_key (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+ALTER TABLE api_key ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES dealership(id);
+
+-- Create test drive schedule tables
+CREATE TABLE IF NOT EXISTS test_drive_schedule (
+    tenant_id UUID PRIMARY KEY REFERENCES dealership(id) ON DELETE CASCADE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE IF NOT EXISTS test_drive_slot (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES test_drive_schedule(tenant_id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    opens TIME NOT NULL,
+    closes TIME NOT NULL,
+    slot_minutes INT NOT NULL CHECK (slot_minutes > 0),
+    CHECK (opens < closes)
+);
+CREATE INDEX IF NOT EXISTS test_drive_slot_tenant_idx ON test_drive_slot (tenant_id, weekday);
+
+-- Create test_drive table, the exclusion constraint backs up the overlap check in the store
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+CREATE TABLE IF NOT EXISTS test_drive (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES dealership(id),
+    car_id UUID NOT NULL REFERENCES car(id) ON DELETE CASCADE,
+    customer_id VARCHAR(255) NOT NULL,
+    customer_name VARCHAR(255) NOT NULL,
+    customer_phone VARCHAR(50) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'booked' CHECK (status IN ('booked', 'cancelled')),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    cancelled_at TIMESTAMPTZ,
+    CHECK (starts_at < ends_at),
+    CONSTRAINT test_drive_no_overlap EXCLUDE USING gist (
+        car_id WITH =,
+        tstzrange(starts_at, ends_at) WITH &&
+    ) WHERE (status = 'booked')
+);
+CREATE INDEX IF NOT EXISTS test_drive_customer_idx ON test_drive (customer_id);
+
+-- Create car_order table, the partial unique index lets a car be in at most one open order
+CREATE SEQUENCE IF NOT EXISTS invoice_number_seq;
+CREATE TABLE IF NOT EXISTS car_order (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES dealership(id),
+    invoice_number VARCHAR(32) NOT NULL UNIQUE,
+    car_id UUID NOT NULL REFERENCES car(id),
+    buyer_id VARCHAR(255) NOT NULL,
+    buyer_name VARCHAR(255) NOT NULL,
+    buyer_email VARCHAR(255) NOT NULL,
+    agreed_price DECIMAL(12, 2) NOT NULL,
+    fees JSONB NOT NULL DEFAULT '[]',
+    fees_total DECIMAL(12, 2) NOT NULL,
+    tax_rate DECIMAL(6, 4) NOT NULL,
+    tax DECIMAL(12, 2) NOT NULL,
+    total DECIMAL(12, 2) NOT NULL,
+    amount_paid DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    payment_status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (payment_status IN ('pending', 'partially_paid', 'paid', 'refunded', 'cancelled')),
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    paid_at TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS car_order_one_sale_idx ON car_order (car_id) WHERE payment_status NOT IN ('cancelled', 'refunded');
+CREATE INDEX IF NOT EXISTS car_order_tenant_idx ON car_order (tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS car_order_buyer_idx ON car_order (buyer_id);
+
+CREATE TABLE IF NOT EXISTS order_payment (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES car_order(id) ON DELETE CASCADE,
+    amount DECIMAL(12, 2) NOT NULL CHECK (amount > 0),
+    method VARCHAR(20) NOT NULL,
+    reference VARCHAR(255) NOT NULL DEFAULT '',
+    recorded_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS order_payment_order_idx ON order_payment (order_id);
+
+-- Create webhook tables
+CREATE TABLE IF NOT EXISTS webhook (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES dealership(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    events TEXT[] NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS webhook_tenant_idx ON webhook (tenant_id) WHERE active;
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id UUID PRIMARY KEY,
+    webhook_id UUID NOT NULL REFERENCES webhook(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ,
+    last_status_code INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    replay_of UUID REFERENCES webhook_delivery(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS webhook_delivery_due_idx ON webhook_delivery (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_delivery_webhook_idx ON webhook_delivery (webhook_id, created_at);
+-- a replayed delivery is a new row, the first delivery of an event to a webhook is unique
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_delivery_event_idx ON webhook_delivery (webhook_id, event_id) WHERE replay_of IS NULL;
+
+-- Create outbox table, written in the same transaction as the change each event describes
+CREATE TABLE IF NOT EXISTS outbox (
+    id UUID PRIMARY KEY,
+    event_type VARCHAR(50) NOT NULL,
+    tenant_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT NOT NULL DEFAULT '',
+    published_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS outbox_unpublished_idx ON outbox (next_attempt_at) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_created_idx ON outbox (created_at, id);
+
+-- Announce each event id on outbox_events once its transaction commits, every
+-- instance listens so car streams see changes made through any of them
+CREATE OR REPLACE FUNCTION notify_outbox_event() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('outbox_events', NEW.id::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS outbox_notify ON outbox;
+CREATE TRIGGER outbox_notify AFTER INSERT ON outbox FOR EACH ROW EXECUTE FUNCTION notify_outbox_event();
+
+-- Create idempotency_key table, the first response to each Idempotency-Key per user
+CREATE TABLE IF NOT EXISTS idempotency_key (
+    user_id VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    body BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
@@ -0,0 +1,11 @@
+-- Create app_user table for local logins, only the bcrypt hash of each password is stored.
+-- Users without a tenant are platform admins.
+CREATE TABLE IF NOT EXISTS app_user (
+    id UUID PRIMARY KEY,
+    username VARCHAR(255) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL,
+    roles TEXT[] NOT NULL,
+    tenant_id UUID REFERENCES dealership(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
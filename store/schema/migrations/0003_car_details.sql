@@ -0,0 +1,20 @@
+-- Listing details of a car. Empty strings are details the dealership hasn't
+-- filled in, existing cars start out with none.
+ALTER TABLE car ADD COLUMN IF NOT EXISTS vin VARCHAR(17);
+ALTER TABLE car ADD COLUMN IF NOT EXISTS odometer_km INTEGER NOT NULL DEFAULT 0
+    CHECK (odometer_km >= 0);
+ALTER TABLE car ADD COLUMN IF NOT EXISTS transmission VARCHAR(20) NOT NULL DEFAULT ''
+    CHECK (transmission IN ('', 'manual', 'automatic', 'cvt', 'dct'));
+ALTER TABLE car ADD COLUMN IF NOT EXISTS drivetrain VARCHAR(20) NOT NULL DEFAULT ''
+    CHECK (drivetrain IN ('', 'fwd', 'rwd', 'awd', '4wd'));
+ALTER TABLE car ADD COLUMN IF NOT EXISTS colour VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE car ADD COLUMN IF NOT EXISTS body_type VARCHAR(20) NOT NULL DEFAULT ''
+    CHECK (body_type IN ('', 'sedan', 'hatchback', 'wagon', 'coupe', 'convertible', 'suv', 'pickup', 'van', 'minivan'));
+ALTER TABLE car ADD COLUMN IF NOT EXISTS condition VARCHAR(20) NOT NULL DEFAULT ''
+    CHECK (condition IN ('', 'new', 'used', 'certified_pre_owned'));
+ALTER TABLE car ADD COLUMN IF NOT EXISTS features TEXT[] NOT NULL DEFAULT '{}';
+
+-- A dealership lists a vehicle once, relisting goes through archived -> draft
+CREATE UNIQUE INDEX IF NOT EXISTS car_tenant_vin_key ON car (tenant_id, vin) WHERE vin IS NOT NULL;
+CREATE INDEX IF NOT EXISTS car_tenant_body_type_idx ON car (tenant_id, body_type);
+CREATE INDEX IF NOT EXISTS car_features_idx ON car USING GIN (features);
@@ -0,0 +1,91 @@
+-- Reference catalogue of brands and models, shared by every dealership. Each
+-- spelling an entry goes by, its own name included, is an alias keyed the
+-- way models.CatalogueKey normalises it, lower case letters and digits only,
+-- so a spelling can only ever resolve to one brand.
+CREATE TABLE IF NOT EXISTS brand (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS brand_alias (
+    alias_key VARCHAR(100) PRIMARY KEY,
+    alias VARCHAR(100) NOT NULL,
+    brand_id UUID NOT NULL REFERENCES brand(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS brand_alias_brand_idx ON brand_alias (brand_id);
+
+CREATE TABLE IF NOT EXISTS car_model (
+    id UUID PRIMARY KEY,
+    brand_id UUID NOT NULL REFERENCES brand(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (brand_id, name)
+);
+
+-- Model spellings only have to be unique within their brand
+CREATE TABLE IF NOT EXISTS car_model_alias (
+    brand_id UUID NOT NULL REFERENCES brand(id) ON DELETE CASCADE,
+    alias_key VARCHAR(100) NOT NULL,
+    alias VARCHAR(100) NOT NULL,
+    model_id UUID NOT NULL REFERENCES car_model(id) ON DELETE CASCADE,
+    PRIMARY KEY (brand_id, alias_key)
+);
+CREATE INDEX IF NOT EXISTS car_model_alias_model_idx ON car_model_alias (model_id);
+
+ALTER TABLE car ADD COLUMN IF NOT EXISTS model VARCHAR(100) NOT NULL DEFAULT '';
+
+-- The brands the VIN decoder knows, spelled the way it spells them
+INSERT INTO brand (id, name)
+SELECT gen_random_uuid(), name FROM (VALUES
+    ('Alfa Romeo'), ('Aston Martin'), ('Audi'), ('BMW'), ('Cadillac'), ('Chevrolet'),
+    ('Chrysler'), ('Citroen'), ('Ferrari'), ('Fiat'), ('Ford'), ('GMC'), ('Honda'),
+    ('Hyundai'), ('Jaguar'), ('Jeep'), ('Kia'), ('Lamborghini'), ('Land Rover'),
+    ('Lexus'), ('Lotus'), ('Mahindra'), ('Mazda'), ('Mercedes-Benz'), ('Mitsubishi'),
+    ('Nissan'), ('Peugeot'), ('Porsche'), ('Ram'), ('Renault'), ('Saab'), ('SEAT'),
+    ('Skoda'), ('smart'), ('Subaru'), ('Tata'), ('Tesla'), ('Toyota'), ('Volkswagen'),
+    ('Volvo')
+) AS seed(name)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO brand_alias (alias_key, alias, brand_id)
+SELECT lower(regexp_replace(name, '[^[:alnum:]]', '', 'g')), name, id FROM brand
+ON CONFLICT (alias_key) DO NOTHING;
+
+INSERT INTO brand_alias (alias_key, alias, brand_id)
+SELECT lower(regexp_replace(seed.alias, '[^[:alnum:]]', '', 'g')), seed.alias, b.id
+FROM (VALUES
+    ('Chevy', 'Chevrolet'), ('Mercedes', 'Mercedes-Benz'), ('Benz', 'Mercedes-Benz'),
+    ('VW', 'Volkswagen'), ('Range Rover', 'Land Rover'), ('Škoda', 'Skoda'),
+    ('Citroën', 'Citroen'), ('Alfa', 'Alfa Romeo')
+) AS seed(alias, brand)
+JOIN brand b ON b.name = seed.brand
+ON CONFLICT (alias_key) DO NOTHING;
+
+-- Brands cars already use that aren't in the seed become brands of their
+-- own, named after their most common spelling
+INSERT INTO brand (id, name)
+SELECT gen_random_uuid(), spelling FROM (
+    SELECT DISTINCT ON (alias_key) alias_key, spelling FROM (
+        SELECT lower(regexp_replace(brand, '[^[:alnum:]]', '', 'g')) AS alias_key, left(btrim(brand), 100) AS spelling, count(*) AS cars
+        FROM car
+        GROUP BY 1, 2
+    ) AS spellings
+    WHERE alias_key <> '' AND NOT EXISTS (SELECT 1 FROM brand_alias a WHERE a.alias_key = spellings.alias_key)
+    ORDER BY alias_key, cars DESC, spelling
+) AS found
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO brand_alias (alias_key, alias, brand_id)
+SELECT lower(regexp_replace(name, '[^[:alnum:]]', '', 'g')), name, id FROM brand
+ON CONFLICT (alias_key) DO NOTHING;
+
+-- Every car takes its brand's catalogue spelling
+UPDATE car c SET brand = b.name, updated_at = CURRENT_TIMESTAMP
+FROM brand_alias a
+JOIN brand b ON b.id = a.brand_id
+WHERE a.alias_key = lower(regexp_replace(c.brand, '[^[:alnum:]]', '', 'g')) AND c.brand <> b.name;
+
+CREATE INDEX IF NOT EXISTS car_tenant_brand_model_idx ON car (tenant_id, brand, model);
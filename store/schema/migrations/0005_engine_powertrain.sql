@@ -0,0 +1,29 @@
+-- Powertrain specs of an engine. Zero and empty strings are specs the
+-- dealership hasn't given, electric engines keep displacement and cylinders
+-- at zero.
+ALTER TABLE engine ADD COLUMN IF NOT EXISTS fuel_type VARCHAR(20) NOT NULL DEFAULT ''
+    CHECK (fuel_type IN ('', 'Persol', 'Diesel', 'Electric', 'Hybrid'));
+ALTER TABLE engine ADD COLUMN IF NOT EXISTS battery_capacity_kwh NUMERIC(6, 2) NOT NULL DEFAULT 0
+    CHECK (battery_capacity_kwh >= 0);
+ALTER TABLE engine ADD COLUMN IF NOT EXISTS motor_power_kw INTEGER NOT NULL DEFAULT 0
+    CHECK (motor_power_kw >= 0);
+ALTER TABLE engine ADD COLUMN IF NOT EXISTS charging_standard VARCHAR(20) NOT NULL DEFAULT ''
+    CHECK (charging_standard IN ('', 'type1', 'type2', 'ccs1', 'ccs2', 'chademo', 'nacs', 'gbt'));
+ALTER TABLE engine ADD COLUMN IF NOT EXISTS horsepower INTEGER NOT NULL DEFAULT 0
+    CHECK (horsepower >= 0);
+ALTER TABLE engine ADD COLUMN IF NOT EXISTS torque_nm INTEGER NOT NULL DEFAULT 0
+    CHECK (torque_nm >= 0);
+ALTER TABLE engine ADD COLUMN IF NOT EXISTS co2_g_per_km INTEGER NOT NULL DEFAULT 0
+    CHECK (co2_g_per_km >= 0);
+
+-- Engines whose cars all burn the same fuel get it as their powertrain.
+-- Hybrids and electric engines are left to the dealership, existing engines
+-- have no battery specs and were never allowed a zero displacement.
+UPDATE engine e SET fuel_type = shared.fuel_type
+FROM (
+    SELECT engine_id, min(fuel_type) AS fuel_type
+    FROM car
+    GROUP BY engine_id
+    HAVING count(DISTINCT fuel_type) = 1
+) AS shared
+WHERE e.id = shared.engine_id AND e.fuel_type = '' AND shared.fuel_type IN ('Persol', 'Diesel');
@@ -0,0 +1,7 @@
+-- An in-flight Idempotency-Key is only held until locked_until, so a request
+-- that died without completing or releasing its key doesn't block retries
+-- until the key expires. Existing in-flight keys are free to claim again.
+ALTER TABLE idempotency_key ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- expired keys are purged in the background
+CREATE INDEX IF NOT EXISTS idempotency_key_expires_at_idx ON idempotency_key (expires_at);
@@ -0,0 +1,39 @@
+-- Car streams resume from seq rather than (created_at, id). created_at is
+-- taken when the writing transaction starts, so an event could commit after
+-- a later one had already been streamed and be skipped by clients resuming
+-- past it. seq is handed out by sequence_outbox once events have committed.
+CREATE SEQUENCE IF NOT EXISTS outbox_seq;
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS seq BIGINT;
+UPDATE outbox o SET seq = numbered.n
+FROM (SELECT id, row_number() OVER (ORDER BY created_at, id) AS n FROM outbox WHERE seq IS NULL) numbered
+WHERE o.id = numbered.id;
+SELECT setval('outbox_seq', (SELECT COALESCE(max(seq), 0) + 1 FROM outbox), false);
+CREATE UNIQUE INDEX IF NOT EXISTS outbox_seq_idx ON outbox (seq);
+CREATE INDEX IF NOT EXISTS outbox_unsequenced_idx ON outbox (created_at, id) WHERE seq IS NULL;
+
+-- Numbers the committed events that have no seq yet and announces each on
+-- outbox_events. The advisory lock is held until the numbering commits, so a
+-- seq only becomes visible once every lower one already is.
+CREATE OR REPLACE FUNCTION sequence_outbox() RETURNS integer AS $$
+DECLARE
+    event RECORD;
+    numbered integer := 0;
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('outbox_seq'));
+    FOR event IN SELECT id FROM outbox WHERE seq IS NULL ORDER BY created_at, id FOR UPDATE LOOP
+        UPDATE outbox SET seq = nextval('outbox_seq') WHERE id = event.id;
+        PERFORM pg_notify('outbox_events', event.id::text);
+        numbered := numbered + 1;
+    END LOOP;
+    RETURN numbered;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Inserts now only wake the listeners on outbox_pending, they announce the
+-- events on outbox_events once sequence_outbox has numbered them.
+CREATE OR REPLACE FUNCTION notify_outbox_event() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('outbox_pending', '');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,7 @@
+-- Petrol was spelled Persol, by car listings from the start and by engine
+-- powertrains since 0005. Both are renamed and engines only accept Petrol.
+ALTER TABLE engine DROP CONSTRAINT IF EXISTS engine_fuel_type_check;
+UPDATE engine SET fuel_type = 'Petrol' WHERE fuel_type = 'Persol';
+UPDATE car SET fuel_type = 'Petrol' WHERE fuel_type = 'Persol';
+ALTER TABLE engine ADD CONSTRAINT engine_fuel_type_check
+    CHECK (fuel_type IN ('', 'Petrol', 'Diesel', 'Electric', 'Hybrid'));
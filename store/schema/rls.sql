@@ -0,0 +1,44 @@
+-- Optional row level security, applied after the migrations when DB_ROW_LEVEL_SECURITY=true.
+-- Policies read app.tenant_id, which the stores set on every transaction,
+-- reads included (see store.SetTenant and store.ReadTx). They fail closed: a
+-- statement without the setting sees no rows, only the explicit platform
+-- setting '*' (platform admins, migrations and fixtures) sees every dealership.
+-- Superusers bypass RLS entirely, so connect as a regular role for this to bite.
+
+-- CASE keeps the '*' marker from ever being cast to uuid.
+CREATE OR REPLACE FUNCTION tenant_visible(row_tenant_id uuid) RETURNS boolean
+    LANGUAGE sql STABLE AS $$
+    SELECT CASE COALESCE(current_setting('app.tenant_id', true), '')
+        WHEN '' THEN false
+        WHEN '*' THEN true
+        ELSE row_tenant_id = current_setting('app.tenant_id', true)::uuid
+    END
+$$;
+
+ALTER TABLE engine ENABLE ROW LEVEL SECURITY;
+ALTER TABLE engine FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS engine_tenant_isolation ON engine;
+CREATE POLICY engine_tenant_isolation ON engine
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id));
+
+ALTER TABLE car ENABLE ROW LEVEL SECURITY;
+ALTER TABLE car FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS car_tenant_isolation ON car;
+CREATE POLICY car_tenant_isolation ON car
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id));
+
+ALTER TABLE test_drive ENABLE ROW LEVEL SECURITY;
+ALTER TABLE test_drive FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS test_drive_tenant_isolation ON test_drive;
+CREATE POLICY test_drive_tenant_isolation ON test_drive
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id));
+
+ALTER TABLE car_order ENABLE ROW LEVEL SECURITY;
+ALTER TABLE car_order FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS car_order_tenant_isolation ON car_order;
+CREATE POLICY car_order_tenant_isolation ON car_order
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id));
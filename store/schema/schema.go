@@ -0,0 +1,216 @@
+// Package schema versions the database. Migrations are applied in file name
+// order and recorded in schema_migrations, fixtures are demo data loaded on
+// request and never by a migration.
+package schema
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/iangechuki/go_carzone/store"
+	"go.opentelemetry.io/otel"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+//go:embed fixtures/*.sql
+var fixtures embed.FS
+
+//go:embed rls.sql
+var rls string
+
+// held while migrating so instances started together don't apply the same migration twice
+const migrationLock = 7261990
+
+// Migrate applies the migrations not yet recorded, each in its own
+// transaction, and returns their names.
+func Migrate(ctx context.Context,db *sql.DB) ([]string,error) {
+	tracer := otel.Tracer("Schema")
+	ctx,span := tracer.Start(ctx, "Migrate-Schema")
+	defer span.End()
+
+	// the advisory lock belongs to a session, so keep to one connection
+	conn,err := db.Conn(ctx)
+	if err != nil {
+		return nil,err
+	}
+	defer conn.Close()
+	if _,err := conn.ExecContext(ctx,`SELECT pg_advisory_lock($1)`,migrationLock); err != nil {
+		return nil,err
+	}
+	defer conn.ExecContext(context.Background(),`SELECT pg_advisory_unlock($1)`,migrationLock)
+
+	_,err = conn.ExecContext(ctx,`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version VARCHAR(255) PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return nil,err
+	}
+	pending,err := pending(ctx,conn)
+	if err != nil {
+		return nil,err
+	}
+	applied := []string{}
+	for _,name := range pending {
+		if err := apply(ctx,conn,name); err != nil {
+			return applied,fmt.Errorf("migration %s: %w",name,err)
+		}
+		applied = append(applied,name)
+	}
+	return applied,nil
+}
+
+// Pending returns the migrations Migrate would apply.
+func Pending(ctx context.Context,db *sql.DB) ([]string,error) {
+	tracer := otel.Tracer("Schema")
+	ctx,span := tracer.Start(ctx, "Pending-Schema")
+	defer span.End()
+
+	conn,err := db.Conn(ctx)
+	if err != nil {
+		return nil,err
+	}
+	defer conn.Close()
+	var exists bool
+	if err := conn.QueryRowContext(ctx,`SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return nil,err
+	}
+	if !exists {
+		return migrationNames()
+	}
+	return pending(ctx,conn)
+}
+
+func pending(ctx context.Context,conn *sql.Conn) ([]string,error) {
+	names,err := migrationNames()
+	if err != nil {
+		return nil,err
+	}
+	rows,err := conn.QueryContext(ctx,`SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	done := make(map[string]bool)
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil,err
+		}
+		done[version] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	todo := []string{}
+	for _,name := range names {
+		if !done[name] {
+			todo = append(todo,name)
+		}
+	}
+	return todo,nil
+}
+
+func apply(ctx context.Context,conn *sql.Conn,name string) (err error) {
+	script,err := migrations.ReadFile(path.Join("migrations",name))
+	if err != nil {
+		return err
+	}
+	tx,err := conn.BeginTx(ctx,nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	// backfills must reach every dealership's rows
+	if err = store.SetPlatform(ctx,tx); err != nil {
+		return err
+	}
+	if _,err = tx.ExecContext(ctx,string(script)); err != nil {
+		return err
+	}
+	if _,err = tx.ExecContext(ctx,`INSERT INTO schema_migrations (version) VALUES ($1)`,name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// migrationNames are the embedded migration files in the order they apply.
+func migrationNames() ([]string,error) {
+	entries,err := fs.ReadDir(migrations,"migrations")
+	if err != nil {
+		return nil,err
+	}
+	names := []string{}
+	for _,entry := range entries {
+		names = append(names,entry.Name())
+	}
+	sort.Strings(names)
+	return names,nil
+}
+
+// ApplyRowLevelSecurity (re)creates the optional tenant isolation policies,
+// it is safe to run after every migration.
+func ApplyRowLevelSecurity(ctx context.Context,db *sql.DB) error {
+	tracer := otel.Tracer("Schema")
+	ctx,span := tracer.Start(ctx, "ApplyRowLevelSecurity-Schema")
+	defer span.End()
+
+	_,err := db.ExecContext(ctx,rls)
+	return err
+}
+
+// Fixtures returns the names of the embedded fixture sets.
+func Fixtures() []string {
+	entries,err := fs.ReadDir(fixtures,"fixtures")
+	if err != nil {
+		return nil
+	}
+	names := []string{}
+	for _,entry := range entries {
+		names = append(names,strings.TrimSuffix(entry.Name(),".sql"))
+	}
+	return names
+}
+
+// Fixture returns the SQL of the named embedded fixture set.
+func Fixture(name string) (string,error) {
+	script,err := fixtures.ReadFile(path.Join("fixtures",name+".sql"))
+	if err != nil {
+		return "",fmt.Errorf("unknown fixture %q, have %s",name,strings.Join(Fixtures(),", "))
+	}
+	return string(script),nil
+}
+
+// Seed runs a fixture script in one transaction, fixtures are written to be
+// loaded more than once.
+func Seed(ctx context.Context,db *sql.DB,script string) error {
+	tracer := otel.Tracer("Schema")
+	ctx,span := tracer.Start(ctx, "Seed-Schema")
+	defer span.End()
+
+	tx,err := db.BeginTx(ctx,nil)
+	if err != nil {
+		return err
+	}
+	if err := store.SetPlatform(ctx,tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _,err := tx.ExecContext(ctx,script); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/iangechuki/go_carzone/auth"
+)
+
+// platformTenant is the app.tenant_id value that lets a transaction see every
+// dealership past the row level security policies in rls.sql.
+const platformTenant = "*"
+
+// SetTenant scopes tx to the caller's dealership so the row level security
+// policies in rls.sql apply. Platform admins get the explicit platform
+// setting, anyone else without a dealership is left unset and sees no rows.
+func SetTenant(ctx context.Context,tx *sql.Tx) error {
+	tenantID := auth.TenantID(ctx)
+	if tenantID.Valid {
+		_,err := tx.ExecContext(ctx,"SELECT set_config('app.tenant_id', $1, true)",tenantID.UUID.String())
+		return err
+	}
+	if principal,ok := auth.PrincipalFromContext(ctx); ok && principal.IsPlatformAdmin() {
+		return SetPlatform(ctx,tx)
+	}
+	return nil
+}
+
+// SetPlatform lifts the row level security policies for tx, for work that
+// isn't done on behalf of a caller such as migrations and fixtures.
+func SetPlatform(ctx context.Context,tx *sql.Tx) error {
+	_,err := tx.ExecContext(ctx,"SELECT set_config('app.tenant_id', $1, true)",platformTenant)
+	return err
+}
+
+// ReadTx begins a read only transaction scoped with SetTenant, so the
+// policies apply to plain reads as well. Callers roll it back when done.
+func ReadTx(ctx context.Context,db *sql.DB) (*sql.Tx,error) {
+	tx,err := db.BeginTx(ctx,&sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil,err
+	}
+	if err := SetTenant(ctx,tx); err != nil {
+		tx.Rollback()
+		return nil,err
+	}
+	return tx,nil
+}
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+)
+
+// recorder is a database/sql driver that records what transactions run, so
+// the tenant scoping can be checked without a database.
+type recorder struct {
+	mu sync.Mutex
+	execs []string
+	readOnly []bool
+}
+
+func (d *recorder)Open(name string) (driver.Conn,error) {
+	return &recordingConn{d: d},nil
+}
+
+func (d *recorder)record(exec string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.execs = append(d.execs,exec)
+}
+
+type recordingConn struct {
+	d *recorder
+}
+
+func (c *recordingConn)Prepare(query string) (driver.Stmt,error) {
+	return nil,errors.New("not supported")
+}
+
+func (c *recordingConn)Close() error {
+	return nil
+}
+
+func (c *recordingConn)Begin() (driver.Tx,error) {
+	return c.BeginTx(context.Background(),driver.TxOptions{})
+}
+
+func (c *recordingConn)BeginTx(ctx context.Context,opts driver.TxOptions) (driver.Tx,error) {
+	c.d.mu.Lock()
+	c.d.readOnly = append(c.d.readOnly,opts.ReadOnly)
+	c.d.mu.Unlock()
+	return recordingTx{},nil
+}
+
+func (c *recordingConn)ExecContext(ctx context.Context,query string,args []driver.NamedValue) (driver.Result,error) {
+	exec := query
+	for _,arg := range args {
+		exec += fmt.Sprintf(" [%v]",arg.Value)
+	}
+	c.d.record(exec)
+	return driver.RowsAffected(1),nil
+}
+
+func (c *recordingConn)QueryContext(ctx context.Context,query string,args []driver.NamedValue) (driver.Rows,error) {
+	return emptyRows{},nil
+}
+
+type recordingTx struct{}
+
+func (recordingTx)Commit() error { return nil }
+func (recordingTx)Rollback() error { return nil }
+
+type emptyRows struct{}
+
+func (emptyRows)Columns() []string { return nil }
+func (emptyRows)Close() error { return nil }
+func (emptyRows)Next(dest []driver.Value) error { return io.EOF }
+
+func newRecordingDB(t *testing.T) (*sql.DB,*recorder) {
+	t.Helper()
+	d := &recorder{}
+	name := "recorder-" + uuid.NewString()
+	sql.Register(name,d)
+	db,err := sql.Open(name,"")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db,d
+}
+
+func TestSetTenant(t *testing.T) {
+	tenantID := uuid.New()
+	tests := []struct {
+		name string
+		principal *auth.Principal
+		want []string
+	}{
+		{
+			name: "dealership",
+			principal: &auth.Principal{UserID: "staff",Roles: []string{"staff"},TenantID: uuid.NullUUID{UUID: tenantID,Valid: true}},
+			want: []string{"SELECT set_config('app.tenant_id', $1, true) [" + tenantID.String() + "]"},
+		},
+		{
+			name: "dealership admin",
+			principal: &auth.Principal{UserID: "owner",Roles: []string{"admin"},TenantID: uuid.NullUUID{UUID: tenantID,Valid: true}},
+			want: []string{"SELECT set_config('app.tenant_id', $1, true) [" + tenantID.String() + "]"},
+		},
+		{
+			name: "platform admin",
+			principal: &auth.Principal{UserID: "root",Roles: []string{"admin"}},
+			want: []string{"SELECT set_config('app.tenant_id', $1, true) [*]"},
+		},
+		// left unset, the policies in rls.sql then show no rows
+		{name: "no dealership",principal: &auth.Principal{UserID: "drifter",Roles: []string{"staff"}}},
+		{name: "anonymous"},
+	}
+	for _,tt := range tests {
+		t.Run(tt.name,func(t *testing.T) {
+			db,rec := newRecordingDB(t)
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = auth.WithPrincipal(ctx,tt.principal)
+			}
+			tx,err := db.BeginTx(ctx,nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tx.Rollback()
+			if err := SetTenant(ctx,tx); err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(rec.execs) != fmt.Sprint(tt.want) {
+				t.Errorf("ran %q, want %q",rec.execs,tt.want)
+			}
+		})
+	}
+}
+
+func TestReadTx(t *testing.T) {
+	db,rec := newRecordingDB(t)
+	tenantID := uuid.New()
+	ctx := auth.WithPrincipal(context.Background(),&auth.Principal{UserID: "staff",TenantID: uuid.NullUUID{UUID: tenantID,Valid: true}})
+	tx,err := ReadTx(ctx,db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Rollback()
+	if len(rec.readOnly) != 1 || !rec.readOnly[0] {
+		t.Errorf("read only = %v, want one read only transaction",rec.readOnly)
+	}
+	want := "SELECT set_config('app.tenant_id', $1, true) [" + tenantID.String() + "]"
+	if len(rec.execs) != 1 || rec.execs[0] != want {
+		t.Errorf("ran %q, want %q",rec.execs,want)
+	}
+}
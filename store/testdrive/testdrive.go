@@ -0,0 +1,260 @@
+package testdrive
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/iangechuki/go_carzone/store"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+const testDriveColumns = `id, tenant_id, car_id, customer_id, customer_name, customer_phone, starts_at, ends_at, status, created_at, cancelled_at`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTestDrive(row scanner) (models.TestDrive,error) {
+	var testDrive models.TestDrive
+	err := row.Scan(
+		&testDrive.ID,
+		&testDrive.TenantID,
+		&testDrive.CarID,
+		&testDrive.CustomerID,
+		&testDrive.CustomerName,
+		&testDrive.CustomerPhone,
+		&testDrive.StartsAt,
+		&testDrive.EndsAt,
+		&testDrive.Status,
+		&testDrive.CreatedAt,
+		&testDrive.CancelledAt,
+	)
+	return testDrive,err
+}
+
+func (s *Store)GetSchedule(ctx context.Context,tenantID uuid.UUID) (models.TestDriveSchedule,error) {
+	tracer := otel.Tracer("TestDriveStore")
+	ctx,span := tracer.Start(ctx, "GetSchedule-Store")
+	defer span.End()
+
+	schedule := models.TestDriveSchedule{Timezone: "UTC",Slots: []models.TestDriveSlot{}}
+	err := s.db.QueryRowContext(ctx,"SELECT timezone FROM test_drive_schedule WHERE tenant_id = $1",tenantID).Scan(&schedule.Timezone)
+	if err != nil {
+		// a dealership that never published a schedule has no slots
+		if errors.Is(err,sql.ErrNoRows) {
+			return schedule,nil
+		}
+		return models.TestDriveSchedule{},err
+	}
+	rows,err := s.db.QueryContext(ctx,`SELECT weekday, to_char(opens, 'HH24:MI'), to_char(closes, 'HH24:MI'), slot_minutes
+	FROM test_drive_slot WHERE tenant_id = $1 ORDER BY weekday, opens`,tenantID)
+	if err != nil {
+		return models.TestDriveSchedule{},err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var slot models.TestDriveSlot
+		if err := rows.Scan(&slot.Weekday,&slot.Opens,&slot.Closes,&slot.SlotMinutes); err != nil {
+			return models.TestDriveSchedule{},err
+		}
+		schedule.Slots = append(schedule.Slots,slot)
+	}
+	if err = rows.Err(); err != nil {
+		return models.TestDriveSchedule{},err
+	}
+	return schedule,nil
+}
+
+// ReplaceSchedule swaps a dealership's weekly slots for the given ones.
+func (s *Store)ReplaceSchedule(ctx context.Context,tenantID uuid.UUID,schedule *models.TestDriveSchedule) (replaced models.TestDriveSchedule,err error) {
+	tracer := otel.Tracer("TestDriveStore")
+	ctx,span := tracer.Start(ctx, "ReplaceSchedule-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.TestDriveSchedule{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	_,err = tx.ExecContext(ctx,`INSERT INTO test_drive_schedule (tenant_id, timezone, updated_at) VALUES ($1, $2, $3)
+	ON CONFLICT (tenant_id) DO UPDATE SET timezone = EXCLUDED.timezone, updated_at = EXCLUDED.updated_at`,tenantID,schedule.Timezone,time.Now())
+	if err != nil {
+		return models.TestDriveSchedule{},err
+	}
+	if _,err = tx.ExecContext(ctx,"DELETE FROM test_drive_slot WHERE tenant_id = $1",tenantID); err != nil {
+		return models.TestDriveSchedule{},err
+	}
+	for _,slot := range schedule.Slots {
+		_,err = tx.ExecContext(ctx,`INSERT INTO test_drive_slot (id, tenant_id, weekday, opens, closes, slot_minutes) VALUES ($1, $2, $3, $4, $5, $6)`,
+			uuid.New(),tenantID,int(slot.Weekday),slot.Opens,slot.Closes,slot.SlotMinutes)
+		if err != nil {
+			return models.TestDriveSchedule{},err
+		}
+	}
+	return *schedule,nil
+}
+
+func (s *Store)GetTestDriveByID(ctx context.Context,id string) (models.TestDrive,error) {
+	tracer := otel.Tracer("TestDriveStore")
+	ctx,span := tracer.Start(ctx, "GetTestDriveByID-Store")
+	defer span.End()
+
+	tx,err := store.ReadTx(ctx,s.db)
+	if err != nil {
+		return models.TestDrive{},err
+	}
+	defer tx.Rollback()
+	row := tx.QueryRowContext(ctx,`SELECT `+testDriveColumns+` FROM test_drive WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`,id,auth.TenantID(ctx))
+	testDrive,err := scanTestDrive(row)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.TestDrive{},models.ErrRecordNotFound
+		}
+		return models.TestDrive{},err
+	}
+	return testDrive,nil
+}
+
+// GetTestDrivesByCar lists a car's bookings overlapping [from, to), only customerID's when it is set.
+func (s *Store)GetTestDrivesByCar(ctx context.Context,carID string,customerID string,from time.Time,to time.Time) ([]models.TestDrive,error) {
+	tracer := otel.Tracer("TestDriveStore")
+	ctx,span := tracer.Start(ctx, "GetTestDrivesByCar-Store")
+	defer span.End()
+
+	query := `SELECT `+testDriveColumns+` FROM test_drive
+	WHERE car_id = $1 AND ($2::uuid IS NULL OR tenant_id = $2) AND ($3 = '' OR customer_id = $3)
+	AND starts_at < $5 AND ends_at > $4
+	ORDER BY starts_at`
+	tx,err := store.ReadTx(ctx,s.db)
+	if err != nil {
+		return nil,err
+	}
+	defer tx.Rollback()
+	rows,err := tx.QueryContext(ctx,query,carID,auth.TenantID(ctx),customerID,from,to)
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	testDrives := []models.TestDrive{}
+	for rows.Next() {
+		testDrive,err := scanTestDrive(rows)
+		if err != nil {
+			return nil,err
+		}
+		testDrives = append(testDrives,testDrive)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	return testDrives,nil
+}
+
+// CreateTestDrive books the car, failing with models.ErrBookingConflict when
+// another booking for the same car overlaps.
+func (s *Store)CreateTestDrive(ctx context.Context,testDrive models.TestDrive) (booking models.TestDrive,err error) {
+	tracer := otel.Tracer("TestDriveStore")
+	ctx,span := tracer.Start(ctx, "CreateTestDrive-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.TestDrive{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.TestDrive{},err
+	}
+	// locking the car serialises bookings for it, the exclusion constraint catches anything else
+	var carID uuid.UUID
+	err = tx.QueryRowContext(ctx,"SELECT id FROM car WHERE id = $1 AND tenant_id = $2 FOR UPDATE",testDrive.CarID,testDrive.TenantID).Scan(&carID)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.TestDrive{},models.ErrRecordNotFound
+		}
+		return models.TestDrive{},err
+	}
+	var overlapping bool
+	err = tx.QueryRowContext(ctx,`SELECT EXISTS (SELECT 1 FROM test_drive WHERE car_id = $1 AND status = 'booked' AND starts_at < $3 AND ends_at > $2)`,
+		testDrive.CarID,testDrive.StartsAt,testDrive.EndsAt).Scan(&overlapping)
+	if err != nil {
+		return models.TestDrive{},err
+	}
+	if overlapping {
+		err = models.ErrBookingConflict
+		return models.TestDrive{},err
+	}
+	row := tx.QueryRowContext(ctx,`INSERT INTO test_drive (id, tenant_id, car_id, customer_id, customer_name, customer_phone, starts_at, ends_at, status, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING `+testDriveColumns,
+		testDrive.ID,testDrive.TenantID,testDrive.CarID,testDrive.CustomerID,testDrive.CustomerName,testDrive.CustomerPhone,
+		testDrive.StartsAt,testDrive.EndsAt,models.TestDriveBooked,time.Now())
+	created,err := scanTestDrive(row)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err,&pqErr) && pqErr.Code == "23P01" {
+			err = models.ErrBookingConflict
+		}
+		return models.TestDrive{},err
+	}
+	return created,nil
+}
+
+// CancelTestDrive cancels a booking that is still booked, models.ErrStatusConflict otherwise.
+func (s *Store)CancelTestDrive(ctx context.Context,id string) (booking models.TestDrive,err error) {
+	tracer := otel.Tracer("TestDriveStore")
+	ctx,span := tracer.Start(ctx, "CancelTestDrive-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.TestDrive{},err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	if err = store.SetTenant(ctx,tx); err != nil {
+		return models.TestDrive{},err
+	}
+	row := tx.QueryRowContext(ctx,`UPDATE test_drive SET status = $2, cancelled_at = $3
+	WHERE id = $1 AND ($4::uuid IS NULL OR tenant_id = $4) AND status = 'booked'
+	RETURNING `+testDriveColumns,id,models.TestDriveCancelled,time.Now(),auth.TenantID(ctx))
+	cancelled,err := scanTestDrive(row)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			err = models.ErrStatusConflict
+		}
+		return models.TestDrive{},err
+	}
+	return cancelled,nil
+}
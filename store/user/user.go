@@ -0,0 +1,63 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+func (s *Store)CreateUser(ctx context.Context,user models.User,passwordHash string) (models.User,error) {
+	tracer := otel.Tracer("UserStore")
+	ctx,span := tracer.Start(ctx, "CreateUser-Store")
+	defer span.End()
+
+	query := `INSERT INTO app_user (id, username, password_hash, roles, tenant_id, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $6)`
+	_,err := s.db.ExecContext(ctx,query,user.ID,user.Username,passwordHash,pq.Array(user.Roles),user.TenantID,user.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err,&pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "app_user_username_key" {
+			return models.User{},models.ErrUsernameTaken
+		}
+		return models.User{},err
+	}
+	return user,nil
+}
+
+// GetUserByUsername returns the user along with their password hash.
+func (s *Store)GetUserByUsername(ctx context.Context,username string) (models.User,string,error) {
+	tracer := otel.Tracer("UserStore")
+	ctx,span := tracer.Start(ctx, "GetUserByUsername-Store")
+	defer span.End()
+
+	var user models.User
+	var passwordHash string
+	err := s.db.QueryRowContext(ctx,`SELECT id, username, password_hash, roles, tenant_id, created_at FROM app_user WHERE username = $1`,username).Scan(
+		&user.ID,
+		&user.Username,
+		&passwordHash,
+		pq.Array(&user.Roles),
+		&user.TenantID,
+		&user.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.User{},"",models.ErrRecordNotFound
+		}
+		return models.User{},"",err
+	}
+	return user,passwordHash,nil
+}
@@ -0,0 +1,267 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iangechuki/go_carzone/auth"
+	"github.com/iangechuki/go_carzone/models"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+const webhookColumns = `w.id, w.tenant_id, w.url, w.events, w.secret, w.active, w.created_by, w.created_at`
+
+const deliveryColumns = `d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at,
+	d.last_status_code, d.last_error, d.replay_of, d.created_at, d.delivered_at`
+
+func scanWebhook(row interface{ Scan(dest ...any) error }) (models.Webhook,error) {
+	var webhook models.Webhook
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.TenantID,
+		&webhook.URL,
+		pq.Array(&webhook.Events),
+		&webhook.Secret,
+		&webhook.Active,
+		&webhook.CreatedBy,
+		&webhook.CreatedAt,
+	)
+	return webhook,err
+}
+
+func deliveryFields(delivery *models.WebhookDelivery) []any {
+	return []any{
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.ReplayOf,
+		&delivery.CreatedAt,
+		&delivery.DeliveredAt,
+	}
+}
+
+func (s *Store)CreateWebhook(ctx context.Context,webhook models.Webhook) (models.Webhook,error) {
+	tracer := otel.Tracer("WebhookStore")
+	ctx,span := tracer.Start(ctx, "CreateWebhook-Store")
+	defer span.End()
+
+	query := `INSERT INTO webhook (id, tenant_id, url, events, secret, active, created_by, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_,err := s.db.ExecContext(ctx,query,webhook.ID,webhook.TenantID,webhook.URL,pq.Array(webhook.Events),webhook.Secret,webhook.Active,webhook.CreatedBy,webhook.CreatedAt)
+	if err != nil {
+		return models.Webhook{},err
+	}
+	return webhook,nil
+}
+
+func (s *Store)GetWebhookByID(ctx context.Context,id string) (models.Webhook,error) {
+	tracer := otel.Tracer("WebhookStore")
+	ctx,span := tracer.Start(ctx, "GetWebhookByID-Store")
+	defer span.End()
+
+	row := s.db.QueryRowContext(ctx,`SELECT `+webhookColumns+` FROM webhook w WHERE w.id = $1 AND ($2::uuid IS NULL OR w.tenant_id = $2)`,id,auth.TenantID(ctx))
+	webhook,err := scanWebhook(row)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.Webhook{},models.ErrRecordNotFound
+		}
+		return models.Webhook{},err
+	}
+	return webhook,nil
+}
+
+func (s *Store)ListWebhooks(ctx context.Context) ([]models.Webhook,error) {
+	tracer := otel.Tracer("WebhookStore")
+	ctx,span := tracer.Start(ctx, "ListWebhooks-Store")
+	defer span.End()
+
+	return s.listWebhooks(ctx,`SELECT `+webhookColumns+` FROM webhook w WHERE ($1::uuid IS NULL OR w.tenant_id = $1) ORDER BY w.created_at`,auth.TenantID(ctx))
+}
+
+// ListActiveWebhooks lists the webhooks that receive the dealership's events.
+func (s *Store)ListActiveWebhooks(ctx context.Context,tenantID uuid.UUID) ([]models.Webhook,error) {
+	tracer := otel.Tracer("WebhookStore")
+	ctx,span := tracer.Start(ctx, "ListActiveWebhooks-Store")
+	defer span.End()
+
+	return s.listWebhooks(ctx,`SELECT `+webhookColumns+` FROM webhook w WHERE w.tenant_id = $1 AND w.active`,tenantID)
+}
+
+func (s *Store)listWebhooks(ctx context.Context,query string,args ...any) ([]models.Webhook,error) {
+	rows,err := s.db.QueryContext(ctx,query,args...)
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		webhook,err := scanWebhook(rows)
+		if err != nil {
+			return nil,err
+		}
+		webhooks = append(webhooks,webhook)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	return webhooks,nil
+}
+
+func (s *Store)DeleteWebhook(ctx context.Context,id string) (models.Webhook,error) {
+	tracer := otel.Tracer("WebhookStore")
+	ctx,span := tracer.Start(ctx, "DeleteWebhook-Store")
+	defer span.End()
+
+	row := s.db.QueryRowContext(ctx,`DELETE FROM webhook w WHERE w.id = $1 AND ($2::uuid IS NULL OR w.tenant_id = $2) RETURNING `+webhookColumns,id,auth.TenantID(ctx))
+	webhook,err := scanWebhook(row)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.Webhook{},models.ErrRecordNotFound
+		}
+		return models.Webhook{},err
+	}
+	return webhook,nil
+}
+
+// CreateDeliveries queues deliveries, an event already queued for a webhook is skipped.
+func (s *Store)CreateDeliveries(ctx context.Context,deliveries []models.WebhookDelivery) (err error) {
+	tracer := otel.Tracer("WebhookStore")
+	ctx,span := tracer.Start(ctx, "CreateDeliveries-Store")
+	defer span.End()
+
+	tx,err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func(){
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	for _,d := range deliveries {
+		_,err = tx.ExecContext(ctx,`INSERT INTO webhook_delivery (id, webhook_id, event_id, event_type, payload, status, next_attempt_at, replay_of, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (webhook_id, event_id) WHERE replay_of IS NULL DO NOTHING`,d.ID,d.WebhookID,d.EventID,d.EventType,[]byte(d.Payload),d.Status,d.NextAttemptAt,d.ReplayOf,d.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store)GetDeliveryByID(ctx context.Context,id string) (models.WebhookDelivery,error) {
+	tracer := otel.Tracer("WebhookStore")
+	ctx,span := tracer.Start(ctx, "GetDeliveryByID-Store")
+	defer span.End()
+
+	var delivery models.WebhookDelivery
+	err := s.db.QueryRowContext(ctx,`SELECT `+deliveryColumns+` FROM webhook_delivery d JOIN webhook w ON w.id = d.webhook_id
+	WHERE d.id = $1 AND ($2::uuid IS NULL OR w.tenant_id = $2)`,id,auth.TenantID(ctx)).Scan(deliveryFields(&delivery)...)
+	if err != nil {
+		if errors.Is(err,sql.ErrNoRows) {
+			return models.WebhookDelivery{},models.ErrRecordNotFound
+		}
+		return models.WebhookDelivery{},err
+	}
+	return delivery,nil
+}
+
+// ListDeliveries is the delivery log of a webhook, newest first.
+func (s *Store)ListDeliveries(ctx context.Context,webhookID string,limit int) ([]models.WebhookDelivery,error) {
+	tracer := otel.Tracer("WebhookStore")
+	ctx,span := tracer.Start(ctx, "ListDeliveries-Store")
+	defer span.End()
+
+	rows,err := s.db.QueryContext(ctx,`SELECT `+deliveryColumns+` FROM webhook_delivery d JOIN webhook w ON w.id = d.webhook_id
+	WHERE d.webhook_id = $1 AND ($2::uuid IS NULL OR w.tenant_id = $2)
+	ORDER BY d.created_at DESC LIMIT $3`,webhookID,auth.TenantID(ctx),limit)
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := rows.Scan(deliveryFields(&delivery)...); err != nil {
+			return nil,err
+		}
+		deliveries = append(deliveries,delivery)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	return deliveries,nil
+}
+
+// ClaimDueDeliveries leases up to limit due deliveries to the caller. A leased
+// delivery is not due again until lease has passed, so one the dispatcher
+// never reports back on is retried rather than lost. SKIP LOCKED lets several
+// replicas dispatch side by side.
+func (s *Store)ClaimDueDeliveries(ctx context.Context,limit int,lease time.Duration) ([]models.WebhookDelivery,error) {
+	tracer := otel.Tracer("WebhookStore")
+	ctx,span := tracer.Start(ctx, "ClaimDueDeliveries-Store")
+	defer span.End()
+
+	query := `
+		UPDATE webhook_delivery d SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM webhook w
+		WHERE w.id = d.webhook_id AND d.id IN (
+			SELECT id FROM webhook_delivery
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+deliveryColumns+`, w.url, w.secret`
+	rows,err := s.db.QueryContext(ctx,query,limit,lease.Seconds())
+	if err != nil {
+		return nil,err
+	}
+	defer rows.Close()
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := rows.Scan(append(deliveryFields(&delivery),&delivery.URL,&delivery.Secret)...); err != nil {
+			return nil,err
+		}
+		deliveries = append(deliveries,delivery)
+	}
+	if err = rows.Err(); err != nil {
+		return nil,err
+	}
+	return deliveries,nil
+}
+
+// RecordAttempt saves the outcome of sending a delivery.
+func (s *Store)RecordAttempt(ctx context.Context,delivery models.WebhookDelivery) error {
+	tracer := otel.Tracer("WebhookStore")
+	ctx,span := tracer.Start(ctx, "RecordAttempt-Store")
+	defer span.End()
+
+	_,err := s.db.ExecContext(ctx,`UPDATE webhook_delivery SET status = $2, attempts = $3, next_attempt_at = $4,
+		last_status_code = $5, last_error = $6, delivered_at = $7
+	WHERE id = $1`,delivery.ID,delivery.Status,delivery.Attempts,delivery.NextAttemptAt,delivery.LastStatusCode,delivery.LastError,delivery.DeliveredAt)
+	return err
+}